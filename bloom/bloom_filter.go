@@ -9,17 +9,47 @@ import (
 // BloomFilter 实现布隆过滤器数据结构
 // 用于高效判断元素是否存在于集合中，存在一定的误判率但不会漏判
 type BloomFilter struct {
-	bits  []uint64  // 位数组，使用uint64切片存储以提高空间效率
-	k     int       // 哈希函数数量
-	m     int       // 位数组总位数
-	hashes []func([]byte) uint64 // 哈希函数列表
+	bits   []uint64                      // 位数组，使用uint64切片存储以提高空间效率
+	k      int                           // 哈希函数数量
+	m      int                           // 位数组总位数
+	hasher func([]byte) (uint64, uint64) // 基础哈希函数，返回两个独立的哈希值
+}
+
+// Option 定义布隆过滤器的配置选项函数类型
+type Option func(*bloomOptions)
+
+// bloomOptions 布隆过滤器的配置选项
+type bloomOptions struct {
+	hasher func([]byte) (uint64, uint64) // 基础哈希函数
+}
+
+// WithHasher 设置基础哈希函数，用于替换默认的FNV哈希
+// 可接入xxhash、murmur3等更快的哈希算法，需返回两个相互独立的64位哈希值
+// 第i个哈希函数按Kirsch-Mitzenmacher双重哈希计算：h1 + i*h2
+// fn: 基础哈希函数，为nil时使用默认的FNV哈希
+func WithHasher(fn func([]byte) (uint64, uint64)) Option {
+	return func(o *bloomOptions) {
+		o.hasher = fn
+	}
+}
+
+// fnvHasher 默认的基础哈希函数，使用FNV-1a和FNV-1两种算法生成两个哈希值
+func fnvHasher(data []byte) (uint64, uint64) {
+	h1 := fnv.New64a()
+	h1.Write(data)
+
+	h2 := fnv.New64()
+	h2.Write(data)
+
+	return h1.Sum64(), h2.Sum64()
 }
 
 // NewBloomFilter 创建一个新的布隆过滤器
 // n: 预期元素数量
 // p: 可接受的误判率(0 < p < 1)
+// options: 可选配置，如WithHasher
 // 返回布隆过滤器实例和可能的错误
-func NewBloomFilter(n int, p float64) (*BloomFilter, error) {
+func NewBloomFilter(n int, p float64, options ...Option) (*BloomFilter, error) {
 	if n <= 0 {
 		return nil, errors.New("预期元素数量n必须大于0")
 	}
@@ -27,9 +57,41 @@ func NewBloomFilter(n int, p float64) (*BloomFilter, error) {
 		return nil, errors.New("误判率p必须在(0, 1)范围内")
 	}
 
-	// 计算最优位数组大小m和哈希函数数量k
-	m := int(-float64(n) * math.Log(p) / (math.Log(2) * math.Log(2)))
-	k := int(math.Round(float64(m) / float64(n) * math.Log(2)))
+	m, k := OptimalParams(n, p)
+
+	// 初始化位数组，向上取整到uint64的倍数
+	bits := make([]uint64, (m+63)/64)
+
+	opts := bloomOptions{
+		hasher: fnvHasher, // 默认使用FNV哈希
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+	if opts.hasher == nil {
+		opts.hasher = fnvHasher
+	}
+
+	return &BloomFilter{
+		bits:   bits,
+		k:      k,
+		m:      m,
+		hasher: opts.hasher,
+	}, nil
+}
+
+// OptimalParams 计算给定预期元素数量和误判率下的最优位数组大小m和哈希函数数量k
+// 与NewBloomFilter内部使用的公式一致，可在创建过滤器前评估不同配置
+// n: 预期元素数量
+// p: 可接受的误判率(0 < p < 1)
+// 参数非法时返回(0, 0)，否则m和k至少为1
+func OptimalParams(n int, p float64) (m, k int) {
+	if n <= 0 || p <= 0 || p >= 1 {
+		return 0, 0
+	}
+
+	m = int(-float64(n) * math.Log(p) / (math.Log(2) * math.Log(2)))
+	k = int(math.Round(float64(m) / float64(n) * math.Log(2)))
 
 	// 确保m和k至少为1
 	if m <= 0 {
@@ -38,43 +100,25 @@ func NewBloomFilter(n int, p float64) (*BloomFilter, error) {
 	if k <= 0 {
 		k = 1
 	}
+	return m, k
+}
 
-	// 初始化位数组，向上取整到uint64的倍数
-	bits := make([]uint64, (m+63)/64)
-
-	// 创建哈希函数列表 - 使用双重哈希策略确保独立性
-	hashes := make([]func([]byte) uint64, k)
-	for i := 0; i < k; i++ {
-		// 捕获循环变量i的值，避免闭包引用问题
-		seed := i
-		hashes[i] = func(data []byte) uint64 {
-			// 使用两种不同的哈希算法生成基础哈希值
-			h1 := fnv.New64a()
-			h1.Write(data)
-			hash1 := h1.Sum64()
-
-			h2 := fnv.New64()
-			h2.Write(data)
-			hash2 := h2.Sum64()
-
-			// 结合种子生成独立的哈希函数
-			return hash1 + uint64(seed)*hash2
-		}
-	}
-
-	return &BloomFilter{
-		bits:  bits,
-		k:     k,
-		m:     m,
-		hashes: hashes,
-	}, nil
+// EstimateMemoryBytes 估算给定预期元素数量和误判率下位数组占用的字节数
+// 位数组按uint64向上取整，不包含结构体本身的少量开销
+// n: 预期元素数量
+// p: 可接受的误判率(0 < p < 1)
+// 参数非法时返回0
+func EstimateMemoryBytes(n int, p float64) int {
+	m, _ := OptimalParams(n, p)
+	return (m + 63) / 64 * 8
 }
 
 // Add 将元素添加到布隆过滤器
 // data: 要添加的元素字节表示
 func (bf *BloomFilter) Add(data []byte) {
-	for _, hash := range bf.hashes {
-		idx := hash(data) % uint64(bf.m)
+	hash1, hash2 := bf.hasher(data)
+	for i := 0; i < bf.k; i++ {
+		idx := bf.index(hash1, hash2, i)
 		bf.bits[idx/64] |= 1 << (idx % 64)
 	}
 }
@@ -82,8 +126,9 @@ func (bf *BloomFilter) Add(data []byte) {
 // Contains 检查元素是否可能存在于布隆过滤器中
 // 返回true表示可能存在(有一定误判率)，返回false表示一定不存在
 func (bf *BloomFilter) Contains(data []byte) bool {
-	for _, hash := range bf.hashes {
-		idx := hash(data) % uint64(bf.m)
+	hash1, hash2 := bf.hasher(data)
+	for i := 0; i < bf.k; i++ {
+		idx := bf.index(hash1, hash2, i)
 		if (bf.bits[idx/64] & (1 << (idx % 64))) == 0 {
 			return false
 		}
@@ -91,7 +136,45 @@ func (bf *BloomFilter) Contains(data []byte) bool {
 	return true
 }
 
+// AddAll 批量将元素添加到布隆过滤器
+// items: 要添加的元素字节表示列表
+func (bf *BloomFilter) AddAll(items [][]byte) {
+	for _, item := range items {
+		bf.Add(item)
+	}
+}
+
+// ContainsAll 检查所有元素是否都可能存在于布隆过滤器中
+// 返回true表示每个元素都可能存在，返回false表示至少有一个元素一定不存在
+// 空列表返回true
+func (bf *BloomFilter) ContainsAll(items [][]byte) bool {
+	for _, item := range items {
+		if !bf.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsAny 检查是否有任意元素可能存在于布隆过滤器中
+// 返回true表示至少有一个元素可能存在，返回false表示所有元素都一定不存在
+// 空列表返回false
+func (bf *BloomFilter) ContainsAny(items [][]byte) bool {
+	for _, item := range items {
+		if bf.Contains(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// index 使用双重哈希策略计算第i个哈希函数对应的位索引
+// 只需计算一次基础哈希即可模拟k个独立的哈希函数
+func (bf *BloomFilter) index(hash1, hash2 uint64, i int) uint64 {
+	return (hash1 + uint64(i)*hash2) % uint64(bf.m)
+}
+
 // Reset 重置布隆过滤器，清除所有元素
 func (bf *BloomFilter) Reset() {
 	bf.bits = make([]uint64, len(bf.bits))
-}
\ No newline at end of file
+}
@@ -116,6 +116,99 @@ func TestBloomFilter_FalsePositive(t *testing.T) {
 	}
 }
 
+// TestBloomFilter_WithHasher 测试自定义哈希函数仍能正确判断且不漏判
+func TestBloomFilter_WithHasher(t *testing.T) {
+	calls := 0
+	bf, err := NewBloomFilter(1000, 0.01, WithHasher(func(data []byte) (uint64, uint64) {
+		calls++
+		return testHasher(data)
+	}))
+	if err != nil {
+		t.Fatalf("创建布隆过滤器失败: %v", err)
+	}
+
+	for i := 0; i < 1000; i++ {
+		bf.Add([]byte(fmt.Sprintf("custom_%d", i)))
+	}
+	for i := 0; i < 1000; i++ {
+		if !bf.Contains([]byte(fmt.Sprintf("custom_%d", i))) {
+			t.Fatalf("元素 custom_%d 应该存在，但未检测到", i)
+		}
+	}
+	if calls != 2000 {
+		t.Errorf("自定义哈希函数调用次数 = %d; 期望 2000", calls)
+	}
+
+	falsePositives := 0
+	for i := 0; i < 10000; i++ {
+		if bf.Contains([]byte(fmt.Sprintf("absent_%d", i))) {
+			falsePositives++
+		}
+	}
+	if actualP := float64(falsePositives) / 10000; actualP > 0.02 {
+		t.Errorf("误判率超出预期: 预期%.4f, 实际%.4f", 0.01, actualP)
+	}
+
+	// 传入nil时回退到默认哈希函数
+	bf, err = NewBloomFilter(100, 0.01, WithHasher(nil))
+	if err != nil {
+		t.Fatalf("创建布隆过滤器失败: %v", err)
+	}
+	bf.Add([]byte("test"))
+	if !bf.Contains([]byte("test")) {
+		t.Error("添加元素后检测失败")
+	}
+}
+
+// testHasher 基于splitmix64的简单哈希，用于测试和基准对比
+func testHasher(data []byte) (uint64, uint64) {
+	var h uint64 = 0x9e3779b97f4a7c15
+	for _, c := range data {
+		h ^= uint64(c)
+		h *= 0xbf58476d1ce4e5b9
+		h ^= h >> 31
+	}
+	h2 := h * 0x94d049bb133111eb
+	h2 ^= h2 >> 29
+	return h, h2 | 1
+}
+
+// TestBloomFilter_Batch 测试批量添加和查询功能
+func TestBloomFilter_Batch(t *testing.T) {
+	bf, err := NewBloomFilter(1000, 0.001)
+	if err != nil {
+		t.Fatalf("创建布隆过滤器失败: %v", err)
+	}
+
+	items := make([][]byte, 100)
+	for i := range items {
+		items[i] = []byte(fmt.Sprintf("batch_%d", i))
+	}
+	bf.AddAll(items)
+
+	if !bf.ContainsAll(items) {
+		t.Error("AddAll后ContainsAll应返回true")
+	}
+	if !bf.ContainsAll(nil) {
+		t.Error("空列表的ContainsAll应返回true")
+	}
+
+	withAbsent := append(append([][]byte{}, items...), []byte("never_added"))
+	if bf.ContainsAll(withAbsent) {
+		t.Error("包含未添加元素时ContainsAll应返回false")
+	}
+
+	if !bf.ContainsAny([][]byte{[]byte("never_added"), items[0]}) {
+		t.Error("包含已添加元素时ContainsAny应返回true")
+	}
+	if bf.ContainsAny([][]byte{[]byte("never_added"), []byte("also_absent")}) {
+		t.Error("全部未添加时ContainsAny应返回false")
+	}
+	if bf.ContainsAny(nil) {
+		t.Error("空列表的ContainsAny应返回false")
+	}
+}
+
 // TestBloomFilter_Reset 测试重置功能
 func TestBloomFilter_Reset(t *testing.T) {
 	bf, err := NewBloomFilter(100, 0.01)
@@ -137,6 +230,47 @@ func TestBloomFilter_Reset(t *testing.T) {
 	}
 }
 
+// TestOptimalParams 测试最优参数计算与构造函数保持一致
+func TestOptimalParams(t *testing.T) {
+	cases := []struct {
+		n int
+		p float64
+		m int
+		k int
+	}{
+		{n: 1000, p: 0.01, m: 9585, k: 7},
+		{n: 100, p: 0.001, m: 1437, k: 10},
+		{n: 1000000, p: 0.05, m: 6235224, k: 4},
+		{n: 1, p: 0.5, m: 1, k: 1},
+	}
+
+	for _, c := range cases {
+		m, k := OptimalParams(c.n, c.p)
+		if m != c.m || k != c.k {
+			t.Errorf("OptimalParams(%d, %v) = (%d, %d); 期望 (%d, %d)", c.n, c.p, m, k, c.m, c.k)
+		}
+
+		bf, err := NewBloomFilter(c.n, c.p)
+		if err != nil {
+			t.Fatalf("创建布隆过滤器失败: %v", err)
+		}
+		if bf.m != m || bf.k != k {
+			t.Errorf("NewBloomFilter(%d, %v) 参数为 m=%d, k=%d; 期望与OptimalParams一致 m=%d, k=%d", c.n, c.p, bf.m, bf.k, m, k)
+		}
+		if got := EstimateMemoryBytes(c.n, c.p); got != len(bf.bits)*8 {
+			t.Errorf("EstimateMemoryBytes(%d, %v) = %d; 期望 %d", c.n, c.p, got, len(bf.bits)*8)
+		}
+	}
+
+	// 测试无效参数
+	if m, k := OptimalParams(0, 0.01); m != 0 || k != 0 {
+		t.Errorf("OptimalParams(0, 0.01) = (%d, %d); 期望 (0, 0)", m, k)
+	}
+	if got := EstimateMemoryBytes(1000, 1); got != 0 {
+		t.Errorf("EstimateMemoryBytes(1000, 1) = %d; 期望 0", got)
+	}
+}
+
 // BenchmarkBloomFilter_Add 基准测试添加元素性能
 func BenchmarkBloomFilter_Add(b *testing.B) {
 	bf, err := NewBloomFilter(1000000, 0.01)
@@ -187,3 +321,30 @@ func BenchmarkBloomFilter_HighLoad(b *testing.B) {
 		bf.Contains(testData)
 	}
 }
+
+// BenchmarkBloomFilter_Hasher 对比默认FNV与自定义哈希函数在较大键上的性能
+func BenchmarkBloomFilter_Hasher(b *testing.B) {
+	data := make([]byte, 1024)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	hashers := []struct {
+		name    string
+		options []Option
+	}{
+		{"fnv", nil},
+		{"custom", []Option{WithHasher(testHasher)}},
+	}
+	for _, h := range hashers {
+		b.Run(h.name, func(b *testing.B) {
+			bf, err := NewBloomFilter(1000000, 0.01, h.options...)
+			if err != nil {
+				b.Fatalf("创建布隆过滤器失败: %v", err)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				bf.Add(data)
+				bf.Contains(data)
+			}
+		})
+	}
+}
@@ -1,5 +1,24 @@
 package cache
 
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrKeyNotFound 表示缓存中不存在该键
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrKeyExpired 表示键存在但已过期
+	ErrKeyExpired = errors.New("key expired")
+	// ErrKeyNegative 表示命中负缓存，即已缓存该键不存在这一结果
+	ErrKeyNegative = errors.New("key cached as absent")
+	// ErrReadOnly 表示对只读视图执行了写操作，启用WithPanicOnWrite时作为panic的值
+	ErrReadOnly = errors.New("cache is read-only")
+)
+
+// Cache 缓存的通用接口
+// 各缓存的Clone方法返回自身的具体类型而不是Cache[K, V]：副本通常还要使用具体类型特有的方法
+// （如TimedCache.SetWithTTL），并且LoadingCache、CounterCache的方法签名与本接口不同，无法统一返回Cache[K, V]
 type Cache[K comparable, V any] interface {
 	// Get 获取缓存中key对应的值，如果不存在返回false
 	Get(key K) (value V, exists bool)
@@ -11,4 +30,37 @@ type Cache[K comparable, V any] interface {
 	Len() int
 	// Clear 清空缓存中的所有元素
 	Clear()
+}
+
+// ConditionalSetter 支持条件写入的缓存，本包中的所有缓存均实现了该接口
+// 对于带过期时间的缓存，已过期的键视为不存在
+type ConditionalSetter[K comparable, V any] interface {
+	// SetIfAbsent 仅当key不存在时写入，返回是否写入
+	SetIfAbsent(key K, value V) bool
+	// SetIfPresent 仅当key已存在时更新，返回是否更新
+	SetIfPresent(key K, value V) bool
+}
+
+// Ranger 支持遍历全部条目的缓存，由LRUCache和TimedCache实现
+type Ranger[K comparable, V any] interface {
+	// Range 依次对每个有效条目调用fn，fn返回false时停止遍历
+	Range(fn func(key K, value V) bool)
+}
+
+// GetByPrefix 返回键以prefix开头的所有条目，适用于"app.db.host"这类分层命名的字符串键
+// 通过Range在持有读锁的情况下遍历全部条目，时间复杂度为O(n)，不会改变条目的访问顺序
+// 参数:
+//   c: 要查询的缓存
+//   prefix: 键前缀，为空时返回全部条目
+// 返回值:
+//   map[K]V: 匹配条目的独立副本，没有匹配时返回空map
+func GetByPrefix[K ~string, V any](c Ranger[K, V], prefix K) map[K]V {
+	result := make(map[K]V)
+	c.Range(func(key K, value V) bool {
+		if strings.HasPrefix(string(key), string(prefix)) {
+			result[key] = value
+		}
+		return true
+	})
+	return result
 }
\ No newline at end of file
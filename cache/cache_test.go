@@ -0,0 +1,191 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// conditionalCaseCache 条件写入测试使用的缓存，Get统一为两个返回值
+type conditionalCaseCache struct {
+	ConditionalSetter[string, int]
+	get func(key string) (int, bool)
+}
+
+// conditionalCases 返回实现ConditionalSetter的各缓存的构造函数
+func conditionalCases(t *testing.T) map[string]func() conditionalCaseCache {
+	t.Helper()
+	must := func(err error) {
+		if err != nil {
+			t.Fatalf("创建缓存失败: %v", err)
+		}
+	}
+	return map[string]func() conditionalCaseCache{
+		"FIFO": func() conditionalCaseCache {
+			c, err := NewFIFOCache[string, int](10)
+			must(err)
+			return conditionalCaseCache{c, c.Get}
+		},
+		"ExpiringFIFO": func() conditionalCaseCache {
+			c, err := NewExpiringFIFOCache[string, int](10, time.Minute)
+			must(err)
+			return conditionalCaseCache{c, c.Get}
+		},
+		"LRU": func() conditionalCaseCache {
+			c, err := NewLRUCache[string, int](10)
+			must(err)
+			return conditionalCaseCache{c, c.Get}
+		},
+		"LFU": func() conditionalCaseCache {
+			c, err := NewLFUCache[string, int](10)
+			must(err)
+			return conditionalCaseCache{c, c.Get}
+		},
+		"Sized": func() conditionalCaseCache {
+			c, err := NewSizedCache[string, int](100, func(string, int) int64 { return 1 })
+			must(err)
+			return conditionalCaseCache{c, c.Get}
+		},
+		"TinyLFU": func() conditionalCaseCache {
+			c, err := NewTinyLFUCache[string, int](100)
+			must(err)
+			return conditionalCaseCache{c, c.Get}
+		},
+		"Timed": func() conditionalCaseCache {
+			c, err := NewTimedCache[string, int](10, time.Minute)
+			must(err)
+			return conditionalCaseCache{c, c.Get}
+		},
+		"Loading": func() conditionalCaseCache {
+			c, err := NewLoadingCache[string, int](10, time.Minute, func(context.Context, string) (int, error) {
+				return 0, ErrKeyNotFound
+			})
+			must(err)
+			return conditionalCaseCache{c, func(key string) (int, bool) {
+				value, exists, _ := c.Get(key)
+				return value, exists
+			}}
+		},
+		"Tagged": func() conditionalCaseCache {
+			c, err := NewTaggedCache[string, int](10, time.Minute)
+			must(err)
+			return conditionalCaseCache{c, c.Get}
+		},
+		"Tiered": func() conditionalCaseCache {
+			l1, err := NewLRUCache[string, int](2)
+			must(err)
+			l2, err := NewTimedCache[string, int](10, time.Minute)
+			must(err)
+			c, err := NewTieredCache[string, int](l1, l2)
+			must(err)
+			return conditionalCaseCache{c, c.Get}
+		},
+	}
+}
+
+// TestConditionalSetter 测试各缓存的SetIfAbsent和SetIfPresent语义
+func TestConditionalSetter(t *testing.T) {
+	for name, newCache := range conditionalCases(t) {
+		t.Run(name, func(t *testing.T) {
+			c := newCache()
+
+			if c.SetIfPresent("a", 1) {
+				t.Error("SetIfPresent(a) 键不存在时应返回false")
+			}
+			if _, exists := c.get("a"); exists {
+				t.Error("SetIfPresent失败后 Get(a) 不应存在")
+			}
+			if !c.SetIfAbsent("a", 1) {
+				t.Error("SetIfAbsent(a, 1) 键不存在时应返回true")
+			}
+			if c.SetIfAbsent("a", 2) {
+				t.Error("SetIfAbsent(a, 2) 键已存在时应返回false")
+			}
+			if val, exists := c.get("a"); !exists || val != 1 {
+				t.Errorf("Get(a) = %v, %v; 期望 1, true", val, exists)
+			}
+			if !c.SetIfPresent("a", 3) {
+				t.Error("SetIfPresent(a, 3) 键已存在时应返回true")
+			}
+			if val, exists := c.get("a"); !exists || val != 3 {
+				t.Errorf("Get(a) = %v, %v; 期望 3, true", val, exists)
+			}
+		})
+	}
+}
+
+// TestConditionalSetter_Concurrent 测试同一个键的并发SetIfAbsent只有一个成功
+func TestConditionalSetter_Concurrent(t *testing.T) {
+	for name, newCache := range conditionalCases(t) {
+		t.Run(name, func(t *testing.T) {
+			c := newCache()
+
+			const goroutines = 100
+			var wg sync.WaitGroup
+			var successes atomic.Int32
+			var winner atomic.Int32
+			start := make(chan struct{})
+			for i := 0; i < goroutines; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					<-start
+					if c.SetIfAbsent("lock", i) {
+						successes.Add(1)
+						winner.Store(int32(i))
+					}
+				}(i)
+			}
+			close(start)
+			wg.Wait()
+
+			if got := successes.Load(); got != 1 {
+				t.Fatalf("成功的SetIfAbsent次数 = %d; 期望 1", got)
+			}
+			if val, exists := c.get("lock"); !exists || val != int(winner.Load()) {
+				t.Errorf("Get(lock) = %v, %v; 期望 %d, true", val, exists, winner.Load())
+			}
+		})
+	}
+}
+
+// TestConditionalSetter_Expiration 测试TimedCache中已过期和负缓存的键视为不存在
+func TestConditionalSetter_Expiration(t *testing.T) {
+	cache, err := NewTimedCache[string, int](10, 30*time.Millisecond)
+	if err != nil {
+		t.Fatalf("创建Timed缓存失败: %v", err)
+	}
+
+	cache.Set("a", 1)
+	time.Sleep(40 * time.Millisecond)
+	if cache.SetIfPresent("a", 2) {
+		t.Error("SetIfPresent(a) 键已过期时应返回false")
+	}
+	if !cache.SetIfAbsent("a", 3) {
+		t.Error("SetIfAbsent(a) 键已过期时应返回true")
+	}
+	if val, exists := cache.Get("a"); !exists || val != 3 {
+		t.Errorf("Get(a) = %v, %v; 期望 3, true", val, exists)
+	}
+
+	cache.SetNegative("n", time.Minute)
+	if cache.SetIfPresent("n", 1) {
+		t.Error("SetIfPresent(n) 负缓存条目应视为不存在")
+	}
+	if !cache.SetIfAbsent("n", 1) {
+		t.Error("SetIfAbsent(n) 负缓存条目应视为不存在")
+	}
+}
+
+// TestConditionalSetter_Null 测试NullCache的条件写入
+func TestConditionalSetter_Null(t *testing.T) {
+	var cache ConditionalSetter[string, int] = NewNullCache[string, int]()
+	if !cache.SetIfAbsent("a", 1) || !cache.SetIfAbsent("a", 2) {
+		t.Error("NullCache.SetIfAbsent 应始终返回true")
+	}
+	if cache.SetIfPresent("a", 1) {
+		t.Error("NullCache.SetIfPresent 应始终返回false")
+	}
+}
@@ -0,0 +1,116 @@
+package cache
+
+import "time"
+
+// CounterCache 计数器缓存，用于限流、计数等场景
+// Increment和Decrement在缓存锁内原子地完成"读取-修改-写入"，避免Get+Set造成的更新丢失
+// 计数器在首次创建时开始计时，window到期后自动删除；期间的增减不会延长其生存时间，
+// 因此可直接用作固定窗口限流的计数器
+// 底层使用并发安全的TimedCache存储，容量满时优先淘汰最早过期的计数器
+// K为键类型（必须可比较）
+type CounterCache[K comparable] struct {
+	cache *TimedCache[K, int64] // 底层存储
+}
+
+// NewCounterCache 创建新的计数器缓存实例
+// 参数:
+//   capacity: 最大计数器数量，必须大于0
+//   window: 计数器从创建到过期的时间，必须大于0
+// 返回值:
+//   *CounterCache[K]: 成功创建的缓存实例
+//   error: 当capacity <= 0或window <= 0时返回非nil错误
+func NewCounterCache[K comparable](capacity int, window time.Duration) (*CounterCache[K], error) {
+	cache, err := NewTimedCache[K, int64](capacity, window)
+	if err != nil {
+		return nil, err
+	}
+	return &CounterCache[K]{cache: cache}, nil
+}
+
+// Increment 原子地将键对应的计数器增加delta，返回增加后的值
+// 如果键不存在或已过期，以delta为初始值创建计数器
+// 参数:
+//   key: 计数器的键
+//   delta: 增量，可以为负数
+// 返回值:
+//   int64: 增加后的计数
+func (c *CounterCache[K]) Increment(key K, delta int64) int64 {
+	return c.cache.update(key, func(old int64, _ bool) int64 {
+		return old + delta
+	})
+}
+
+// Decrement 原子地将键对应的计数器减少delta，返回减少后的值
+// 如果键不存在或已过期，以-delta为初始值创建计数器
+// 参数:
+//   key: 计数器的键
+//   delta: 减量，可以为负数
+// 返回值:
+//   int64: 减少后的计数
+func (c *CounterCache[K]) Decrement(key K, delta int64) int64 {
+	return c.Increment(key, -delta)
+}
+
+// Get 获取键对应的计数
+// 参数:
+//   key: 计数器的键
+// 返回值:
+//   value: 当前计数，键不存在或已过期时返回0
+//   exists: 布尔值，表示计数器是否存在且未过期
+func (c *CounterCache[K]) Get(key K) (value int64, exists bool) {
+	return c.cache.Get(key)
+}
+
+// Set 将计数器设置为指定值，并重新开始计时
+// 参数:
+//   key: 计数器的键
+//   value: 新的计数
+func (c *CounterCache[K]) Set(key K, value int64) {
+	c.cache.Set(key, value)
+}
+
+// SetIfAbsent 仅当计数器不存在或已过期时将其设置为指定值并开始计时，检查和写入是原子的
+// 参数:
+//   key: 计数器的键
+//   value: 初始计数
+// 返回值:
+//   bool: 是否设置了计数器
+func (c *CounterCache[K]) SetIfAbsent(key K, value int64) bool {
+	return c.cache.SetIfAbsent(key, value)
+}
+
+// SetIfPresent 仅当计数器存在且未过期时将其设置为指定值，并重新开始计时，检查和写入是原子的
+// 参数:
+//   key: 计数器的键
+//   value: 新的计数
+// 返回值:
+//   bool: 是否更新了计数器
+func (c *CounterCache[K]) SetIfPresent(key K, value int64) bool {
+	return c.cache.SetIfPresent(key, value)
+}
+
+// Delete 删除指定计数器，下次Increment会重新开始计数
+// 参数:
+//   key: 计数器的键
+func (c *CounterCache[K]) Delete(key K) {
+	c.cache.Delete(key)
+}
+
+// Clone 返回计数器缓存的独立副本，计数和过期时间与原缓存相同
+// 返回值:
+//   *CounterCache[K]: 新的计数器缓存实例
+func (c *CounterCache[K]) Clone() *CounterCache[K] {
+	return &CounterCache[K]{cache: c.cache.Clone()}
+}
+
+// Len 返回当前未过期的计数器数量
+// 返回值:
+//   int: 计数器数量
+func (c *CounterCache[K]) Len() int {
+	return c.cache.Len()
+}
+
+// Clear 删除所有计数器
+func (c *CounterCache[K]) Clear() {
+	c.cache.Clear()
+}
@@ -0,0 +1,136 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+var _ Cache[string, int64] = (*CounterCache[string])(nil)
+
+// TestCounterCache_Basic 测试计数器的创建、增减和删除
+func TestCounterCache_Basic(t *testing.T) {
+	cache, err := NewCounterCache[string](10, time.Minute)
+	if err != nil {
+		t.Fatalf("创建Counter缓存失败: %v", err)
+	}
+
+	if got := cache.Increment("a", 5); got != 5 {
+		t.Errorf("Increment(a, 5) = %d; 期望 5", got)
+	}
+	if got := cache.Increment("a", 2); got != 7 {
+		t.Errorf("Increment(a, 2) = %d; 期望 7", got)
+	}
+	if got := cache.Decrement("a", 10); got != -3 {
+		t.Errorf("Decrement(a, 10) = %d; 期望 -3", got)
+	}
+	if got := cache.Decrement("b", 1); got != -1 {
+		t.Errorf("Decrement(b, 1) = %d; 期望 -1", got)
+	}
+	if val, exists := cache.Get("a"); !exists || val != -3 {
+		t.Errorf("Get(a) = %v, %v; 期望 -3, true", val, exists)
+	}
+
+	cache.Delete("a")
+	if _, exists := cache.Get("a"); exists {
+		t.Error("Get(a) 应该已删除，但存在")
+	}
+	if got := cache.Increment("a", 1); got != 1 {
+		t.Errorf("删除后 Increment(a, 1) = %d; 期望 1", got)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d; 期望 2", cache.Len())
+	}
+}
+
+// TestCounterCache_Window 测试计数器的过期时间从创建时开始计算，增减不会延长
+func TestCounterCache_Window(t *testing.T) {
+	cache, err := NewCounterCache[string](10, 60*time.Millisecond)
+	if err != nil {
+		t.Fatalf("创建Counter缓存失败: %v", err)
+	}
+
+	cache.Increment("a", 1)
+	time.Sleep(40 * time.Millisecond)
+	cache.Increment("a", 1)
+	time.Sleep(40 * time.Millisecond)
+
+	if _, exists := cache.Get("a"); exists {
+		t.Error("Get(a) 应该已过期，但存在")
+	}
+	if got := cache.Increment("a", 1); got != 1 {
+		t.Errorf("过期后 Increment(a, 1) = %d; 期望 1", got)
+	}
+}
+
+// TestCounterCache_SetIfAbsent 测试只在计数器不存在时设置初始值
+func TestCounterCache_SetIfAbsent(t *testing.T) {
+	cache, err := NewCounterCache[string](10, time.Minute)
+	if err != nil {
+		t.Fatalf("创建Counter缓存失败: %v", err)
+	}
+
+	if cache.SetIfPresent("c", 1) {
+		t.Error("SetIfPresent(c) 计数器不存在时应返回false")
+	}
+	if !cache.SetIfAbsent("c", 5) {
+		t.Error("SetIfAbsent(c, 5) 计数器不存在时应返回true")
+	}
+	if cache.SetIfAbsent("c", 6) {
+		t.Error("SetIfAbsent(c, 6) 计数器已存在时应返回false")
+	}
+	if got := cache.Increment("c", 1); got != 6 {
+		t.Errorf("Increment(c, 1) = %d; 期望 6", got)
+	}
+	if !cache.SetIfPresent("c", 0) {
+		t.Error("SetIfPresent(c, 0) 计数器已存在时应返回true")
+	}
+	if val, exists := cache.Get("c"); !exists || val != 0 {
+		t.Errorf("Get(c) = %v, %v; 期望 0, true", val, exists)
+	}
+}
+
+// TestCounterCache_Concurrent 测试并发对同一个键计数时不会丢失更新
+func TestCounterCache_Concurrent(t *testing.T) {
+	cache, err := NewCounterCache[string](10, time.Minute)
+	if err != nil {
+		t.Fatalf("创建Counter缓存失败: %v", err)
+	}
+
+	const (
+		numGoroutines          = 50
+		operationsPerGoroutine = 1000
+	)
+	var wg sync.WaitGroup
+	var want int64
+	wg.Add(numGoroutines)
+	for i := 0; i < numGoroutines; i++ {
+		delta := int64(i%5 + 1)
+		want += delta * operationsPerGoroutine
+		go func() {
+			defer wg.Done()
+			for j := 0; j < operationsPerGoroutine; j++ {
+				cache.Increment("hits", delta)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if val, exists := cache.Get("hits"); !exists || val != want {
+		t.Errorf("Get(hits) = %v, %v; 期望 %d, true", val, exists, want)
+	}
+}
+
+// BenchmarkCounterCache_Increment 基准测试并发Increment性能
+func BenchmarkCounterCache_Increment(b *testing.B) {
+	cache, _ := NewCounterCache[int](1000, time.Minute)
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			cache.Increment(i%1000, 1)
+			i++
+		}
+	})
+}
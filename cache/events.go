@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// eventBufferSize 每个订阅通道的缓冲区大小，缓冲区满时新事件会被丢弃
+const eventBufferSize = 64
+
+// CacheEventType 缓存事件类型
+type CacheEventType int
+
+const (
+	// EventSet 写入条目
+	EventSet CacheEventType = iota
+	// EventGetHit 读取命中
+	EventGetHit
+	// EventGetMiss 读取未命中
+	EventGetMiss
+	// EventDelete 主动删除条目
+	EventDelete
+	// EventEvict 因容量不足淘汰条目
+	EventEvict
+	// EventExpire 因TTL到期删除条目
+	EventExpire
+)
+
+// String 返回事件类型的名称
+func (e CacheEventType) String() string {
+	switch e {
+	case EventSet:
+		return "Set"
+	case EventGetHit:
+		return "GetHit"
+	case EventGetMiss:
+		return "GetMiss"
+	case EventDelete:
+		return "Delete"
+	case EventEvict:
+		return "Evict"
+	case EventExpire:
+		return "Expire"
+	default:
+		return "Unknown"
+	}
+}
+
+// CacheEvent 缓存操作事件
+// K为键类型，V为值类型
+type CacheEvent[K comparable, V any] struct {
+	Type  CacheEventType // 事件类型
+	Key   K              // 操作的键
+	Value V              // 相关的值，GetMiss事件为V类型的零值
+}
+
+// eventHub 管理事件订阅者并分发事件
+// 零值可直接使用，分发是非阻塞的：订阅通道已满时丢弃事件，不会阻塞缓存操作
+type eventHub[K comparable, V any] struct {
+	mu   sync.RWMutex            // 保护subs
+	subs []chan CacheEvent[K, V] // 订阅通道列表
+	n    atomic.Int32            // 订阅者数量，无订阅者时跳过加锁
+}
+
+// subscribe 创建并注册一个新的订阅通道
+func (h *eventHub[K, V]) subscribe() <-chan CacheEvent[K, V] {
+	ch := make(chan CacheEvent[K, V], eventBufferSize)
+	h.mu.Lock()
+	h.subs = append(h.subs, ch)
+	h.n.Store(int32(len(h.subs)))
+	h.mu.Unlock()
+	return ch
+}
+
+// unsubscribe 注销订阅通道并关闭它，通道未注册时无效果
+func (h *eventHub[K, V]) unsubscribe(ch <-chan CacheEvent[K, V]) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i, sub := range h.subs {
+		if sub == ch {
+			h.subs = append(h.subs[:i], h.subs[i+1:]...)
+			h.n.Store(int32(len(h.subs)))
+			close(sub)
+			return
+		}
+	}
+}
+
+// publish 向所有订阅者非阻塞地发送事件
+func (h *eventHub[K, V]) publish(typ CacheEventType, key K, value V) {
+	if h.n.Load() == 0 {
+		return
+	}
+	ev := CacheEvent[K, V]{Type: typ, Key: key, Value: value}
+	// 持有读锁发送，保证不会向已被unsubscribe关闭的通道发送
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for _, sub := range h.subs {
+		select {
+		case sub <- ev:
+		default:
+		}
+	}
+}
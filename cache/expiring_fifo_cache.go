@@ -0,0 +1,283 @@
+package cache
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ExpiringFIFOCache 同时限制条目数量和条目年龄的FIFO缓存
+// 缓存满时按插入顺序淘汰最早写入的条目；写入时间超过ttl的条目在访问时被惰性删除
+// 与TimedCache按最早过期淘汰不同，所有条目的ttl相同，因此插入顺序即过期顺序
+// K为键类型（必须可比较），V为值类型
+type ExpiringFIFOCache[K comparable, V any] struct {
+	cache          map[K]*list.Element // 键到链表节点的映射
+	queue          *list.List          // 按写入时间排序的队列，队首最旧
+	capacity       int                 // 最大容量
+	ttl            time.Duration       // 条目的最大年龄
+	concurrentSafe bool                // 是否启用并发安全
+	mu             sync.Mutex          // 互斥锁，读操作也会清理过期条目
+}
+
+// expiringFIFOEntry 链表节点存储的数据结构
+type expiringFIFOEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	writtenAt time.Time // 写入时间
+}
+
+// NewExpiringFIFOCache 创建新的限量限时FIFO缓存实例
+// 参数:
+//
+//	capacity: 最大缓存条目数，必须大于0
+//	ttl: 条目的最大年龄，必须大于0
+//	options: 可选配置，与FIFOCache共用，如WithConcurrentSafe
+//
+// 返回值:
+//
+//	*ExpiringFIFOCache[K, V]: 成功创建的缓存实例
+//	error: 当capacity <= 0或ttl <= 0时返回非nil错误
+func NewExpiringFIFOCache[K comparable, V any](capacity int, ttl time.Duration, options ...Option) (*ExpiringFIFOCache[K, V], error) {
+	if capacity <= 0 {
+		return nil, errors.New("容量必须大于0")
+	}
+	if ttl <= 0 {
+		return nil, errors.New("TTL必须大于0")
+	}
+
+	opts := fifoCacheOptions{
+		concurrentSafe: true,
+	}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	return &ExpiringFIFOCache[K, V]{
+		cache:          make(map[K]*list.Element, capacity),
+		queue:          list.New(),
+		capacity:       capacity,
+		ttl:            ttl,
+		concurrentSafe: opts.concurrentSafe,
+	}, nil
+}
+
+// Get 从缓存中获取键对应的值
+// 调用此方法会先清理所有超过ttl的条目；Get不会改变条目的淘汰顺序
+// 参数:
+//
+//	key: 要查找的键
+//
+// 返回值:
+//
+//	value: 键对应的值，如果键不存在或已过期则返回V类型的零值
+//	exists: 布尔值，表示键是否存在且未过期
+func (f *ExpiringFIFOCache[K, V]) Get(key K) (V, bool) {
+	if f.concurrentSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+
+	f.removeExpired()
+
+	elem, ok := f.cache[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return elem.Value.(*expiringFIFOEntry[K, V]).value, true
+}
+
+// Set 将键值对存入缓存
+// 如果键已存在，更新值并视为重新写入：重置其年龄并移到队尾
+// 如果键不存在且缓存已满，会先移除最早写入的条目
+// 参数:
+//
+//	key: 要存储的键
+//	value: 要存储的值
+func (f *ExpiringFIFOCache[K, V]) Set(key K, value V) {
+	if f.concurrentSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+
+	f.removeExpired()
+	f.set(key, value)
+}
+
+// SetIfAbsent 仅当键不存在或已过期时写入键值对，写入方式与Set相同
+// 检查和写入在同一次加锁内完成，是原子的
+// 参数:
+//
+//	key: 要存储的键
+//	value: 要存储的值
+//
+// 返回值:
+//
+//	bool: 是否写入了值，键已存在时返回false
+func (f *ExpiringFIFOCache[K, V]) SetIfAbsent(key K, value V) bool {
+	if f.concurrentSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+
+	f.removeExpired()
+
+	if _, ok := f.cache[key]; ok {
+		return false
+	}
+	f.set(key, value)
+	return true
+}
+
+// SetIfPresent 仅当键存在且未过期时更新其值，更新方式与Set相同（重置年龄并移到队尾）
+// 检查和写入在同一次加锁内完成，是原子的
+// 参数:
+//
+//	key: 要存储的键
+//	value: 要存储的值
+//
+// 返回值:
+//
+//	bool: 是否更新了值，键不存在或已过期时返回false
+func (f *ExpiringFIFOCache[K, V]) SetIfPresent(key K, value V) bool {
+	if f.concurrentSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+
+	f.removeExpired()
+
+	if _, ok := f.cache[key]; !ok {
+		return false
+	}
+	f.set(key, value)
+	return true
+}
+
+// set 存储键值对，此方法应在持有锁并清理过期条目后调用
+func (f *ExpiringFIFOCache[K, V]) set(key K, value V) {
+	now := time.Now()
+	if elem, ok := f.cache[key]; ok {
+		entry := elem.Value.(*expiringFIFOEntry[K, V])
+		entry.value = value
+		entry.writtenAt = now
+		f.queue.MoveToBack(elem)
+		return
+	}
+
+	if f.queue.Len() >= f.capacity {
+		f.removeElement(f.queue.Front())
+	}
+
+	f.cache[key] = f.queue.PushBack(&expiringFIFOEntry[K, V]{
+		key:       key,
+		value:     value,
+		writtenAt: now,
+	})
+}
+
+// Delete 从缓存中删除指定键
+// 如果键不存在，此操作无效果
+// 参数:
+//
+//	key: 要删除的键
+func (f *ExpiringFIFOCache[K, V]) Delete(key K) {
+	if f.concurrentSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+
+	if elem, ok := f.cache[key]; ok {
+		f.removeElement(elem)
+	}
+}
+
+// GetAll 返回所有未过期条目的快照
+// 返回的map是独立副本，修改它不会影响缓存
+// 返回值:
+//
+//	map[K]V: 所有未过期的键值对
+func (f *ExpiringFIFOCache[K, V]) GetAll() map[K]V {
+	if f.concurrentSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+
+	f.removeExpired()
+
+	result := make(map[K]V, len(f.cache))
+	for key, elem := range f.cache {
+		result[key] = elem.Value.(*expiringFIFOEntry[K, V]).value
+	}
+	return result
+}
+
+// Clone 返回缓存的独立副本，包含相同的条目、淘汰顺序和写入时间
+// 副本中的条目与原缓存同时过期；之后对副本或原缓存的修改互不影响
+// 返回值:
+//
+//	*ExpiringFIFOCache[K, V]: 配置与原缓存相同的新缓存
+func (f *ExpiringFIFOCache[K, V]) Clone() *ExpiringFIFOCache[K, V] {
+	if f.concurrentSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+
+	clone := &ExpiringFIFOCache[K, V]{
+		cache:          make(map[K]*list.Element, f.capacity),
+		queue:          list.New(),
+		capacity:       f.capacity,
+		ttl:            f.ttl,
+		concurrentSafe: f.concurrentSafe,
+	}
+	for elem := f.queue.Front(); elem != nil; elem = elem.Next() {
+		e := *elem.Value.(*expiringFIFOEntry[K, V])
+		clone.cache[e.key] = clone.queue.PushBack(&e)
+	}
+	return clone
+}
+
+// Len 返回当前未过期的条目数量
+// 返回值:
+//
+//	int: 缓存中未过期的键值对数量
+func (f *ExpiringFIFOCache[K, V]) Len() int {
+	if f.concurrentSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+
+	f.removeExpired()
+	return f.queue.Len()
+}
+
+// Clear 清空缓存中的所有元素
+func (f *ExpiringFIFOCache[K, V]) Clear() {
+	if f.concurrentSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+
+	f.cache = make(map[K]*list.Element, f.capacity)
+	f.queue.Init()
+}
+
+// removeExpired 从队首开始移除所有超过ttl的条目
+// 此方法应在持有锁的情况下调用
+func (f *ExpiringFIFOCache[K, V]) removeExpired() {
+	deadline := time.Now().Add(-f.ttl)
+	for elem := f.queue.Front(); elem != nil; elem = f.queue.Front() {
+		if elem.Value.(*expiringFIFOEntry[K, V]).writtenAt.After(deadline) {
+			break
+		}
+		f.removeElement(elem)
+	}
+}
+
+// removeElement 从链表和哈希表中移除节点
+// 此方法应在持有锁的情况下调用
+func (f *ExpiringFIFOCache[K, V]) removeElement(elem *list.Element) {
+	entry := f.queue.Remove(elem).(*expiringFIFOEntry[K, V])
+	delete(f.cache, entry.key)
+}
@@ -0,0 +1,143 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+var _ Cache[string, int] = (*ExpiringFIFOCache[string, int])(nil)
+
+// TestExpiringFIFOCache_CountCap 测试数量上限：缓存满时按插入顺序淘汰，与剩余存活时间无关
+func TestExpiringFIFOCache_CountCap(t *testing.T) {
+	cache, err := NewExpiringFIFOCache[int, string](2, time.Hour)
+	if err != nil {
+		t.Fatalf("创建ExpiringFIFO缓存失败: %v", err)
+	}
+
+	cache.Set(1, "a")
+	cache.Set(2, "b")
+	cache.Get(1) // 访问不改变淘汰顺序
+	cache.Set(3, "c")
+
+	if _, exists := cache.Get(1); exists {
+		t.Error("Get(1) 应该被淘汰，但存在")
+	}
+	if val, exists := cache.Get(2); !exists || val != "b" {
+		t.Errorf("Get(2) = %v, %v; 期望 'b', true", val, exists)
+	}
+	if val, exists := cache.Get(3); !exists || val != "c" {
+		t.Errorf("Get(3) = %v, %v; 期望 'c', true", val, exists)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d; 期望 2", cache.Len())
+	}
+
+	// 更新已有键视为重新写入，移到队尾
+	cache.Set(2, "b2")
+	cache.Set(4, "d")
+	if _, exists := cache.Get(3); exists {
+		t.Error("Get(3) 应该被淘汰，但存在")
+	}
+	if val, exists := cache.Get(2); !exists || val != "b2" {
+		t.Errorf("Get(2) = %v, %v; 期望 'b2', true", val, exists)
+	}
+}
+
+// TestExpiringFIFOCache_AgeCap 测试年龄上限：未满时超过ttl的条目也会被删除
+func TestExpiringFIFOCache_AgeCap(t *testing.T) {
+	cache, err := NewExpiringFIFOCache[int, string](100, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("创建ExpiringFIFO缓存失败: %v", err)
+	}
+
+	cache.Set(1, "a")
+	cache.Set(2, "b")
+	time.Sleep(30 * time.Millisecond)
+	cache.Set(3, "c")
+	cache.Set(1, "a2") // 重新写入重置年龄
+	time.Sleep(30 * time.Millisecond)
+
+	if _, exists := cache.Get(2); exists {
+		t.Error("Get(2) 应该已过期，但存在")
+	}
+	if val, exists := cache.Get(1); !exists || val != "a2" {
+		t.Errorf("Get(1) = %v, %v; 期望 'a2', true", val, exists)
+	}
+	if val, exists := cache.Get(3); !exists || val != "c" {
+		t.Errorf("Get(3) = %v, %v; 期望 'c', true", val, exists)
+	}
+	if all := cache.GetAll(); len(all) != 2 {
+		t.Errorf("GetAll() = %v; 期望 2 个条目", all)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	if cache.Len() != 0 {
+		t.Errorf("Len() = %d; 期望 0", cache.Len())
+	}
+}
+
+// TestExpiringFIFOCache_DeleteClear 测试Delete和Clear
+func TestExpiringFIFOCache_DeleteClear(t *testing.T) {
+	cache, err := NewExpiringFIFOCache[int, string](2, time.Hour, WithConcurrentSafe(false))
+	if err != nil {
+		t.Fatalf("创建ExpiringFIFO缓存失败: %v", err)
+	}
+
+	cache.Set(1, "a")
+	cache.Set(2, "b")
+	cache.Delete(1)
+	cache.Delete(3) // 不存在的键
+	cache.Set(3, "c")
+	if val, exists := cache.Get(2); !exists || val != "b" {
+		t.Errorf("Get(2) = %v, %v; 期望 'b', true", val, exists)
+	}
+
+	cache.Clear()
+	if cache.Len() != 0 {
+		t.Errorf("Clear() 后 Len() = %d; 期望 0", cache.Len())
+	}
+
+	if _, err := NewExpiringFIFOCache[int, string](0, time.Hour); err == nil {
+		t.Error("capacity为0时应返回错误")
+	}
+	if _, err := NewExpiringFIFOCache[int, string](1, 0); err == nil {
+		t.Error("ttl为0时应返回错误")
+	}
+}
+
+// TestExpiringFIFOCache_Clone 测试副本保留写入时间，且修改副本不影响原缓存
+func TestExpiringFIFOCache_Clone(t *testing.T) {
+	cache, err := NewExpiringFIFOCache[int, string](2, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("创建ExpiringFIFO缓存失败: %v", err)
+	}
+	cache.Set(1, "a")
+
+	clone := cache.Clone()
+	clone.Set(2, "b")
+	clone.Set(3, "c") // 副本中淘汰1
+	if _, exists := clone.Get(1); exists {
+		t.Error("副本 Get(1) 应该被淘汰，但存在")
+	}
+	if val, exists := cache.Get(1); !exists || val != "a" || cache.Len() != 1 {
+		t.Errorf("原缓存 Get(1) = %v, %v, Len() = %d; 期望 'a', true, 1", val, exists, cache.Len())
+	}
+
+	clone = cache.Clone()
+	time.Sleep(60 * time.Millisecond)
+	if _, exists := clone.Get(1); exists {
+		t.Error("副本 Get(1) 应与原缓存同时过期，但存在")
+	}
+}
+
+// BenchmarkExpiringFIFOCache_SetGet 基准测试Set和Get操作性能
+func BenchmarkExpiringFIFOCache_SetGet(b *testing.B) {
+	cache, _ := NewExpiringFIFOCache[int, int](1000, time.Minute)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		key := i % 1000
+		cache.Set(key, i)
+		cache.Get(key)
+	}
+}
@@ -109,6 +109,59 @@ func (f *FIFOCache[K, V]) Set(key K, value V) {
 		defer f.mu.Unlock()
 	}
 
+	f.set(key, value)
+}
+
+// SetIfAbsent 仅当键不存在时写入键值对，写入方式与Set相同
+// 检查和写入在同一次加锁内完成，是原子的
+// 参数:
+//
+//	key: 要存储的键
+//	value: 要存储的值
+//
+// 返回值:
+//
+//	bool: 是否写入了值，键已存在时返回false
+func (f *FIFOCache[K, V]) SetIfAbsent(key K, value V) bool {
+	// 如果启用并发安全，加写锁
+	if f.concurrentSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+
+	if _, ok := f.cache[key]; ok {
+		return false
+	}
+	f.set(key, value)
+	return true
+}
+
+// SetIfPresent 仅当键已存在时更新其值，更新方式与Set相同
+// 检查和写入在同一次加锁内完成，是原子的
+// 参数:
+//
+//	key: 要存储的键
+//	value: 要存储的值
+//
+// 返回值:
+//
+//	bool: 是否更新了值，键不存在时返回false
+func (f *FIFOCache[K, V]) SetIfPresent(key K, value V) bool {
+	// 如果启用并发安全，加写锁
+	if f.concurrentSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+
+	if _, ok := f.cache[key]; !ok {
+		return false
+	}
+	f.set(key, value)
+	return true
+}
+
+// set 存储键值对，此方法应在持有锁的情况下调用
+func (f *FIFOCache[K, V]) set(key K, value V) {
 	// 检查键是否已存在
 	if entry, ok := f.cache[key]; ok {
 		// 更新值
@@ -164,6 +217,51 @@ func (f *FIFOCache[K, V]) Delete(key K) bool {
 	return true
 }
 
+// GetAll 返回所有条目的快照
+// 在持有锁的情况下复制全部键值对，不会改变元素的淘汰顺序
+// 返回的map是独立副本，修改它不会影响缓存
+// 返回值:
+//
+//	map[K]V: 缓存中的所有键值对
+func (f *FIFOCache[K, V]) GetAll() map[K]V {
+	if f.concurrentSafe {
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+	}
+
+	result := make(map[K]V, len(f.cache))
+	for key, entry := range f.cache {
+		result[key] = entry.value
+	}
+	return result
+}
+
+// Clone 返回缓存的独立副本，包含相同的条目和淘汰顺序
+// 复制在持有读锁的情况下完成，之后对副本或原缓存的修改互不影响
+// 返回值:
+//   *FIFOCache[K, V]: 容量和并发安全配置与原缓存相同的新缓存
+func (f *FIFOCache[K, V]) Clone() *FIFOCache[K, V] {
+	if f.concurrentSafe {
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+	}
+
+	clone := &FIFOCache[K, V]{
+		cache:          make(map[K]cacheEntry[K, V], f.capacity),
+		queue:          list.New(),
+		capacity:       f.capacity,
+		concurrentSafe: f.concurrentSafe,
+	}
+	for elem := f.queue.Front(); elem != nil; elem = elem.Next() {
+		key := elem.Value.(K)
+		clone.cache[key] = cacheEntry[K, V]{
+			value: f.cache[key].value,
+			node:  clone.queue.PushBack(key),
+		}
+	}
+	return clone
+}
+
 // Len 返回当前缓存中的元素数量
 // 返回值:
 //
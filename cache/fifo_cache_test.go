@@ -122,6 +122,55 @@ func TestFIFOCache_Clear(t *testing.T) {
 	}
 }
 
+// TestFIFOCache_GetAll 测试GetAll返回独立副本且不改变淘汰顺序
+func TestFIFOCache_GetAll(t *testing.T) {
+	fifo, err := NewFIFOCache[int, string](2)
+	if err != nil {
+		t.Fatalf("创建FIFO缓存失败: %v", err)
+	}
+
+	fifo.Set(1, "a")
+	fifo.Set(2, "b")
+
+	all := fifo.GetAll()
+	if len(all) != 2 || all[1] != "a" || all[2] != "b" {
+		t.Errorf("GetAll() = %v; 期望 map[1:a 2:b]", all)
+	}
+	delete(all, 1)
+	if fifo.Len() != 2 {
+		t.Errorf("Len() = %d; 期望 2", fifo.Len())
+	}
+
+	fifo.Set(3, "c")
+	if _, exists := fifo.Get(1); exists {
+		t.Error("Get(1) 应该被淘汰，但存在")
+	}
+}
+
+// TestFIFOCache_Clone 测试副本保留淘汰顺序，且修改副本不影响原缓存
+func TestFIFOCache_Clone(t *testing.T) {
+	fifo, err := NewFIFOCache[int, string](2)
+	if err != nil {
+		t.Fatalf("创建FIFO缓存失败: %v", err)
+	}
+	fifo.Set(1, "a")
+	fifo.Set(2, "b")
+
+	clone := fifo.Clone()
+	clone.Set(1, "a_clone")
+	clone.Set(3, "c") // 副本中淘汰最早插入的1
+	if _, exists := clone.Get(1); exists {
+		t.Error("副本 Get(1) 应该被淘汰，但存在")
+	}
+
+	if val, exists := fifo.Get(1); !exists || val != "a" {
+		t.Errorf("原缓存 Get(1) = %v, %v; 期望 'a', true", val, exists)
+	}
+	if _, exists := fifo.Get(3); exists {
+		t.Error("原缓存 Get(3) 不应该存在")
+	}
+}
+
 // BenchmarkFIFOCache_SetGet 基准测试Set和Get操作性能
 func BenchmarkFIFOCache_SetGet(b *testing.B) {
 	fifo, _ := NewFIFOCache[int, int](1000)
@@ -0,0 +1,93 @@
+package cache
+
+// sketchDepth Count-Min Sketch的行数，每行使用不同的哈希位置
+const sketchDepth = 4
+
+// sketchMaxCount 单个计数器的上限，与4位计数器一致
+const sketchMaxCount = 15
+
+// frequencySketch 基于Count-Min Sketch的访问频率估计器
+// 使用固定大小的计数器矩阵近似统计大量键的访问次数，估计值可能偏大但不会偏小
+// 累计记录次数达到采样上限后所有计数器减半，使历史热点逐渐衰减，适应访问模式的变化
+// 此结构不是并发安全的，应在调用方的锁保护下使用
+type frequencySketch struct {
+	table      [sketchDepth][]uint8 // 计数器矩阵
+	mask       uint32               // 列下标掩码，列数为2的幂
+	additions  int                  // 自上次衰减以来的记录次数
+	sampleSize int                  // 触发衰减的记录次数
+}
+
+// newFrequencySketch 创建可容纳约capacity个键的频率估计器
+func newFrequencySketch(capacity int) *frequencySketch {
+	width := 16
+	for width < capacity {
+		width <<= 1
+	}
+	s := &frequencySketch{
+		mask:       uint32(width - 1),
+		sampleSize: 10 * width,
+	}
+	for i := range s.table {
+		s.table[i] = make([]uint8, width)
+	}
+	return s
+}
+
+// index 计算哈希值在第row行对应的列下标
+func (s *frequencySketch) index(hash uint64, row int) uint32 {
+	h1 := uint32(hash)
+	h2 := uint32(hash >> 32)
+	return (h1 + uint32(row)*h2) & s.mask
+}
+
+// increment 记录一次访问
+func (s *frequencySketch) increment(hash uint64) {
+	for row := range s.table {
+		idx := s.index(hash, row)
+		if s.table[row][idx] < sketchMaxCount {
+			s.table[row][idx]++
+		}
+	}
+	s.additions++
+	if s.additions >= s.sampleSize {
+		s.reset()
+	}
+}
+
+// estimate 返回估计的访问次数，取各行计数的最小值
+func (s *frequencySketch) estimate(hash uint64) int {
+	count := uint8(sketchMaxCount)
+	for row := range s.table {
+		if c := s.table[row][s.index(hash, row)]; c < count {
+			count = c
+		}
+	}
+	return int(count)
+}
+
+// reset 将所有计数器减半，实现频率衰减
+func (s *frequencySketch) reset() {
+	for row := range s.table {
+		for i := range s.table[row] {
+			s.table[row][i] >>= 1
+		}
+	}
+	s.additions /= 2
+}
+
+// clone 返回频率估计器的独立副本
+func (s *frequencySketch) clone() *frequencySketch {
+	c := *s
+	for i := range c.table {
+		c.table[i] = append([]uint8(nil), s.table[i]...)
+	}
+	return &c
+}
+
+// clear 清空所有计数器
+func (s *frequencySketch) clear() {
+	for row := range s.table {
+		clear(s.table[row])
+	}
+	s.additions = 0
+}
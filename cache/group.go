@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Group 请求合并器（singleflight），不缓存任何结果
+// 同一个键的并发调用只会执行一次fn，其余调用方等待并共享其结果；调用结束后结果即被丢弃，
+// 下一次Do会重新执行fn。适用于只需要防止重复请求、但结果不适合缓存的场景
+// 零值可直接使用
+type Group struct {
+	mu    sync.Mutex                        // 保护calls
+	calls map[string]*loadCall[interface{}] // 进行中的调用
+}
+
+// Do 执行并返回fn的结果，同一个键同时只有一次执行
+// 如果该键已有进行中的调用，则等待其完成并返回相同的结果
+// fn panic时等待方收到错误，panic继续向执行fn的调用方传播
+// 参数:
+//
+//	key: 用于合并调用的键
+//	fn: 实际执行的函数
+//
+// 返回值:
+//
+//	interface{}: fn返回的值
+//	error: fn返回的错误
+func (g *Group) Do(key string, fn func() (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*loadCall[interface{}])
+	}
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+	call := &loadCall[interface{}]{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	g.doCall(key, call, fn)
+	return call.value, call.err
+}
+
+// Forget 丢弃键对应的进行中调用，之后的Do会重新执行fn而不再等待该调用
+// 已经在等待的调用方不受影响，仍会收到原调用的结果
+// 参数:
+//
+//	key: 要丢弃的键
+func (g *Group) Forget(key string) {
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+}
+
+// doCall 执行fn并唤醒所有等待方
+func (g *Group) doCall(key string, call *loadCall[interface{}], fn func() (interface{}, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			call.err = fmt.Errorf("group call panicked: %v", r)
+			g.finish(key, call)
+			panic(r)
+		}
+		g.finish(key, call)
+	}()
+
+	call.value, call.err = fn()
+}
+
+// finish 移除进行中的调用并唤醒等待方
+// 调用可能已被Forget并被新的调用替代，此时不能删除新的调用
+func (g *Group) finish(key string, call *loadCall[interface{}]) {
+	g.mu.Lock()
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+	close(call.done)
+}
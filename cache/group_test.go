@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestGroup_Do 测试并发的相同调用只执行一次并共享结果
+func TestGroup_Do(t *testing.T) {
+	var g Group
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fn := func() (interface{}, error) {
+		calls.Add(1)
+		<-release
+		return "result", nil
+	}
+
+	const goroutines = 50
+	var started, wg sync.WaitGroup
+	results := make(chan interface{}, goroutines)
+	started.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			started.Done()
+			val, err := g.Do("key", fn)
+			if err != nil {
+				t.Errorf("Do(key) error = %v", err)
+			}
+			results <- val
+		}()
+	}
+	started.Wait()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	for val := range results {
+		if val != "result" {
+			t.Errorf("Do(key) = %v; 期望 'result'", val)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("fn调用次数 = %d; 期望 1", calls.Load())
+	}
+
+	// 调用结束后结果不会被缓存
+	if _, err := g.Do("key", func() (interface{}, error) {
+		return nil, errors.New("boom")
+	}); err == nil || err.Error() != "boom" {
+		t.Errorf("Do(key) error = %v; 期望 'boom'", err)
+	}
+}
+
+// TestGroup_Forget 测试Forget后新的调用不再等待进行中的调用
+func TestGroup_Forget(t *testing.T) {
+	var g Group
+	release := make(chan struct{})
+	firstDone := make(chan interface{})
+	go func() {
+		val, _ := g.Do("key", func() (interface{}, error) {
+			<-release
+			return "first", nil
+		})
+		firstDone <- val
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	g.Forget("key")
+	val, err := g.Do("key", func() (interface{}, error) {
+		return "second", nil
+	})
+	if err != nil || val != "second" {
+		t.Errorf("Forget后 Do(key) = %v, %v; 期望 'second', nil", val, err)
+	}
+
+	close(release)
+	if val := <-firstDone; val != "first" {
+		t.Errorf("原调用 Do(key) = %v; 期望 'first'", val)
+	}
+}
+
+// TestGroup_Panic 测试fn panic时等待方收到错误，且之后的调用正常执行
+func TestGroup_Panic(t *testing.T) {
+	var g Group
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("Do 应该传播fn的panic")
+			}
+		}()
+		g.Do("key", func() (interface{}, error) {
+			panic("boom")
+		})
+	}()
+
+	val, err := g.Do("key", func() (interface{}, error) {
+		return 1, nil
+	})
+	if err != nil || val != 1 {
+		t.Errorf("panic后 Do(key) = %v, %v; 期望 1, nil", val, err)
+	}
+}
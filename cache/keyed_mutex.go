@@ -0,0 +1,89 @@
+package cache
+
+import "sync"
+
+// keyedLock 单个键对应的互斥锁及其引用计数
+type keyedLock struct {
+	mu   sync.Mutex
+	refs int // 持有或等待该锁的goroutine数量
+}
+
+// KeyedMutex 按键加锁的互斥锁集合
+// 用于对缓存值执行"读取-修改-写入"时避免更新丢失：不同键之间互不阻塞，相同键串行执行
+// 内部按引用计数管理每个键的锁，最后一个使用者解锁后即从映射中移除，未使用的键不会长期占用内存
+// 零值可直接使用
+// K为键类型，必须支持比较操作
+type KeyedMutex[K comparable] struct {
+	mu    sync.Mutex       // 保护locks映射
+	locks map[K]*keyedLock // 键到锁的映射
+}
+
+// NewKeyedMutex 创建新的按键互斥锁
+// 返回值:
+//
+//	*KeyedMutex[K]: 按键互斥锁实例
+func NewKeyedMutex[K comparable]() *KeyedMutex[K] {
+	return &KeyedMutex[K]{}
+}
+
+// Lock 获取指定键的锁，如果该键已被锁定则阻塞等待
+// 参数:
+//
+//	key: 要锁定的键
+func (m *KeyedMutex[K]) Lock(key K) {
+	m.mu.Lock()
+	if m.locks == nil {
+		m.locks = make(map[K]*keyedLock)
+	}
+	l, exists := m.locks[key]
+	if !exists {
+		l = &keyedLock{}
+		m.locks[key] = l
+	}
+	l.refs++
+	m.mu.Unlock()
+
+	l.mu.Lock()
+}
+
+// Unlock 释放指定键的锁
+// 对未锁定的键调用Unlock会panic，与sync.Mutex的行为一致
+// 参数:
+//
+//	key: 要解锁的键
+func (m *KeyedMutex[K]) Unlock(key K) {
+	m.mu.Lock()
+	l, exists := m.locks[key]
+	if !exists {
+		m.mu.Unlock()
+		panic("cache: unlock of unlocked key")
+	}
+	l.refs--
+	if l.refs == 0 {
+		delete(m.locks, key)
+	}
+	m.mu.Unlock()
+
+	l.mu.Unlock()
+}
+
+// WithLock 在持有指定键的锁期间执行fn，fn返回或panic后自动解锁
+// 参数:
+//
+//	key: 要锁定的键
+//	fn: 需要在锁保护下执行的函数
+func (m *KeyedMutex[K]) WithLock(key K, fn func()) {
+	m.Lock(key)
+	defer m.Unlock(key)
+	fn()
+}
+
+// Len 返回当前被持有或等待的键数量
+// 返回值:
+//
+//	int: 活跃的键锁数量
+func (m *KeyedMutex[K]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.locks)
+}
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestKeyedMutex_Counter 测试多个goroutine在按键锁保护下递增缓存计数器
+func TestKeyedMutex_Counter(t *testing.T) {
+	cache, err := NewLRUCache[string, int](10)
+	if err != nil {
+		t.Fatalf("创建LRU缓存失败: %v", err)
+	}
+	km := NewKeyedMutex[string]()
+
+	const (
+		numGoroutines = 50
+		increments    = 200
+	)
+	keys := []string{"a", "b"}
+
+	var wg sync.WaitGroup
+	wg.Add(numGoroutines)
+	for i := 0; i < numGoroutines; i++ {
+		go func(id int) {
+			defer wg.Done()
+			key := keys[id%len(keys)]
+			for j := 0; j < increments; j++ {
+				km.WithLock(key, func() {
+					val, _ := cache.Get(key)
+					cache.Set(key, val+1)
+				})
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, key := range keys {
+		want := numGoroutines / len(keys) * increments
+		if val, _ := cache.Get(key); val != want {
+			t.Errorf("Get(%q) = %d; 期望 %d", key, val, want)
+		}
+	}
+
+	// 所有锁释放后不应残留键
+	if km.Len() != 0 {
+		t.Errorf("Len() = %d; 期望 0", km.Len())
+	}
+}
+
+// TestKeyedMutex_Independent 测试不同键之间互不阻塞
+func TestKeyedMutex_Independent(t *testing.T) {
+	var km KeyedMutex[int]
+	km.Lock(1)
+	defer km.Unlock(1)
+
+	done := make(chan struct{})
+	go func() {
+		km.Lock(2)
+		km.Unlock(2)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("锁定键1时键2不应被阻塞")
+	}
+	if km.Len() != 1 {
+		t.Errorf("Len() = %d; 期望 1", km.Len())
+	}
+}
+
+// TestKeyedMutex_UnlockUnlocked 测试解锁未锁定的键会panic
+func TestKeyedMutex_UnlockUnlocked(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("解锁未锁定的键应该panic")
+		}
+	}()
+	NewKeyedMutex[int]().Unlock(1)
+}
@@ -87,6 +87,39 @@ func (l *LFUCache[K, V]) Set(key K, value V) {
 		defer l.mu.Unlock()
 	}
 
+	l.set(key, value)
+}
+
+// SetIfAbsent 仅当键不存在时写入键值对，返回是否写入；检查和写入是原子的
+func (l *LFUCache[K, V]) SetIfAbsent(key K, value V) bool {
+	if l.concurrentSafe {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+	}
+
+	if _, exists := l.cache[key]; exists {
+		return false
+	}
+	l.set(key, value)
+	return true
+}
+
+// SetIfPresent 仅当键已存在时更新其值并增加访问频率，返回是否更新；检查和更新是原子的
+func (l *LFUCache[K, V]) SetIfPresent(key K, value V) bool {
+	if l.concurrentSafe {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+	}
+
+	if _, exists := l.cache[key]; !exists {
+		return false
+	}
+	l.set(key, value)
+	return true
+}
+
+// set 存储键值对，此方法应在持有锁的情况下调用
+func (l *LFUCache[K, V]) set(key K, value V) {
 	if node, exists := l.cache[key]; exists {
 		node.value = value
 		l.updateFreq(node)
@@ -144,6 +177,54 @@ func (l *LFUCache[K, V]) Delete(key K) {
 	delete(l.cache, key)
 }
 
+// GetAll 返回所有条目的快照
+// 在持有锁的情况下复制全部键值对，不会增加元素的访问频率
+// 返回的map是独立副本，修改它不会影响缓存
+// 返回值:
+//   map[K]V: 缓存中的所有键值对
+func (l *LFUCache[K, V]) GetAll() map[K]V {
+	if l.concurrentSafe {
+		l.mu.RLock()
+		defer l.mu.RUnlock()
+	}
+
+	result := make(map[K]V, len(l.cache))
+	for key, node := range l.cache {
+		result[key] = node.value
+	}
+	return result
+}
+
+// Clone 返回缓存的独立副本，包含相同的条目、访问频率和同频率下的淘汰顺序
+// 复制在持有读锁的情况下完成，之后对副本或原缓存的修改互不影响
+// 返回值:
+//   *LFUCache[K, V]: 容量和并发安全配置与原缓存相同的新缓存
+func (l *LFUCache[K, V]) Clone() *LFUCache[K, V] {
+	if l.concurrentSafe {
+		l.mu.RLock()
+		defer l.mu.RUnlock()
+	}
+
+	clone := &LFUCache[K, V]{
+		cache:          make(map[K]*lfuNode[K, V], len(l.cache)),
+		freqMap:        make(map[int]*ctl.List, len(l.freqMap)),
+		minFreq:        l.minFreq,
+		capacity:       l.capacity,
+		concurrentSafe: l.concurrentSafe,
+	}
+	for freq, freqList := range l.freqMap {
+		newList := ctl.New()
+		for elem := freqList.Front(); elem != nil; elem = elem.Next() {
+			node := elem.Value.(*lfuNode[K, V])
+			newNode := &lfuNode[K, V]{key: node.key, value: node.value, freq: node.freq}
+			newNode.elem = newList.PushBack(newNode)
+			clone.cache[node.key] = newNode
+		}
+		clone.freqMap[freq] = newList
+	}
+	return clone
+}
+
 // Len 实现Cache接口的Len方法
 func (l *LFUCache[K, V]) Len() int {
 	if l.concurrentSafe {
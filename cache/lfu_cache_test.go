@@ -124,6 +124,72 @@ func TestLFUCache_Clear(t *testing.T) {
 	}
 }
 
+// TestLFUCache_GetAll 测试GetAll返回独立副本且不增加访问频率
+func TestLFUCache_GetAll(t *testing.T) {
+	lfu, err := NewLFUCache[int, string](2)
+	if err != nil {
+		t.Fatalf("创建LFU缓存失败: %v", err)
+	}
+
+	lfu.Set(1, "a")
+	lfu.Set(2, "b")
+	lfu.Get(2)
+
+	all := lfu.GetAll()
+	if len(all) != 2 || all[1] != "a" || all[2] != "b" {
+		t.Errorf("GetAll() = %v; 期望 map[1:a 2:b]", all)
+	}
+	all[2] = "modified"
+
+	// 多次GetAll不应增加1的频率，1仍应被淘汰
+	lfu.GetAll()
+	lfu.GetAll()
+	lfu.Set(3, "c")
+	if _, exists := lfu.Get(1); exists {
+		t.Error("Get(1) 应该被淘汰，但存在")
+	}
+	if val, _ := lfu.Get(2); val != "b" {
+		t.Errorf("Get(2) = %v; 期望 'b'", val)
+	}
+}
+
+// TestLFUCache_Clone 测试副本保留访问频率，且修改副本不影响原缓存
+func TestLFUCache_Clone(t *testing.T) {
+	lfu, err := NewLFUCache[int, string](2)
+	if err != nil {
+		t.Fatalf("创建LFU缓存失败: %v", err)
+	}
+	lfu.Set(1, "a") // freq:1
+	lfu.Set(2, "b") // freq:1
+	lfu.Get(1)      // freq:2
+	lfu.Get(1)      // freq:3
+
+	clone := lfu.Clone()
+	clone.Get(2)      // 副本中2的freq:2，原缓存不变
+	clone.Set(3, "c") // 副本中淘汰频率最低的2（freq:2 < 3）
+	if _, exists := clone.Get(2); exists {
+		t.Error("副本 Get(2) 应该被淘汰，但存在")
+	}
+	if val, exists := clone.Get(1); !exists || val != "a" {
+		t.Errorf("副本 Get(1) = %v, %v; 期望 'a', true", val, exists)
+	}
+
+	// 原缓存中2的频率仍为1，写入新键时应淘汰2而保留1
+	if lfu.Len() != 2 {
+		t.Errorf("原缓存 Len() = %d; 期望 2", lfu.Len())
+	}
+	lfu.Set(4, "d")
+	if _, exists := lfu.Get(2); exists {
+		t.Error("原缓存 Get(2) 应该被淘汰，但存在")
+	}
+	if _, exists := lfu.Get(3); exists {
+		t.Error("原缓存 Get(3) 不应该存在")
+	}
+	if val, exists := lfu.Get(1); !exists || val != "a" {
+		t.Errorf("原缓存 Get(1) = %v, %v; 期望 'a', true", val, exists)
+	}
+}
+
 // BenchmarkLFUCache_SetGet 基准测试Set和Get操作性能
 func BenchmarkLFUCache_SetGet(b *testing.B) {
 	lfu, _ := NewLFUCache[int, int](1000)
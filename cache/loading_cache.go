@@ -0,0 +1,269 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// loadingCacheOptions 用于配置LoadingCache的选项
+type loadingCacheOptions struct {
+	negativeTTL time.Duration // 负缓存的生存时间，0表示不缓存不存在的结果
+}
+
+// LoadingOption 定义配置LoadingCache的函数类型
+type LoadingOption func(*loadingCacheOptions)
+
+// WithNegativeTTL 设置负缓存的生存时间
+// 加载函数返回ErrKeyNotFound时，在ttl内缓存"键不存在"这一结果，期间Get不再调用加载函数
+// 参数:
+//   ttl: 负缓存的生存时间，通常应短于普通条目的TTL；0表示不启用（默认），不能为负数
+// 返回值:
+//   LoadingOption: 用于配置缓存的选项函数
+func WithNegativeTTL(ttl time.Duration) LoadingOption {
+	return func(o *loadingCacheOptions) {
+		o.negativeTTL = ttl
+	}
+}
+
+// loadCall 正在进行中的加载调用，同一个键的并发请求共享其结果
+type loadCall[V any] struct {
+	done     chan struct{} // 加载完成后关闭
+	value    V             // 加载结果
+	err      error         // 加载错误
+	canceled bool          // 加载是否因发起方的ctx被取消而失败，仅LoadingCache使用
+}
+
+// LoadingCache 自动加载的缓存实现
+// 未命中时调用加载函数获取值并写入缓存，底层使用并发安全的TimedCache存储
+// 同一个键的并发未命中只会调用一次加载函数，其余调用方等待并共享结果
+// 加载函数返回ErrKeyNotFound表示键在后端不存在，可配合WithNegativeTTL缓存该结果
+// K为键类型（必须可比较），V为值类型
+type LoadingCache[K comparable, V any] struct {
+	cache       *TimedCache[K, V]                   // 底层存储
+	loader      func(context.Context, K) (V, error) // 加载函数
+	negativeTTL time.Duration                       // 负缓存的生存时间
+	calls       map[K]*loadCall[V]                  // 进行中的加载调用
+	mu          sync.Mutex                          // 保护calls
+}
+
+// NewLoadingCache 创建新的自动加载缓存实例
+// 参数:
+//   capacity: 最大缓存条目数，必须大于0
+//   ttl: 加载结果的过期时间，必须大于0
+//   loader: 加载函数，键不存在时应返回ErrKeyNotFound；应遵循传入的context，在取消时尽快返回
+//   options: 可选配置，如WithNegativeTTL
+// 返回值:
+//   *LoadingCache[K, V]: 成功创建的缓存实例
+//   error: 当capacity <= 0、ttl <= 0、loader为nil或负缓存TTL为负数时返回非nil错误
+func NewLoadingCache[K comparable, V any](capacity int, ttl time.Duration, loader func(context.Context, K) (V, error), options ...LoadingOption) (*LoadingCache[K, V], error) {
+	if loader == nil {
+		return nil, errors.New("loader must not be nil")
+	}
+
+	opts := loadingCacheOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+	if opts.negativeTTL < 0 {
+		return nil, errors.New("negative TTL must not be negative")
+	}
+
+	cache, err := NewTimedCache[K, V](capacity, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoadingCache[K, V]{
+		cache:       cache,
+		loader:      loader,
+		negativeTTL: opts.negativeTTL,
+		calls:       make(map[K]*loadCall[V]),
+	}, nil
+}
+
+// Get 获取缓存中键对应的值，未命中时调用加载函数
+// 等效于使用context.Background()调用GetCtx，但以exists区分键不存在
+// 参数:
+//   key: 要查找的键
+// 返回值:
+//   value: 键对应的值，键不存在或加载失败时返回V类型的零值
+//   exists: 布尔值，表示键是否存在（来自缓存或加载成功）
+//   err: 加载函数返回的错误（ErrKeyNotFound除外），错误结果不会被缓存
+func (c *LoadingCache[K, V]) Get(key K) (value V, exists bool, err error) {
+	value, err = c.GetCtx(context.Background(), key)
+	if errors.Is(err, ErrKeyNotFound) {
+		return value, false, nil
+	}
+	if err != nil {
+		return value, false, err
+	}
+	return value, true, nil
+}
+
+// GetCtx 获取缓存中键对应的值，未命中时以ctx调用加载函数
+// 命中负缓存时直接返回ErrKeyNotFound，不会调用加载函数
+// 若同一个键已有其它goroutine正在加载，则等待其结果；等待期间ctx被取消时返回ctx.Err()，
+// 进行中的加载不受影响，其结果仍会写入缓存
+// 加载函数使用发起加载的调用方的ctx；该ctx被取消导致加载失败时，ctx仍然有效的等待方会重新发起加载，
+// 而不是收到其他调用方的取消错误
+// 参数:
+//   ctx: 控制加载与等待的上下文
+//   key: 要查找的键
+// 返回值:
+//   value: 键对应的值，键不存在或加载失败时返回V类型的零值
+//   err: 键不存在时返回ErrKeyNotFound；ctx取消时返回ctx.Err()；加载失败时返回加载函数的错误
+func (c *LoadingCache[K, V]) GetCtx(ctx context.Context, key K) (value V, err error) {
+	cached, err := c.cache.GetE(key)
+	if err == nil {
+		return cached, nil
+	}
+	if errors.Is(err, ErrKeyNegative) {
+		return value, ErrKeyNotFound
+	}
+	if err := ctx.Err(); err != nil {
+		return value, err
+	}
+
+	for {
+		c.mu.Lock()
+		if call, ok := c.calls[key]; ok {
+			c.mu.Unlock()
+			select {
+			case <-call.done:
+				if call.canceled && ctx.Err() == nil {
+					continue // 发起加载的调用方已取消，由当前调用方重新加载
+				}
+				return call.value, call.err
+			case <-ctx.Done():
+				return value, ctx.Err()
+			}
+		}
+		// 加锁前可能恰好有加载完成并写入缓存，重新检查以免重复加载
+		cached, err := c.cache.GetE(key)
+		if err == nil {
+			c.mu.Unlock()
+			return cached, nil
+		}
+		if errors.Is(err, ErrKeyNegative) {
+			c.mu.Unlock()
+			return value, ErrKeyNotFound
+		}
+		call := &loadCall[V]{done: make(chan struct{})}
+		c.calls[key] = call
+		c.mu.Unlock()
+
+		c.load(ctx, key, call)
+		return call.value, call.err
+	}
+}
+
+// load 调用加载函数并写入缓存，完成后唤醒所有等待方
+// 加载函数panic时等待方收到错误，panic继续向调用方传播
+func (c *LoadingCache[K, V]) load(ctx context.Context, key K, call *loadCall[V]) {
+	defer func() {
+		if r := recover(); r != nil {
+			call.err = fmt.Errorf("loader panicked: %v", r)
+			c.finish(key, call)
+			panic(r)
+		}
+		c.finish(key, call)
+	}()
+
+	call.value, call.err = c.loader(ctx, key)
+	if errors.Is(call.err, ErrKeyNotFound) {
+		var zero V
+		call.value, call.err = zero, ErrKeyNotFound
+		if c.negativeTTL > 0 {
+			c.cache.SetNegative(key, c.negativeTTL)
+		}
+		return
+	}
+	if call.err != nil {
+		var zero V
+		call.value = zero
+		call.canceled = ctx.Err() != nil
+		return
+	}
+	c.cache.Set(key, call.value)
+}
+
+// finish 移除进行中的加载调用并唤醒等待方
+func (c *LoadingCache[K, V]) finish(key K, call *loadCall[V]) {
+	c.mu.Lock()
+	delete(c.calls, key)
+	c.mu.Unlock()
+	close(call.done)
+}
+
+// Set 直接写入键值对，使用默认TTL
+// 参数:
+//   key: 要存储的键
+//   value: 要存储的值
+func (c *LoadingCache[K, V]) Set(key K, value V) {
+	c.cache.Set(key, value)
+}
+
+// SetIfAbsent 仅当键不存在、已过期或为负缓存条目时直接写入键值对，使用默认TTL
+// 检查和写入是原子的，但不会等待进行中的加载，加载完成后其结果仍会覆盖该值
+// 参数:
+//   key: 要存储的键
+//   value: 要存储的值
+// 返回值:
+//   bool: 是否写入了值
+func (c *LoadingCache[K, V]) SetIfAbsent(key K, value V) bool {
+	return c.cache.SetIfAbsent(key, value)
+}
+
+// SetIfPresent 仅当键存在且未过期时更新其值，并按默认TTL重新计算过期时间
+// 检查和写入是原子的，不会调用加载函数
+// 参数:
+//   key: 要存储的键
+//   value: 要存储的值
+// 返回值:
+//   bool: 是否更新了值
+func (c *LoadingCache[K, V]) SetIfPresent(key K, value V) bool {
+	return c.cache.SetIfPresent(key, value)
+}
+
+// SetNegative 手动缓存键不存在这一结果，在ttl内Get不会调用加载函数
+// 参数:
+//   key: 不存在的键
+//   ttl: 负缓存的生存时间
+func (c *LoadingCache[K, V]) SetNegative(key K, ttl time.Duration) {
+	c.cache.SetNegative(key, ttl)
+}
+
+// Delete 从缓存中删除指定键（包括负缓存条目），下次Get会重新加载
+// 参数:
+//   key: 要删除的键
+func (c *LoadingCache[K, V]) Delete(key K) {
+	c.cache.Delete(key)
+}
+
+// Clone 返回缓存的独立副本，包含相同的条目（包括负缓存条目）和过期时间
+// 副本与原缓存共用加载函数，但进行中的加载不会被复制，其结果只写入原缓存
+// 返回值:
+//   *LoadingCache[K, V]: 新的缓存实例
+func (c *LoadingCache[K, V]) Clone() *LoadingCache[K, V] {
+	return &LoadingCache[K, V]{
+		cache:       c.cache.Clone(),
+		loader:      c.loader,
+		negativeTTL: c.negativeTTL,
+		calls:       make(map[K]*loadCall[V]),
+	}
+}
+
+// Len 返回当前缓存条目数量（包括负缓存条目）
+// 返回值:
+//   int: 缓存中未过期的条目数量
+func (c *LoadingCache[K, V]) Len() int {
+	return c.cache.Len()
+}
+
+// Clear 清空所有缓存条目（包括负缓存条目）
+func (c *LoadingCache[K, V]) Clear() {
+	c.cache.Clear()
+}
@@ -0,0 +1,294 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestLoadingCache_Basic 测试未命中时调用加载函数并缓存结果
+func TestLoadingCache_Basic(t *testing.T) {
+	calls := 0
+	cache, err := NewLoadingCache[int, string](10, time.Second, func(ctx context.Context, key int) (string, error) {
+		calls++
+		return fmt.Sprintf("v%d", key), nil
+	})
+	if err != nil {
+		t.Fatalf("创建Loading缓存失败: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		val, exists, err := cache.Get(1)
+		if err != nil || !exists || val != "v1" {
+			t.Errorf("Get(1) = %v, %v, %v; 期望 'v1', true, nil", val, exists, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("加载函数调用次数 = %d; 期望 1", calls)
+	}
+
+	cache.Set(2, "manual")
+	if val, exists, _ := cache.Get(2); !exists || val != "manual" {
+		t.Errorf("Get(2) = %v, %v; 期望 'manual', true", val, exists)
+	}
+	if calls != 1 {
+		t.Errorf("加载函数调用次数 = %d; 期望 1", calls)
+	}
+
+	cache.Delete(1)
+	cache.Get(1)
+	if calls != 2 {
+		t.Errorf("Delete后加载函数调用次数 = %d; 期望 2", calls)
+	}
+}
+
+// TestLoadingCache_LoaderError 测试加载失败时返回错误且不缓存
+func TestLoadingCache_LoaderError(t *testing.T) {
+	calls := 0
+	errBackend := errors.New("backend unavailable")
+	cache, err := NewLoadingCache[int, string](10, time.Second, func(ctx context.Context, key int) (string, error) {
+		calls++
+		return "", errBackend
+	}, WithNegativeTTL(time.Second))
+	if err != nil {
+		t.Fatalf("创建Loading缓存失败: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, exists, err := cache.Get(1); exists || !errors.Is(err, errBackend) {
+			t.Errorf("Get(1) = %v, %v; 期望 false, errBackend", exists, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("加载函数调用次数 = %d; 期望 2（错误不应被缓存）", calls)
+	}
+}
+
+// TestLoadingCache_NegativeCaching 测试负缓存在TTL内跳过加载函数，过期后重新加载
+func TestLoadingCache_NegativeCaching(t *testing.T) {
+	calls := 0
+	cache, err := NewLoadingCache[int, string](10, time.Second, func(ctx context.Context, key int) (string, error) {
+		calls++
+		return "", ErrKeyNotFound
+	}, WithNegativeTTL(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("创建Loading缓存失败: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		val, exists, err := cache.Get(1)
+		if err != nil || exists || val != "" {
+			t.Errorf("Get(1) = %v, %v, %v; 期望 '', false, nil", val, exists, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("负缓存TTL内加载函数调用次数 = %d; 期望 1", calls)
+	}
+
+	time.Sleep(80 * time.Millisecond)
+	cache.Get(1)
+	if calls != 2 {
+		t.Errorf("负缓存过期后加载函数调用次数 = %d; 期望 2", calls)
+	}
+
+	// 手动负缓存
+	cache.SetNegative(2, 50*time.Millisecond)
+	cache.Get(2)
+	if calls != 2 {
+		t.Errorf("SetNegative后加载函数调用次数 = %d; 期望 2", calls)
+	}
+
+	// 未启用负缓存时每次都调用加载函数
+	calls = 0
+	uncached, err := NewLoadingCache[int, string](10, time.Second, func(ctx context.Context, key int) (string, error) {
+		calls++
+		return "", ErrKeyNotFound
+	})
+	if err != nil {
+		t.Fatalf("创建Loading缓存失败: %v", err)
+	}
+	uncached.Get(1)
+	uncached.Get(1)
+	if calls != 2 {
+		t.Errorf("未启用负缓存时加载函数调用次数 = %d; 期望 2", calls)
+	}
+}
+
+// TestLoadingCache_GetCtxDeduplicates 测试同一个键的并发未命中只调用一次加载函数
+func TestLoadingCache_GetCtxDeduplicates(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	cache, err := NewLoadingCache[int, string](10, time.Second, func(ctx context.Context, key int) (string, error) {
+		calls.Add(1)
+		<-release
+		return "loaded", nil
+	})
+	if err != nil {
+		t.Fatalf("创建Loading缓存失败: %v", err)
+	}
+
+	const goroutines = 20
+	var wg sync.WaitGroup
+	results := make(chan string, goroutines)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			val, err := cache.GetCtx(context.Background(), 1)
+			if err != nil {
+				t.Errorf("GetCtx(1) error = %v", err)
+			}
+			results <- val
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	for val := range results {
+		if val != "loaded" {
+			t.Errorf("GetCtx(1) = %v; 期望 'loaded'", val)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("加载函数调用次数 = %d; 期望 1", calls.Load())
+	}
+}
+
+// TestLoadingCache_GetCtxCancel 测试等待进行中的加载时取消context返回context错误，且不影响进行中的加载
+func TestLoadingCache_GetCtxCancel(t *testing.T) {
+	var calls atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	cache, err := NewLoadingCache[int, string](10, time.Second, func(ctx context.Context, key int) (string, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		return "slow", nil
+	})
+	if err != nil {
+		t.Fatalf("创建Loading缓存失败: %v", err)
+	}
+
+	leaderDone := make(chan string)
+	go func() {
+		val, _ := cache.GetCtx(context.Background(), 1)
+		leaderDone <- val
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := cache.GetCtx(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetCtx(1) error = %v; 期望 context.DeadlineExceeded", err)
+	}
+
+	// 已取消的context直接返回错误
+	cancelled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	if _, err := cache.GetCtx(cancelled, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetCtx(2) error = %v; 期望 context.Canceled", err)
+	}
+
+	close(release)
+	if val := <-leaderDone; val != "slow" {
+		t.Errorf("进行中的加载结果 = %v; 期望 'slow'", val)
+	}
+
+	// 进行中的调用已清理，结果已写入缓存
+	cache.mu.Lock()
+	inFlight := len(cache.calls)
+	cache.mu.Unlock()
+	if inFlight != 0 {
+		t.Errorf("进行中的调用数 = %d; 期望 0", inFlight)
+	}
+	if val, err := cache.GetCtx(context.Background(), 1); err != nil || val != "slow" {
+		t.Errorf("GetCtx(1) = %v, %v; 期望 'slow', nil", val, err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("加载函数调用次数 = %d; 期望 1", calls.Load())
+	}
+}
+
+// TestLoadingCache_GetCtxLeaderCancel 测试发起加载的调用方取消后，ctx仍然有效的等待方重新加载并得到值
+func TestLoadingCache_GetCtxLeaderCancel(t *testing.T) {
+	var calls atomic.Int32
+	started := make(chan struct{})
+	cache, err := NewLoadingCache[int, string](10, time.Second, func(ctx context.Context, key int) (string, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-ctx.Done()
+			return "", ctx.Err()
+		}
+		return "value", nil
+	})
+	if err != nil {
+		t.Fatalf("创建Loading缓存失败: %v", err)
+	}
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error)
+	go func() {
+		_, err := cache.GetCtx(leaderCtx, 1)
+		leaderErr <- err
+	}()
+	<-started
+
+	waiterDone := make(chan struct{})
+	var waiterVal string
+	var waiterErr error
+	go func() {
+		defer close(waiterDone)
+		waiterVal, waiterErr = cache.GetCtx(context.Background(), 1)
+	}()
+	// 等待方开始等待进行中的加载后再取消发起方
+	time.Sleep(20 * time.Millisecond)
+	cancelLeader()
+
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("发起方 GetCtx(1) error = %v; 期望 context.Canceled", err)
+	}
+	<-waiterDone
+	if waiterErr != nil || waiterVal != "value" {
+		t.Errorf("等待方 GetCtx(1) = %v, %v; 期望 'value', nil", waiterVal, waiterErr)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("加载函数调用次数 = %d; 期望 2", calls.Load())
+	}
+}
+
+// TestLoadingCache_GetCtxNotFound 测试GetCtx以ErrKeyNotFound表示键不存在
+func TestLoadingCache_GetCtxNotFound(t *testing.T) {
+	cache, err := NewLoadingCache[int, string](10, time.Second, func(ctx context.Context, key int) (string, error) {
+		return "", fmt.Errorf("lookup %d: %w", key, ErrKeyNotFound)
+	}, WithNegativeTTL(time.Second))
+	if err != nil {
+		t.Fatalf("创建Loading缓存失败: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := cache.GetCtx(context.Background(), 1); err != ErrKeyNotFound {
+			t.Errorf("GetCtx(1) error = %v; 期望 ErrKeyNotFound", err)
+		}
+	}
+}
+
+// TestLoadingCache_InvalidParams 测试非法参数
+func TestLoadingCache_InvalidParams(t *testing.T) {
+	loader := func(ctx context.Context, key int) (string, error) { return "", nil }
+	if _, err := NewLoadingCache[int, string](10, time.Second, nil); err == nil {
+		t.Error("loader为nil时应返回错误")
+	}
+	if _, err := NewLoadingCache(0, time.Second, loader); err == nil {
+		t.Error("capacity为0时应返回错误")
+	}
+	if _, err := NewLoadingCache(10, time.Second, loader, WithNegativeTTL(-time.Second)); err == nil {
+		t.Error("负缓存TTL为负数时应返回错误")
+	}
+}
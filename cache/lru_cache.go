@@ -106,6 +106,51 @@ func (l *LRUCache[K, V]) Set(key K, value V) {
 		defer l.mu.Unlock()
 	}
 
+	l.set(key, value)
+}
+
+// SetIfAbsent 仅当键不存在时写入键值对，写入方式与Set相同（标记为最近使用，缓存满时淘汰）
+// 检查和写入在同一次加锁内完成，是原子的
+// 参数:
+//   key: 要存储的键
+//   value: 要存储的值
+// 返回值:
+//   bool: 是否写入了值，键已存在时返回false
+func (l *LRUCache[K, V]) SetIfAbsent(key K, value V) bool {
+	if l.concurrentSafe {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+	}
+
+	if _, exists := l.cache[key]; exists {
+		return false
+	}
+	l.set(key, value)
+	return true
+}
+
+// SetIfPresent 仅当键已存在时更新其值，并将该键标记为最近使用
+// 检查和写入在同一次加锁内完成，是原子的
+// 参数:
+//   key: 要存储的键
+//   value: 要存储的值
+// 返回值:
+//   bool: 是否更新了值，键不存在时返回false
+func (l *LRUCache[K, V]) SetIfPresent(key K, value V) bool {
+	if l.concurrentSafe {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+	}
+
+	if _, exists := l.cache[key]; !exists {
+		return false
+	}
+	l.set(key, value)
+	return true
+}
+
+// set 存储键值对，此方法应在持有锁的情况下调用
+func (l *LRUCache[K, V]) set(key K, value V) {
 	// 如果键已存在，更新值并移到头部
 	if elem, exists := l.cache[key]; exists {
 		elem.Value.(*entry[K, V]).value = value
@@ -150,6 +195,65 @@ func (l *LRUCache[K, V]) Delete(key K) {
 	delete(l.cache, key)
 }
 
+// GetAll 返回所有条目的快照
+// 在持有锁的情况下复制全部键值对，不会改变元素的访问顺序
+// 返回的map是独立副本，修改它不会影响缓存
+// 返回值:
+//   map[K]V: 缓存中的所有键值对
+func (l *LRUCache[K, V]) GetAll() map[K]V {
+	if l.concurrentSafe {
+		l.mu.RLock()
+		defer l.mu.RUnlock()
+	}
+
+	result := make(map[K]V, len(l.cache))
+	for key, elem := range l.cache {
+		result[key] = elem.Value.(*entry[K, V]).value
+	}
+	return result
+}
+
+// Clone 返回缓存的独立副本，包含相同的条目和访问顺序
+// 复制在持有读锁的情况下完成，之后对副本或原缓存的修改互不影响
+// 返回值:
+//   *LRUCache[K, V]: 容量和并发安全配置与原缓存相同的新缓存
+func (l *LRUCache[K, V]) Clone() *LRUCache[K, V] {
+	if l.concurrentSafe {
+		l.mu.RLock()
+		defer l.mu.RUnlock()
+	}
+
+	clone := &LRUCache[K, V]{
+		cache:          make(map[K]*list.Element, len(l.cache)),
+		list:           list.New(),
+		capacity:       l.capacity,
+		concurrentSafe: l.concurrentSafe,
+	}
+	for elem := l.list.Front(); elem != nil; elem = elem.Next() {
+		e := elem.Value.(*entry[K, V])
+		clone.cache[e.key] = clone.list.PushBack(&entry[K, V]{key: e.key, value: e.value})
+	}
+	return clone
+}
+
+// Range 依次对每个条目调用fn，fn返回false时停止遍历
+// 遍历在持有读锁的情况下进行，不会改变元素的访问顺序，遍历顺序不确定
+// fn中不能调用该缓存的方法，否则会死锁
+// 参数:
+//   fn: 处理每个键值对的函数，返回false表示停止
+func (l *LRUCache[K, V]) Range(fn func(key K, value V) bool) {
+	if l.concurrentSafe {
+		l.mu.RLock()
+		defer l.mu.RUnlock()
+	}
+
+	for key, elem := range l.cache {
+		if !fn(key, elem.Value.(*entry[K, V]).value) {
+			return
+		}
+	}
+}
+
 // Len 返回当前缓存中的元素数量
 // 返回值:
 //   int: 缓存中已存储的键值对数量
@@ -204,6 +204,57 @@ func TestLRUCache_Clear(t *testing.T) {
 	}
 }
 
+// TestLRUCache_GetAll 测试GetAll返回独立副本且不改变访问顺序
+func TestLRUCache_GetAll(t *testing.T) {
+	lru, err := NewLRUCache[int, string](2)
+	if err != nil {
+		t.Fatalf("创建LRU缓存失败: %v", err)
+	}
+
+	lru.Set(1, "a")
+	lru.Set(2, "b")
+
+	all := lru.GetAll()
+	if len(all) != 2 || all[1] != "a" || all[2] != "b" {
+		t.Errorf("GetAll() = %v; 期望 map[1:a 2:b]", all)
+	}
+
+	all[1] = "modified"
+	if val, _ := lru.Get(1); val != "a" {
+		t.Errorf("Get(1) = %v; 期望 'a'", val)
+	}
+
+	// GetAll不应改变访问顺序：此时最久未使用的是2
+	lru.GetAll()
+	lru.Set(3, "c")
+	if _, exists := lru.Get(2); exists {
+		t.Error("Get(2) 应该被淘汰，但存在")
+	}
+}
+
+// TestLRUCache_GetByPrefix 测试按前缀查询不改变访问顺序
+func TestLRUCache_GetByPrefix(t *testing.T) {
+	type configKey string
+	lru, err := NewLRUCache[configKey, int](3)
+	if err != nil {
+		t.Fatalf("创建LRU缓存失败: %v", err)
+	}
+	lru.Set("app.db.host", 1)
+	lru.Set("app.db.port", 2)
+	lru.Set("app.cache.size", 3)
+
+	got := GetByPrefix[configKey, int](lru, "app.db.")
+	if len(got) != 2 || got["app.db.host"] != 1 || got["app.db.port"] != 2 {
+		t.Errorf("GetByPrefix(app.db.) = %v; 期望 map[app.db.host:1 app.db.port:2]", got)
+	}
+
+	// 查询不应刷新访问顺序，最早写入的app.db.host仍应最先被淘汰
+	lru.Set("web.db.host", 4)
+	if _, exists := lru.Get("app.db.host"); exists {
+		t.Error("Get(app.db.host) 应该被淘汰，但存在")
+	}
+}
+
 // BenchmarkLRUCache_SetGet 基准测试Set和Get操作性能
 func BenchmarkLRUCache_SetGet(b *testing.B) {
 	lru, _ := NewLRUCache[int, int](1000)
@@ -0,0 +1,59 @@
+package cache
+
+// Key2 由两个参数组成的复合键，用作Memoize2的缓存键
+// A、B为两个参数的类型，必须支持比较操作
+type Key2[A, B comparable] struct {
+	First  A // 第一个参数
+	Second B // 第二个参数
+}
+
+// Memoize 返回fn的记忆化版本，按参数缓存计算结果，适用于开销较大的纯函数
+// 结果存储在调用方提供的cache中，因此容量、过期和淘汰策略由cache决定；
+// 同一个参数的并发调用按键串行执行并在计算前再次检查缓存，只有第一个调用方会执行fn，
+// 其余调用方直接使用其结果（GetOrSet语义），不同参数之间互不阻塞
+// 结果被淘汰或过期后，下一次调用会重新计算
+// 参数:
+//
+//	fn: 要记忆化的函数，应为纯函数（相同参数总是返回相同结果且无副作用）
+//	cache: 存储结果的缓存，应支持并发访问
+//
+// 返回值:
+//
+//	func(A) R: 记忆化后的函数，可并发调用
+func Memoize[A comparable, R any](fn func(A) R, cache Cache[A, R]) func(A) R {
+	var locks KeyedMutex[A]
+	return func(arg A) R {
+		if result, ok := cache.Get(arg); ok {
+			return result
+		}
+
+		locks.Lock(arg)
+		defer locks.Unlock(arg)
+		// 等待锁期间其它调用方可能已经完成计算
+		if result, ok := cache.Get(arg); ok {
+			return result
+		}
+		result := fn(arg)
+		cache.Set(arg, result)
+		return result
+	}
+}
+
+// Memoize2 返回两参数函数fn的记忆化版本，以两个参数组成的Key2作为缓存键
+// 语义与Memoize相同
+// 参数:
+//
+//	fn: 要记忆化的函数，应为纯函数
+//	cache: 存储结果的缓存，键类型为Key2[A, B]
+//
+// 返回值:
+//
+//	func(A, B) R: 记忆化后的函数，可并发调用
+func Memoize2[A, B comparable, R any](fn func(A, B) R, cache Cache[Key2[A, B], R]) func(A, B) R {
+	memoized := Memoize(func(key Key2[A, B]) R {
+		return fn(key.First, key.Second)
+	}, cache)
+	return func(a A, b B) R {
+		return memoized(Key2[A, B]{First: a, Second: b})
+	}
+}
@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestMemoize 测试每个不同参数只调用一次fn，且结果正确
+func TestMemoize(t *testing.T) {
+	lru, err := NewLRUCache[int, int](10)
+	if err != nil {
+		t.Fatalf("创建LRU缓存失败: %v", err)
+	}
+	calls := make(map[int]int)
+	square := Memoize(func(n int) int {
+		calls[n]++
+		return n * n
+	}, lru)
+
+	for round := 0; round < 3; round++ {
+		for n := 0; n < 5; n++ {
+			if got := square(n); got != n*n {
+				t.Errorf("square(%d) = %d; 期望 %d", n, got, n*n)
+			}
+		}
+	}
+	for n := 0; n < 5; n++ {
+		if calls[n] != 1 {
+			t.Errorf("参数%d的调用次数 = %d; 期望 1", n, calls[n])
+		}
+	}
+}
+
+// TestMemoize_Evicted 测试结果被淘汰后会重新计算
+func TestMemoize_Evicted(t *testing.T) {
+	lru, err := NewLRUCache[string, int](1)
+	if err != nil {
+		t.Fatalf("创建LRU缓存失败: %v", err)
+	}
+	calls := 0
+	length := Memoize(func(s string) int {
+		calls++
+		return len(s)
+	}, lru)
+
+	length("a")
+	length("bb") // 淘汰"a"
+	if got := length("a"); got != 1 {
+		t.Errorf("length(a) = %d; 期望 1", got)
+	}
+	if calls != 3 {
+		t.Errorf("调用次数 = %d; 期望 3", calls)
+	}
+}
+
+// TestMemoize_Concurrent 测试同一个参数的并发调用只计算一次
+func TestMemoize_Concurrent(t *testing.T) {
+	lru, err := NewLRUCache[int, int](100)
+	if err != nil {
+		t.Fatalf("创建LRU缓存失败: %v", err)
+	}
+	var calls [10]atomic.Int32
+	slow := Memoize(func(n int) int {
+		calls[n].Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return n * 10
+	}, lru)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			if got := slow(n); got != n*10 {
+				t.Errorf("slow(%d) = %d; 期望 %d", n, got, n*10)
+			}
+		}(i % 10)
+	}
+	wg.Wait()
+
+	for n := range calls {
+		if got := calls[n].Load(); got != 1 {
+			t.Errorf("参数%d的调用次数 = %d; 期望 1", n, got)
+		}
+	}
+}
+
+// TestMemoize2 测试两参数函数按参数组合缓存结果
+func TestMemoize2(t *testing.T) {
+	lru, err := NewLRUCache[Key2[string, int], string](10)
+	if err != nil {
+		t.Fatalf("创建LRU缓存失败: %v", err)
+	}
+	calls := 0
+	repeat := Memoize2(func(s string, n int) string {
+		calls++
+		result := ""
+		for i := 0; i < n; i++ {
+			result += s
+		}
+		return result
+	}, lru)
+
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"ab", 2, "abab"},
+		{"ab", 3, "ababab"},
+		{"x", 2, "xx"},
+		{"ab", 2, "abab"},
+		{"x", 2, "xx"},
+	}
+	for _, tt := range tests {
+		if got := repeat(tt.s, tt.n); got != tt.want {
+			t.Errorf("repeat(%q, %d) = %q; 期望 %q", tt.s, tt.n, got, tt.want)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("调用次数 = %d; 期望 3", calls)
+	}
+	if val, exists := lru.Get(Key2[string, int]{"ab", 3}); !exists || val != "ababab" {
+		t.Errorf("Get({ab, 3}) = %v, %v; 期望 ababab, true", val, exists)
+	}
+}
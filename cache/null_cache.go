@@ -0,0 +1,52 @@
+package cache
+
+// NullCache 不存储任何数据的空缓存实现
+// Get始终未命中，Set/Delete/Clear均为空操作，Len始终为0
+// 用于通过注入不同实现来关闭缓存功能，避免调用方到处判断缓存是否启用
+// K为键类型，必须支持比较操作；V为值类型，可以是任意类型
+type NullCache[K comparable, V any] struct{}
+
+// NewNullCache 创建新的空缓存实例
+// 返回值:
+//
+//	*NullCache[K, V]: 空缓存实例
+func NewNullCache[K comparable, V any]() *NullCache[K, V] {
+	return &NullCache[K, V]{}
+}
+
+// Get 始终返回V类型的零值和false
+func (n *NullCache[K, V]) Get(key K) (value V, exists bool) {
+	return value, false
+}
+
+// Set 空操作，不存储任何数据
+func (n *NullCache[K, V]) Set(key K, value V) {}
+
+// SetIfAbsent 键始终不存在，因此总是返回true，但不存储任何数据
+// 所有调用方都会得到true，不能用于实现互斥
+func (n *NullCache[K, V]) SetIfAbsent(key K, value V) bool {
+	return true
+}
+
+// SetIfPresent 键始终不存在，因此总是返回false
+func (n *NullCache[K, V]) SetIfPresent(key K, value V) bool {
+	return false
+}
+
+// Delete 空操作
+func (n *NullCache[K, V]) Delete(key K) {}
+
+// Len 始终返回0
+func (n *NullCache[K, V]) Len() int {
+	return 0
+}
+
+// Clone 返回新的空缓存实例
+// 返回值:
+//   *NullCache[K, V]: 空缓存实例
+func (n *NullCache[K, V]) Clone() *NullCache[K, V] {
+	return NewNullCache[K, V]()
+}
+
+// Clear 空操作
+func (n *NullCache[K, V]) Clear() {}
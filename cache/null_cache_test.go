@@ -0,0 +1,31 @@
+package cache
+
+import (
+	"testing"
+)
+
+// 确保NullCache实现了Cache接口
+var _ Cache[string, int] = (*NullCache[string, int])(nil)
+
+// TestNullCache 测试空缓存始终未命中且不存储数据
+func TestNullCache(t *testing.T) {
+	var cache Cache[int, string] = NewNullCache[int, string]()
+
+	cache.Set(1, "a")
+	cache.Set(2, "b")
+
+	val, exists := cache.Get(1)
+	if exists || val != "" {
+		t.Errorf("Get(1) = %v, %v; 期望 '', false", val, exists)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Len() = %d; 期望 0", cache.Len())
+	}
+
+	// Delete和Clear不应产生任何影响
+	cache.Delete(1)
+	cache.Clear()
+	if cache.Len() != 0 {
+		t.Errorf("Clear() 后 Len() = %d; 期望 0", cache.Len())
+	}
+}
@@ -0,0 +1,83 @@
+package cache
+
+// readOnlyOptions 用于配置只读视图的选项
+type readOnlyOptions struct {
+	panicOnWrite bool // 写操作是否panic
+}
+
+// ReadOnlyOption 定义配置只读视图的函数类型
+type ReadOnlyOption func(*readOnlyOptions)
+
+// WithPanicOnWrite 设置只读视图的写操作是否panic
+// 默认写操作静默忽略；启用后Set、Delete和Clear会以ErrReadOnly为值panic，便于在测试中尽早发现误用
+// 参数:
+//
+//	enabled: true表示写操作panic，false表示静默忽略（默认）
+//
+// 返回值:
+//
+//	ReadOnlyOption: 用于配置只读视图的选项函数
+func WithPanicOnWrite(enabled bool) ReadOnlyOption {
+	return func(o *readOnlyOptions) {
+		o.panicOnWrite = enabled
+	}
+}
+
+// readOnlyCache 缓存的只读视图
+type readOnlyCache[K comparable, V any] struct {
+	cache        Cache[K, V] // 被包装的缓存
+	panicOnWrite bool        // 写操作是否panic
+}
+
+// ReadOnly 返回缓存的只读视图，用于把缓存交给只应读取的组件
+// Get和Len委托给原缓存，Set、Delete和Clear不会修改原缓存；
+// 返回值只暴露Cache接口，调用方无法通过类型断言取回原缓存的具体类型
+// 视图不复制数据，原缓存之后的修改对视图立即可见；并发安全性取决于原缓存
+// 注意: 对于LRU等读取会更新访问状态的缓存，通过视图的Get仍会影响其淘汰顺序
+// 参数:
+//
+//	c: 被包装的缓存
+//	options: 可选配置，如WithPanicOnWrite
+//
+// 返回值:
+//
+//	Cache[K, V]: 只读视图
+func ReadOnly[K comparable, V any](c Cache[K, V], options ...ReadOnlyOption) Cache[K, V] {
+	var opts readOnlyOptions
+	for _, option := range options {
+		option(&opts)
+	}
+	return &readOnlyCache[K, V]{cache: c, panicOnWrite: opts.panicOnWrite}
+}
+
+// Get 从原缓存获取键对应的值
+func (r *readOnlyCache[K, V]) Get(key K) (value V, exists bool) {
+	return r.cache.Get(key)
+}
+
+// Set 不修改原缓存
+func (r *readOnlyCache[K, V]) Set(key K, value V) {
+	r.rejectWrite()
+}
+
+// Delete 不修改原缓存
+func (r *readOnlyCache[K, V]) Delete(key K) {
+	r.rejectWrite()
+}
+
+// Len 返回原缓存的条目数量
+func (r *readOnlyCache[K, V]) Len() int {
+	return r.cache.Len()
+}
+
+// Clear 不修改原缓存
+func (r *readOnlyCache[K, V]) Clear() {
+	r.rejectWrite()
+}
+
+// rejectWrite 处理写操作：启用WithPanicOnWrite时panic，否则忽略
+func (r *readOnlyCache[K, V]) rejectWrite() {
+	if r.panicOnWrite {
+		panic(ErrReadOnly)
+	}
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"testing"
+)
+
+// TestReadOnly 测试只读视图可以读取，写操作不影响原缓存
+func TestReadOnly(t *testing.T) {
+	lru, err := NewLRUCache[string, int](10)
+	if err != nil {
+		t.Fatalf("创建LRU缓存失败: %v", err)
+	}
+	lru.Set("a", 1)
+	lru.Set("b", 2)
+
+	view := ReadOnly[string, int](lru)
+	if val, exists := view.Get("a"); !exists || val != 1 {
+		t.Errorf("Get(a) = %v, %v; 期望 1, true", val, exists)
+	}
+	if view.Len() != 2 {
+		t.Errorf("Len() = %d; 期望 2", view.Len())
+	}
+
+	view.Set("a", 100)
+	view.Set("c", 3)
+	view.Delete("b")
+	if val, exists := lru.Get("a"); !exists || val != 1 {
+		t.Errorf("通过视图Set后原缓存 Get(a) = %v, %v; 期望 1, true", val, exists)
+	}
+	if _, exists := lru.Get("c"); exists {
+		t.Error("通过视图Set后原缓存不应包含c")
+	}
+	if _, exists := lru.Get("b"); !exists {
+		t.Error("通过视图Delete后原缓存仍应包含b")
+	}
+	view.Clear()
+	if lru.Len() != 2 {
+		t.Errorf("通过视图Clear后原缓存 Len() = %d; 期望 2", lru.Len())
+	}
+
+	// 原缓存的修改对视图立即可见
+	lru.Set("c", 3)
+	if val, exists := view.Get("c"); !exists || val != 3 {
+		t.Errorf("Get(c) = %v, %v; 期望 3, true", val, exists)
+	}
+	if _, ok := view.(*LRUCache[string, int]); ok {
+		t.Error("只读视图不应能断言回原缓存类型")
+	}
+}
+
+// TestReadOnly_PanicOnWrite 测试启用WithPanicOnWrite后写操作panic且原缓存不变
+func TestReadOnly_PanicOnWrite(t *testing.T) {
+	lru, err := NewLRUCache[string, int](10)
+	if err != nil {
+		t.Fatalf("创建LRU缓存失败: %v", err)
+	}
+	lru.Set("a", 1)
+	view := ReadOnly[string, int](lru, WithPanicOnWrite(true))
+
+	writes := map[string]func(){
+		"Set":    func() { view.Set("a", 2) },
+		"Delete": func() { view.Delete("a") },
+		"Clear":  func() { view.Clear() },
+	}
+	for name, write := range writes {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != ErrReadOnly {
+					t.Errorf("%s panic值 = %v; 期望 ErrReadOnly", name, r)
+				}
+			}()
+			write()
+		})
+	}
+	if val, exists := view.Get("a"); !exists || val != 1 {
+		t.Errorf("Get(a) = %v, %v; 期望 1, true", val, exists)
+	}
+}
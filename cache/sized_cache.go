@@ -0,0 +1,246 @@
+package cache
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+)
+
+// SizedCache 按估算字节数限制容量的缓存实现
+// 与按条目数限制容量的缓存不同，当所有条目的估算大小之和超过maxBytes时，
+// 按最近最久未使用(LRU)顺序淘汰条目，直到总大小回到限制以内
+// 适用于值大小差异悬殊、需要控制内存占用的大对象缓存
+// K为键类型，必须支持比较操作；V为值类型，可以是任意类型
+type SizedCache[K comparable, V any] struct {
+	cache          map[K]*list.Element // 键到链表元素的映射
+	list           *list.List          // 维护访问顺序的双向链表，越靠近头部越是最近访问的元素
+	maxBytes       int64               // 最大总字节数
+	curBytes       int64               // 当前所有条目的估算字节数之和
+	sizeOf         func(K, V) int64    // 估算单个条目大小的函数
+	concurrentSafe bool                // 是否启用并发安全模式
+	mu             sync.Mutex          // 互斥锁，在并发安全模式下使用
+}
+
+// sizedEntry 链表节点存储的数据结构，记录条目大小以便淘汰时扣减
+type sizedEntry[K comparable, V any] struct {
+	key   K
+	value V
+	size  int64
+}
+
+// SizedOption 定义SizedCache的配置选项函数类型
+type SizedOption func(*sizedCacheOptions)
+
+// sizedCacheOptions SizedCache的配置选项
+type sizedCacheOptions struct {
+	concurrentSafe bool
+}
+
+// WithSizedConcurrentSafe 设置是否启用并发安全模式
+func WithSizedConcurrentSafe(concurrentSafe bool) SizedOption {
+	return func(o *sizedCacheOptions) {
+		o.concurrentSafe = concurrentSafe
+	}
+}
+
+// NewSizedCache 创建新的按字节数限制容量的缓存实例
+// maxBytes为所有条目估算大小之和的上限，必须大于0
+// sizeOf用于估算单个条目占用的字节数，不能为nil
+// 返回值:
+//
+//	*SizedCache[K, V]: 成功创建的缓存实例
+//	error: 当maxBytes <= 0或sizeOf为nil时返回非nil错误
+func NewSizedCache[K comparable, V any](maxBytes int64, sizeOf func(K, V) int64, options ...SizedOption) (*SizedCache[K, V], error) {
+	if maxBytes <= 0 {
+		return nil, errors.New("max bytes must be positive")
+	}
+	if sizeOf == nil {
+		return nil, errors.New("sizeOf function must not be nil")
+	}
+
+	opts := sizedCacheOptions{
+		concurrentSafe: true, // 默认启用并发安全
+	}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	return &SizedCache[K, V]{
+		cache:          make(map[K]*list.Element),
+		list:           list.New(),
+		maxBytes:       maxBytes,
+		sizeOf:         sizeOf,
+		concurrentSafe: opts.concurrentSafe,
+	}, nil
+}
+
+// Get 从缓存中获取键对应的值，命中时将该键标记为最近使用
+func (s *SizedCache[K, V]) Get(key K) (value V, exists bool) {
+	if s.concurrentSafe {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+
+	elem, exists := s.cache[key]
+	if !exists {
+		return value, false
+	}
+
+	s.list.MoveToFront(elem)
+	return elem.Value.(*sizedEntry[K, V]).value, true
+}
+
+// Set 将键值对存入缓存并标记为最近使用
+// 写入后如果总大小超过maxBytes，则从最久未使用的条目开始淘汰
+// 单个条目的大小超过maxBytes时不会被缓存（同时移除该键的旧值）
+func (s *SizedCache[K, V]) Set(key K, value V) {
+	if s.concurrentSafe {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+
+	s.set(key, value)
+}
+
+// SetIfAbsent 仅当键不存在时写入键值对，返回是否执行了写入；检查和写入是原子的
+// 与Set一样，大小超过maxBytes的条目不会被缓存，此时仍返回true
+func (s *SizedCache[K, V]) SetIfAbsent(key K, value V) bool {
+	if s.concurrentSafe {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+
+	if _, exists := s.cache[key]; exists {
+		return false
+	}
+	s.set(key, value)
+	return true
+}
+
+// SetIfPresent 仅当键已存在时更新其值，返回是否执行了更新；检查和更新是原子的
+// 与Set一样，新值的大小超过maxBytes时该键会被移除，此时仍返回true
+func (s *SizedCache[K, V]) SetIfPresent(key K, value V) bool {
+	if s.concurrentSafe {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+
+	if _, exists := s.cache[key]; !exists {
+		return false
+	}
+	s.set(key, value)
+	return true
+}
+
+// set 存储键值对并按需淘汰，此方法应在持有锁的情况下调用
+func (s *SizedCache[K, V]) set(key K, value V) {
+	size := s.sizeOf(key, value)
+	if size > s.maxBytes {
+		s.removeKey(key)
+		return
+	}
+
+	if elem, exists := s.cache[key]; exists {
+		e := elem.Value.(*sizedEntry[K, V])
+		s.curBytes += size - e.size
+		e.value = value
+		e.size = size
+		s.list.MoveToFront(elem)
+	} else {
+		elem := s.list.PushFront(&sizedEntry[K, V]{key: key, value: value, size: size})
+		s.cache[key] = elem
+		s.curBytes += size
+	}
+
+	// 按LRU顺序淘汰，直到总大小不超过限制
+	for s.curBytes > s.maxBytes {
+		back := s.list.Back()
+		if back == nil {
+			break
+		}
+		s.removeElement(back)
+	}
+}
+
+// Delete 从缓存中删除指定键
+// 如果键不存在，此操作无效果
+func (s *SizedCache[K, V]) Delete(key K) {
+	if s.concurrentSafe {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+
+	s.removeKey(key)
+}
+
+// Clone 返回缓存的独立副本，包含相同的条目、访问顺序和总大小
+// 复制在持有锁的情况下完成，之后对副本或原缓存的修改互不影响，sizeOf函数由两者共用
+// 返回值:
+//   *SizedCache[K, V]: 容量上限和并发安全配置与原缓存相同的新缓存
+func (s *SizedCache[K, V]) Clone() *SizedCache[K, V] {
+	if s.concurrentSafe {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+
+	clone := &SizedCache[K, V]{
+		cache:          make(map[K]*list.Element, len(s.cache)),
+		list:           list.New(),
+		maxBytes:       s.maxBytes,
+		curBytes:       s.curBytes,
+		sizeOf:         s.sizeOf,
+		concurrentSafe: s.concurrentSafe,
+	}
+	for elem := s.list.Front(); elem != nil; elem = elem.Next() {
+		e := elem.Value.(*sizedEntry[K, V])
+		clone.cache[e.key] = clone.list.PushBack(&sizedEntry[K, V]{key: e.key, value: e.value, size: e.size})
+	}
+	return clone
+}
+
+// Len 返回当前缓存中的元素数量
+func (s *SizedCache[K, V]) Len() int {
+	if s.concurrentSafe {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+
+	return s.list.Len()
+}
+
+// Size 返回当前所有条目的估算字节数之和
+func (s *SizedCache[K, V]) Size() int64 {
+	if s.concurrentSafe {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+
+	return s.curBytes
+}
+
+// Clear 清空缓存中的所有元素
+func (s *SizedCache[K, V]) Clear() {
+	if s.concurrentSafe {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+
+	s.list.Init()
+	s.cache = make(map[K]*list.Element)
+	s.curBytes = 0
+}
+
+// removeKey 删除指定键（如果存在），此方法应在持有锁的情况下调用
+func (s *SizedCache[K, V]) removeKey(key K) {
+	if elem, exists := s.cache[key]; exists {
+		s.removeElement(elem)
+	}
+}
+
+// removeElement 从链表和哈希表中移除元素并扣减总大小，此方法应在持有锁的情况下调用
+func (s *SizedCache[K, V]) removeElement(elem *list.Element) {
+	e := elem.Value.(*sizedEntry[K, V])
+	s.list.Remove(elem)
+	delete(s.cache, e.key)
+	s.curBytes -= e.size
+}
@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"testing"
+)
+
+// byteLen 以字节切片长度作为条目大小
+func byteLen(_ string, v []byte) int64 {
+	return int64(len(v))
+}
+
+// TestSizedCache_Basic 测试基本的Set和Get操作及大小统计
+func TestSizedCache_Basic(t *testing.T) {
+	cache, err := NewSizedCache[string, []byte](100, byteLen)
+	if err != nil {
+		t.Fatalf("创建Sized缓存失败: %v", err)
+	}
+
+	cache.Set("a", make([]byte, 10))
+	cache.Set("b", make([]byte, 20))
+	if cache.Size() != 30 {
+		t.Errorf("Size() = %d; 期望 30", cache.Size())
+	}
+
+	val, exists := cache.Get("a")
+	if !exists || len(val) != 10 {
+		t.Errorf("Get(a) = %d字节, %v; 期望 10字节, true", len(val), exists)
+	}
+
+	// 更新值时大小应同步调整
+	cache.Set("a", make([]byte, 5))
+	if cache.Size() != 25 {
+		t.Errorf("更新后 Size() = %d; 期望 25", cache.Size())
+	}
+
+	cache.Delete("b")
+	if cache.Size() != 5 || cache.Len() != 1 {
+		t.Errorf("删除后 Size() = %d, Len() = %d; 期望 5, 1", cache.Size(), cache.Len())
+	}
+
+	cache.Clear()
+	if cache.Size() != 0 || cache.Len() != 0 {
+		t.Errorf("Clear() 后 Size() = %d, Len() = %d; 期望 0, 0", cache.Size(), cache.Len())
+	}
+}
+
+// TestSizedCache_Eviction 测试按字节数而非条目数触发淘汰
+func TestSizedCache_Eviction(t *testing.T) {
+	cache, err := NewSizedCache[string, []byte](100, byteLen)
+	if err != nil {
+		t.Fatalf("创建Sized缓存失败: %v", err)
+	}
+
+	// 大量小条目不会触发淘汰
+	for _, k := range []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"} {
+		cache.Set(k, make([]byte, 10))
+	}
+	if cache.Len() != 10 || cache.Size() != 100 {
+		t.Fatalf("Len() = %d, Size() = %d; 期望 10, 100", cache.Len(), cache.Size())
+	}
+
+	// 访问"1"使其成为最近使用
+	cache.Get("1")
+
+	// 写入一个大条目，需要淘汰最久未使用的"2"、"3"、"4"、"5"、"6"
+	cache.Set("big", make([]byte, 50))
+	if cache.Size() > 100 {
+		t.Errorf("Size() = %d; 期望不超过 100", cache.Size())
+	}
+	for _, k := range []string{"2", "3", "4", "5", "6"} {
+		if _, exists := cache.Get(k); exists {
+			t.Errorf("Get(%s) 应该被淘汰，但存在", k)
+		}
+	}
+	for _, k := range []string{"1", "7", "big"} {
+		if _, exists := cache.Get(k); !exists {
+			t.Errorf("Get(%s) 应该存在", k)
+		}
+	}
+
+	// 超过上限的单个条目不会被缓存
+	cache.Set("huge", make([]byte, 101))
+	if _, exists := cache.Get("huge"); exists {
+		t.Error("Get(huge) 超过上限不应该被缓存")
+	}
+}
+
+// TestNewSizedCache_Invalid 测试非法参数
+func TestNewSizedCache_Invalid(t *testing.T) {
+	if _, err := NewSizedCache[string, []byte](0, byteLen); err == nil {
+		t.Error("maxBytes为0时应该返回错误")
+	}
+	if _, err := NewSizedCache[string, []byte](100, nil); err == nil {
+		t.Error("sizeOf为nil时应该返回错误")
+	}
+}
+
+// TestSizedCache_Clone 测试副本保留大小统计和访问顺序，且修改副本不影响原缓存
+func TestSizedCache_Clone(t *testing.T) {
+	cache, err := NewSizedCache[string, []byte](100, byteLen)
+	if err != nil {
+		t.Fatalf("创建Sized缓存失败: %v", err)
+	}
+	cache.Set("a", make([]byte, 40))
+	cache.Set("b", make([]byte, 40))
+
+	clone := cache.Clone()
+	if clone.Size() != 80 {
+		t.Errorf("副本 Size() = %d; 期望 80", clone.Size())
+	}
+	clone.Set("c", make([]byte, 40)) // 副本中淘汰最久未使用的a
+	if _, exists := clone.Get("a"); exists {
+		t.Error("副本 Get(a) 应该被淘汰，但存在")
+	}
+
+	if cache.Size() != 80 || cache.Len() != 2 {
+		t.Errorf("原缓存 Size() = %d, Len() = %d; 期望 80, 2", cache.Size(), cache.Len())
+	}
+	if _, exists := cache.Get("a"); !exists {
+		t.Error("原缓存 Get(a) 应该存在")
+	}
+}
@@ -0,0 +1,213 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// TaggedCache 支持按标签批量失效的缓存
+// 写入时可以为条目附加任意个标签（如"user:42"），之后通过InvalidateTag一次删除带有该标签的全部条目，
+// 适用于"用户资料变更后清除与该用户相关的所有缓存"这类场景
+// 底层使用TimedCache存储，并维护标签到键的索引；条目因容量淘汰或过期被删除时，
+// 通过OnEvict和OnExpire回调同步清理索引，索引不会无限增长
+// 所有操作由同一把锁串行化，是并发安全的
+// K为键类型（必须可比较），V为值类型
+type TaggedCache[K comparable, V any] struct {
+	cache     *TimedCache[K, V]         // 底层存储
+	keysByTag map[string]map[K]struct{} // 标签到键集合的索引
+	tagsByKey map[K][]string            // 键到标签列表的反向索引，用于删除键时清理索引
+	mu        sync.Mutex                // 保护底层缓存的调用和索引，淘汰回调在持有该锁时执行
+}
+
+// NewTaggedCache 创建新的标签缓存实例
+// 参数:
+//   capacity: 最大缓存条目数，必须大于0
+//   defaultTTL: 默认过期时间，必须大于0
+//   options: 底层TimedCache的选项，WithOnEvict和WithOnExpire设置的回调会在清理索引后照常调用，
+//            回调中同样不能调用该缓存的方法
+// 返回值:
+//   *TaggedCache[K, V]: 成功创建的缓存实例
+//   error: 参数不合法或回调类型与缓存不匹配时返回非nil错误，与NewTimedCache一致
+func NewTaggedCache[K comparable, V any](capacity int, defaultTTL time.Duration, options ...TimedOption) (*TaggedCache[K, V], error) {
+	var opts timedCacheOptions
+	for _, option := range options {
+		option(&opts)
+	}
+	userEvict, ok := opts.onEvict.(func(K, V))
+	if opts.onEvict != nil && !ok {
+		return nil, errors.New("OnEvict callback type does not match cache key/value types")
+	}
+	userExpire, ok := opts.onExpire.(func(K, V))
+	if opts.onExpire != nil && !ok {
+		return nil, errors.New("OnExpire callback type does not match cache key/value types")
+	}
+
+	t := &TaggedCache[K, V]{
+		keysByTag: make(map[string]map[K]struct{}),
+		tagsByKey: make(map[K][]string),
+	}
+	// 追加在调用方选项之后，覆盖其回调并在清理索引后转调
+	options = append(options[:len(options):len(options)],
+		WithOnEvict(func(key K, value V) {
+			t.untag(key)
+			if userEvict != nil {
+				userEvict(key, value)
+			}
+		}),
+		WithOnExpire(func(key K, value V) {
+			t.untag(key)
+			if userExpire != nil {
+				userExpire(key, value)
+			}
+		}),
+	)
+	cache, err := NewTimedCache[K, V](capacity, defaultTTL, options...)
+	if err != nil {
+		return nil, err
+	}
+	t.cache = cache
+	return t, nil
+}
+
+// Get 获取缓存中键对应的值
+// 参数:
+//   key: 要查找的键
+// 返回值:
+//   value: 键对应的值，如果键不存在或已过期则返回V类型的零值
+//   exists: 布尔值，表示键是否存在且未过期
+func (t *TaggedCache[K, V]) Get(key K) (value V, exists bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.cache.Get(key)
+}
+
+// Set 存储键值对，保留该键已有的标签
+// 键不存在或已过期时，写入的条目不带任何标签
+// 参数:
+//   key: 要存储的键
+//   value: 要存储的值
+func (t *TaggedCache[K, V]) Set(key K, value V) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cache.Set(key, value)
+}
+
+// SetWithTags 存储键值对并将其关联到指定的标签
+// 如果键已存在，原有的标签会被tags替换；不传tags等同于清除该键的标签
+// 参数:
+//   key: 要存储的键
+//   value: 要存储的值
+//   tags: 要关联的标签，重复的标签只记录一次
+func (t *TaggedCache[K, V]) SetWithTags(key K, value V, tags ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// 先写入再建立索引：写入可能使该键的旧值过期，过期回调会清理旧值的索引
+	t.cache.Set(key, value)
+	t.untag(key)
+	if len(tags) == 0 {
+		return
+	}
+	keyTags := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		keys, ok := t.keysByTag[tag]
+		if !ok {
+			keys = make(map[K]struct{})
+			t.keysByTag[tag] = keys
+		}
+		if _, dup := keys[key]; dup {
+			continue
+		}
+		keys[key] = struct{}{}
+		keyTags = append(keyTags, tag)
+	}
+	t.tagsByKey[key] = keyTags
+}
+
+// SetIfAbsent 仅当键不存在或已过期时写入键值对，写入的条目不带任何标签
+// 参数:
+//   key: 要存储的键
+//   value: 要存储的值
+// 返回值:
+//   bool: 是否写入了值
+func (t *TaggedCache[K, V]) SetIfAbsent(key K, value V) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.cache.SetIfAbsent(key, value)
+}
+
+// SetIfPresent 仅当键存在且未过期时更新其值，保留该键已有的标签
+// 参数:
+//   key: 要存储的键
+//   value: 要存储的值
+// 返回值:
+//   bool: 是否更新了值
+func (t *TaggedCache[K, V]) SetIfPresent(key K, value V) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.cache.SetIfPresent(key, value)
+}
+
+// InvalidateTag 删除与指定标签关联的全部条目
+// 被删除的条目可能还带有其他标签，这些标签的索引也会一并清理
+// 参数:
+//   tag: 要失效的标签
+// 返回值:
+//   int: 被删除的条目数量，标签不存在时返回0
+func (t *TaggedCache[K, V]) InvalidateTag(tag string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// 先清理已过期的条目，使返回的数量只计算仍然有效的条目
+	t.cache.Cleanup()
+	keys := t.keysByTag[tag]
+	n := len(keys)
+	for key := range keys {
+		t.untag(key)
+		t.cache.Delete(key)
+	}
+	return n
+}
+
+// Delete 从缓存中删除指定键及其标签关联
+// 如果键不存在，此操作无效果
+// 参数:
+//   key: 要删除的键
+func (t *TaggedCache[K, V]) Delete(key K) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.untag(key)
+	t.cache.Delete(key)
+}
+
+// Len 返回当前有效缓存条目数量
+// 返回值:
+//   int: 缓存中未过期的键值对数量
+func (t *TaggedCache[K, V]) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.cache.Len()
+}
+
+// Clear 清空所有缓存条目和标签索引
+func (t *TaggedCache[K, V]) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cache.Clear()
+	t.keysByTag = make(map[string]map[K]struct{})
+	t.tagsByKey = make(map[K][]string)
+}
+
+// untag 从索引中移除键的全部标签关联，标签不再关联任何键时一并删除
+// 此方法应在持有锁的情况下调用
+func (t *TaggedCache[K, V]) untag(key K) {
+	for _, tag := range t.tagsByKey[key] {
+		keys := t.keysByTag[tag]
+		delete(keys, key)
+		if len(keys) == 0 {
+			delete(t.keysByTag, tag)
+		}
+	}
+	delete(t.tagsByKey, key)
+}
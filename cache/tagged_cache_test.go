@@ -0,0 +1,147 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+// TestTaggedCache_InvalidateTag 测试失效一个标签只删除带有该标签的条目
+func TestTaggedCache_InvalidateTag(t *testing.T) {
+	cache, err := NewTaggedCache[string, string](10, time.Minute)
+	if err != nil {
+		t.Fatalf("创建Tagged缓存失败: %v", err)
+	}
+
+	cache.SetWithTags("profile:42", "alice", "user:42")
+	cache.SetWithTags("orders:42", "[1 2]", "user:42", "orders")
+	cache.SetWithTags("orders:7", "[3]", "user:7", "orders")
+	cache.Set("config", "v1")
+
+	if n := cache.InvalidateTag("user:42"); n != 2 {
+		t.Errorf("InvalidateTag(user:42) = %d; 期望 2", n)
+	}
+	for _, key := range []string{"profile:42", "orders:42"} {
+		if _, exists := cache.Get(key); exists {
+			t.Errorf("Get(%s) 标签失效后不应存在", key)
+		}
+	}
+	for _, key := range []string{"orders:7", "config"} {
+		if _, exists := cache.Get(key); !exists {
+			t.Errorf("Get(%s) 不带该标签的条目不应被删除", key)
+		}
+	}
+
+	// orders:42已被删除，orders标签只剩orders:7
+	if n := cache.InvalidateTag("orders"); n != 1 {
+		t.Errorf("InvalidateTag(orders) = %d; 期望 1", n)
+	}
+	if n := cache.InvalidateTag("orders"); n != 0 {
+		t.Errorf("重复 InvalidateTag(orders) = %d; 期望 0", n)
+	}
+	if n := cache.InvalidateTag("missing"); n != 0 {
+		t.Errorf("InvalidateTag(missing) = %d; 期望 0", n)
+	}
+	if got := cache.Len(); got != 1 {
+		t.Errorf("Len() = %d; 期望 1", got)
+	}
+	if len(cache.keysByTag) != 0 || len(cache.tagsByKey) != 0 {
+		t.Errorf("标签索引未清理: keysByTag=%v, tagsByKey=%v", cache.keysByTag, cache.tagsByKey)
+	}
+}
+
+// TestTaggedCache_Retag 测试SetWithTags替换标签、Set保留标签、Delete清理标签
+func TestTaggedCache_Retag(t *testing.T) {
+	cache, err := NewTaggedCache[string, int](10, time.Minute)
+	if err != nil {
+		t.Fatalf("创建Tagged缓存失败: %v", err)
+	}
+
+	cache.SetWithTags("a", 1, "old", "old")
+	cache.SetWithTags("a", 2, "new")
+	if n := cache.InvalidateTag("old"); n != 0 {
+		t.Errorf("InvalidateTag(old) = %d; 期望 0，标签应已被替换", n)
+	}
+
+	cache.Set("a", 3)
+	if n := cache.InvalidateTag("new"); n != 1 {
+		t.Errorf("InvalidateTag(new) = %d; 期望 1，Set应保留已有标签", n)
+	}
+
+	cache.SetWithTags("b", 1, "t")
+	cache.Delete("b")
+	cache.Set("b", 2)
+	if n := cache.InvalidateTag("t"); n != 0 {
+		t.Errorf("InvalidateTag(t) = %d; 期望 0，Delete应清理标签", n)
+	}
+	if val, exists := cache.Get("b"); !exists || val != 2 {
+		t.Errorf("Get(b) = %v, %v; 期望 2, true", val, exists)
+	}
+}
+
+// TestTaggedCache_EvictCleansIndex 测试容量淘汰会清理标签索引，并继续调用调用方的回调
+func TestTaggedCache_EvictCleansIndex(t *testing.T) {
+	var evicted []string
+	cache, err := NewTaggedCache[string, int](2, time.Minute,
+		WithOnEvict(func(key string, _ int) { evicted = append(evicted, key) }),
+	)
+	if err != nil {
+		t.Fatalf("创建Tagged缓存失败: %v", err)
+	}
+
+	cache.SetWithTags("a", 1, "t")
+	time.Sleep(time.Millisecond)
+	cache.SetWithTags("b", 2, "t")
+	time.Sleep(time.Millisecond)
+	cache.SetWithTags("c", 3, "t") // 淘汰最早过期的a
+
+	if len(evicted) != 1 || evicted[0] != "a" {
+		t.Fatalf("OnEvict 收到 %v; 期望 [a]", evicted)
+	}
+	if _, tagged := cache.tagsByKey["a"]; tagged {
+		t.Error("被淘汰的键a仍在标签索引中")
+	}
+	if n := cache.InvalidateTag("t"); n != 2 {
+		t.Errorf("InvalidateTag(t) = %d; 期望 2", n)
+	}
+
+	if len(cache.keysByTag) != 0 || len(cache.tagsByKey) != 0 {
+		t.Errorf("标签索引未清理: keysByTag=%v, tagsByKey=%v", cache.keysByTag, cache.tagsByKey)
+	}
+}
+
+// TestTaggedCache_ExpireCleansIndex 测试过期条目会清理标签索引，且不计入InvalidateTag的返回值
+func TestTaggedCache_ExpireCleansIndex(t *testing.T) {
+	var expired []string
+	cache, err := NewTaggedCache[string, int](10, 10*time.Millisecond,
+		WithOnExpire(func(key string, _ int) { expired = append(expired, key) }),
+	)
+	if err != nil {
+		t.Fatalf("创建Tagged缓存失败: %v", err)
+	}
+
+	cache.SetWithTags("a", 1, "t")
+	cache.SetWithTags("b", 2, "t", "other")
+	time.Sleep(20 * time.Millisecond)
+	if n := cache.InvalidateTag("t"); n != 0 {
+		t.Errorf("InvalidateTag(t) = %d; 期望 0，已过期的条目不应计入", n)
+	}
+	if len(expired) != 2 {
+		t.Errorf("OnExpire 收到 %v; 期望 a和b", expired)
+	}
+	if len(cache.keysByTag) != 0 || len(cache.tagsByKey) != 0 {
+		t.Errorf("标签索引未清理: keysByTag=%v, tagsByKey=%v", cache.keysByTag, cache.tagsByKey)
+	}
+}
+
+// TestTaggedCache_InvalidOptions 测试回调类型不匹配时返回错误
+func TestTaggedCache_InvalidOptions(t *testing.T) {
+	if _, err := NewTaggedCache[string, int](10, time.Minute, WithOnEvict(func(int, int) {})); err == nil {
+		t.Error("OnEvict回调类型不匹配时应返回错误")
+	}
+	if _, err := NewTaggedCache[string, int](10, time.Minute, WithOnExpire(func(string, string) {})); err == nil {
+		t.Error("OnExpire回调类型不匹配时应返回错误")
+	}
+	if _, err := NewTaggedCache[string, int](0, time.Minute); err == nil {
+		t.Error("容量为0时应返回错误")
+	}
+}
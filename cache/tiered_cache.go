@@ -0,0 +1,181 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+)
+
+// TieredCache 两级缓存组合实现
+// L1通常是容量较小、访问快速的内存缓存（如LRU），L2是容量更大或访问更慢的缓存
+// Get先查L1，未命中再查L2，L2命中的值会回填到L1（遵循L1自身的淘汰策略）
+// Set和Delete会同时写入两级缓存（write-through）
+// 并发安全性取决于两级缓存自身的实现
+// K为键类型，必须支持比较操作；V为值类型，可以是任意类型
+type TieredCache[K comparable, V any] struct {
+	l1 Cache[K, V] // 一级缓存
+	l2 Cache[K, V] // 二级缓存
+}
+
+// NewTieredCache 创建新的两级缓存实例
+// 参数:
+//   l1: 一级缓存，不能为nil
+//   l2: 二级缓存，不能为nil
+// 返回值:
+//   *TieredCache[K, V]: 成功创建的缓存实例
+//   error: 当l1或l2为nil时返回非nil错误
+func NewTieredCache[K comparable, V any](l1, l2 Cache[K, V]) (*TieredCache[K, V], error) {
+	if l1 == nil || l2 == nil {
+		return nil, errors.New("both cache levels must be non-nil")
+	}
+	return &TieredCache[K, V]{l1: l1, l2: l2}, nil
+}
+
+// Get 获取缓存中键对应的值
+// 先查L1，未命中时查L2，L2命中则将值回填到L1
+// 参数:
+//   key: 要查找的键
+// 返回值:
+//   value: 键对应的值，如果两级缓存都不存在则返回V类型的零值
+//   exists: 布尔值，表示键是否存在
+func (t *TieredCache[K, V]) Get(key K) (value V, exists bool) {
+	if value, exists = t.l1.Get(key); exists {
+		return value, true
+	}
+	if value, exists = t.l2.Get(key); exists {
+		t.l1.Set(key, value)
+		return value, true
+	}
+	return value, false
+}
+
+// Set 将键值对同时写入两级缓存
+// 参数:
+//   key: 要存储的键
+//   value: 要存储的值
+func (t *TieredCache[K, V]) Set(key K, value V) {
+	t.l2.Set(key, value)
+	t.l1.Set(key, value)
+}
+
+// SetIfAbsent 仅当键在L2中不存在时写入两级缓存
+// L2实现ConditionalSetter时由L2保证检查和写入的原子性，成功后再写入L1；
+// 否则退化为先Get再Set，并发调用时不保证只有一个调用方成功
+// 参数:
+//   key: 要存储的键
+//   value: 要存储的值
+// 返回值:
+//   bool: 是否写入了值
+func (t *TieredCache[K, V]) SetIfAbsent(key K, value V) bool {
+	if cs, ok := t.l2.(ConditionalSetter[K, V]); ok {
+		if !cs.SetIfAbsent(key, value) {
+			return false
+		}
+	} else {
+		if _, exists := t.l2.Get(key); exists {
+			return false
+		}
+		t.l2.Set(key, value)
+	}
+	t.l1.Set(key, value)
+	return true
+}
+
+// SetIfPresent 仅当键在L2中存在时更新两级缓存
+// 原子性的保证与SetIfAbsent相同
+// 参数:
+//   key: 要存储的键
+//   value: 要存储的值
+// 返回值:
+//   bool: 是否更新了值
+func (t *TieredCache[K, V]) SetIfPresent(key K, value V) bool {
+	if cs, ok := t.l2.(ConditionalSetter[K, V]); ok {
+		if !cs.SetIfPresent(key, value) {
+			return false
+		}
+	} else {
+		if _, exists := t.l2.Get(key); !exists {
+			return false
+		}
+		t.l2.Set(key, value)
+	}
+	t.l1.Set(key, value)
+	return true
+}
+
+// Delete 从两级缓存中删除指定键
+// 参数:
+//   key: 要删除的键
+func (t *TieredCache[K, V]) Delete(key K) {
+	t.l1.Delete(key)
+	t.l2.Delete(key)
+}
+
+// Len 返回L2中的条目数量
+// 由于写入会同时落到L2，L2被视为完整数据集，L1只是它的热点子集
+// 如需分别查看两级缓存的条目数，可使用L1Len和L2Len
+// 返回值:
+//   int: L2中的条目数量
+func (t *TieredCache[K, V]) Len() int {
+	return t.l2.Len()
+}
+
+// L1Len 返回L1中的条目数量
+func (t *TieredCache[K, V]) L1Len() int {
+	return t.l1.Len()
+}
+
+// L2Len 返回L2中的条目数量
+func (t *TieredCache[K, V]) L2Len() int {
+	return t.l2.Len()
+}
+
+// Clone 返回两级缓存的独立副本，两级缓存分别通过各自的Clone复制
+// 两级缓存的复制不是同一时刻完成的，复制期间有并发写入时两级的内容可能略有不同
+// 返回值:
+//   *TieredCache[K, V]: 新的两级缓存实例
+//   error: 任一级缓存不是本包中提供Clone的缓存类型时返回非nil错误
+func (t *TieredCache[K, V]) Clone() (*TieredCache[K, V], error) {
+	l1, ok := cloneCache(t.l1)
+	if !ok {
+		return nil, fmt.Errorf("L1 cache of type %T does not support Clone", t.l1)
+	}
+	l2, ok := cloneCache(t.l2)
+	if !ok {
+		return nil, fmt.Errorf("L2 cache of type %T does not support Clone", t.l2)
+	}
+	return &TieredCache[K, V]{l1: l1, l2: l2}, nil
+}
+
+// cloneCache 调用本包缓存类型的Clone方法，c不是这些类型时返回false
+func cloneCache[K comparable, V any](c Cache[K, V]) (Cache[K, V], bool) {
+	switch c := c.(type) {
+	case *ExpiringFIFOCache[K, V]:
+		return c.Clone(), true
+	case *LRUCache[K, V]:
+		return c.Clone(), true
+	case *LFUCache[K, V]:
+		return c.Clone(), true
+	case *SizedCache[K, V]:
+		return c.Clone(), true
+	case *TinyLFUCache[K, V]:
+		return c.Clone(), true
+	case *TimedCache[K, V]:
+		return c.Clone(), true
+	case *NullCache[K, V]:
+		return c.Clone(), true
+	case *TieredCache[K, V]:
+		clone, err := c.Clone()
+		if err != nil {
+			return nil, false
+		}
+		return clone, true
+	default:
+		return nil, false
+	}
+}
+
+// Clear 清空两级缓存
+func (t *TieredCache[K, V]) Clear() {
+	t.l1.Clear()
+	t.l2.Clear()
+}
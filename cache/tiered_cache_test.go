@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"testing"
+)
+
+// 确保TieredCache实现了Cache接口
+var _ Cache[string, int] = (*TieredCache[string, int])(nil)
+
+// newTestTieredCache 创建L1容量为2、L2容量为10的两级LRU缓存
+func newTestTieredCache(t *testing.T) (*TieredCache[int, string], *LRUCache[int, string], *LRUCache[int, string]) {
+	l1, err := NewLRUCache[int, string](2)
+	if err != nil {
+		t.Fatalf("创建L1缓存失败: %v", err)
+	}
+	l2, err := NewLRUCache[int, string](10)
+	if err != nil {
+		t.Fatalf("创建L2缓存失败: %v", err)
+	}
+	tiered, err := NewTieredCache[int, string](l1, l2)
+	if err != nil {
+		t.Fatalf("创建两级缓存失败: %v", err)
+	}
+	return tiered, l1, l2
+}
+
+// TestTieredCache_Promotion 测试L1淘汰的值仍能从L2读取并回填到L1
+func TestTieredCache_Promotion(t *testing.T) {
+	tiered, l1, _ := newTestTieredCache(t)
+
+	tiered.Set(1, "a")
+	tiered.Set(2, "b")
+	tiered.Set(3, "c") // L1淘汰1
+
+	if _, exists := l1.Get(1); exists {
+		t.Fatal("L1 中的 1 应该被淘汰")
+	}
+
+	val, exists := tiered.Get(1)
+	if !exists || val != "a" {
+		t.Errorf("Get(1) = %v, %v; 期望 'a', true", val, exists)
+	}
+
+	// 1被回填到L1
+	if val, exists := l1.Get(1); !exists || val != "a" {
+		t.Errorf("L1.Get(1) = %v, %v; 期望 'a', true", val, exists)
+	}
+	if l1.Len() != 2 {
+		t.Errorf("L1 Len() = %d; 期望 2", l1.Len())
+	}
+}
+
+// TestTieredCache_Len 测试Len返回L2条目数
+func TestTieredCache_Len(t *testing.T) {
+	tiered, _, _ := newTestTieredCache(t)
+
+	for i := 0; i < 5; i++ {
+		tiered.Set(i, "v")
+	}
+	if tiered.Len() != 5 {
+		t.Errorf("Len() = %d; 期望 5", tiered.Len())
+	}
+	if tiered.L1Len() != 2 || tiered.L2Len() != 5 {
+		t.Errorf("L1Len() = %d, L2Len() = %d; 期望 2, 5", tiered.L1Len(), tiered.L2Len())
+	}
+}
+
+// TestTieredCache_DeleteAndClear 测试Delete和Clear作用于两级缓存
+func TestTieredCache_DeleteAndClear(t *testing.T) {
+	tiered, l1, l2 := newTestTieredCache(t)
+
+	tiered.Set(1, "a")
+	tiered.Set(2, "b")
+	tiered.Delete(1)
+	if _, exists := tiered.Get(1); exists {
+		t.Error("Get(1) 在删除后应该不存在")
+	}
+	if _, exists := l2.Get(1); exists {
+		t.Error("L2 中的 1 在删除后应该不存在")
+	}
+
+	tiered.Clear()
+	if l1.Len() != 0 || l2.Len() != 0 {
+		t.Errorf("Clear() 后 L1 Len() = %d, L2 Len() = %d; 期望 0, 0", l1.Len(), l2.Len())
+	}
+}
+
+// TestNewTieredCache_Nil 测试缓存层为nil时返回错误
+func TestNewTieredCache_Nil(t *testing.T) {
+	l1, _ := NewLRUCache[int, string](2)
+	if _, err := NewTieredCache[int, string](l1, nil); err == nil {
+		t.Error("L2 为 nil 时应返回错误")
+	}
+}
+
+// TestTieredCache_Clone 测试副本复制两级缓存，且修改副本不影响原缓存
+func TestTieredCache_Clone(t *testing.T) {
+	tiered, l1, l2 := newTestTieredCache(t)
+	tiered.Set(1, "a")
+	tiered.Set(2, "b")
+
+	clone, err := tiered.Clone()
+	if err != nil {
+		t.Fatalf("Clone() error = %v", err)
+	}
+	if clone.L1Len() != 2 || clone.L2Len() != 2 {
+		t.Errorf("副本 L1Len() = %d, L2Len() = %d; 期望 2, 2", clone.L1Len(), clone.L2Len())
+	}
+
+	clone.Set(3, "c")
+	clone.Delete(1)
+	if val, exists := tiered.Get(1); !exists || val != "a" {
+		t.Errorf("原缓存 Get(1) = %v, %v; 期望 a, true", val, exists)
+	}
+	if _, exists := l2.Get(3); exists {
+		t.Error("修改副本后原缓存的L2不应包含3")
+	}
+	if l1.Len() != 2 {
+		t.Errorf("原缓存 L1 Len() = %d; 期望 2", l1.Len())
+	}
+
+	// 嵌套的两级缓存也可以复制
+	nested, err := NewTieredCache[int, string](NewNullCache[int, string](), tiered)
+	if err != nil {
+		t.Fatalf("创建两级缓存失败: %v", err)
+	}
+	if _, err := nested.Clone(); err != nil {
+		t.Errorf("嵌套两级缓存 Clone() error = %v", err)
+	}
+
+	// 不支持Clone的缓存层返回错误
+	readOnly, err := NewTieredCache[int, string](l1, ReadOnly[int, string](l2))
+	if err != nil {
+		t.Fatalf("创建两级缓存失败: %v", err)
+	}
+	if _, err := readOnly.Clone(); err == nil {
+		t.Error("L2不支持Clone时 Clone() 应返回错误")
+	}
+}
@@ -3,6 +3,8 @@ package cache
 import (
 	"container/heap"
 	"errors"
+	"math"
+	"math/rand/v2"
 	"sync"
 	"time"
 )
@@ -60,13 +62,28 @@ func (h *expirationHeap[K]) Pop() interface{} {
 
 // timedEntry 缓存中的条目，包含值和过期时间
 type timedEntry[V any] struct {
-	value      V          // 缓存值
-	expiration int64      // 过期时间戳（纳秒）
+	value       V     // 缓存值
+	expiration  int64 // 过期时间戳（纳秒）
+	negative    bool  // 是否为负缓存条目（缓存键不存在这一结果）
+	computeTime int64 // 计算该值的耗时（纳秒），0表示未记录
+}
+
+// Entry 缓存条目的导出表示，包含键、值和过期时间
+type Entry[K comparable, V any] struct {
+	Key        K         // 缓存键
+	Value      V         // 缓存值
+	Expiration time.Time // 过期时间
 }
 
 // timedCacheOptions 用于配置TimedCache的选项
 type timedCacheOptions struct {
-	concurrentSafe bool // 是否启用并发安全
+	concurrentSafe bool          // 是否启用并发安全
+	onEvict        any           // 容量淘汰回调，类型为func(K, V)
+	onExpire       any           // 过期回调，类型为func(K, V)
+	staleWindow    time.Duration // 过期后仍可通过GetStale读取的宽限时间
+	ttlJitter      float64       // TTL随机抖动比例
+	lazyCleanup    bool          // 是否只检查被访问的键，推迟批量清理
+	earlyBeta      float64       // 概率性提前过期（XFetch）的beta参数
 }
 
 // TimedOption 定义配置TimedCache的函数类型
@@ -83,6 +100,89 @@ func WithTimedConcurrentSafe(enabled bool) TimedOption {
 	}
 }
 
+// WithOnEvict 设置容量淘汰回调
+// 当缓存已满、为新条目腾出空间而淘汰旧条目时调用，不会因过期或显式Delete触发
+// 回调在持有缓存锁的情况下执行，不能在回调中调用该缓存的方法，否则会死锁
+// 参数:
+//   fn: 淘汰回调，接收被淘汰的键和值，其类型参数必须与缓存一致
+// 返回值:
+//   TimedOption: 用于配置缓存的选项函数
+func WithOnEvict[K comparable, V any](fn func(K, V)) TimedOption {
+	return func(o *timedCacheOptions) {
+		o.onEvict = fn
+	}
+}
+
+// WithOnExpire 设置过期回调
+// 当条目因TTL到期被清理时调用，每个过期条目只触发一次，不会因容量淘汰或显式Delete触发
+// 过期清理是惰性的，回调在下一次访问缓存（Get、Set、Len等）时触发
+// 回调在持有缓存锁的情况下执行，不能在回调中调用该缓存的方法，否则会死锁
+// 参数:
+//   fn: 过期回调，接收过期的键和值，其类型参数必须与缓存一致
+// 返回值:
+//   TimedOption: 用于配置缓存的选项函数
+func WithOnExpire[K comparable, V any](fn func(K, V)) TimedOption {
+	return func(o *timedCacheOptions) {
+		o.onExpire = fn
+	}
+}
+
+// WithStaleWhileRevalidate 设置过期后的陈旧宽限窗口（serve-stale-while-revalidate）
+// 条目过期后的window时间内，Get视为未命中，但GetStale仍会返回该值并标记为陈旧，
+// 调用方可以先使用陈旧值，同时在后台刷新；超过TTL+window后条目被彻底删除
+// 处于宽限窗口内的条目仍占用容量并计入Len，OnExpire回调在条目被彻底删除时触发
+// 参数:
+//   window: 宽限窗口，不能为负数，0表示不启用
+// 返回值:
+//   TimedOption: 用于配置缓存的选项函数
+func WithStaleWhileRevalidate(window time.Duration) TimedOption {
+	return func(o *timedCacheOptions) {
+		o.staleWindow = window
+	}
+}
+
+// WithTTLJitter 设置TTL随机抖动比例，防止大量同时写入的条目同时过期引发回源风暴
+// 每次写入时实际TTL在[ttl*(1-fraction), ttl*(1+fraction))内均匀随机取值，
+// GetWithTTL返回的剩余时间基于实际TTL计算
+// 参数:
+//   fraction: 抖动比例，取值范围[0, 1)，0表示不启用（默认）
+// 返回值:
+//   TimedOption: 用于配置缓存的选项函数
+func WithTTLJitter(fraction float64) TimedOption {
+	return func(o *timedCacheOptions) {
+		o.ttlJitter = fraction
+	}
+}
+
+// WithLazyCleanup 设置是否启用惰性清理
+// 默认情况下Get、Set等方法每次调用都会先批量清理所有已过期的条目；启用后这些方法只检查被访问的键，
+// 批量清理推迟到缓存已满需要淘汰时、调用Len或GetAll时，或由调用方定期调用Cleanup完成
+// 取舍: 惰性清理降低了每次读写的CPU开销，但已过期的条目在被清理前仍占用内存（最多占满容量），
+// 并且OnExpire回调可能明显晚于过期时间触发
+// 参数:
+//   enabled: true表示启用惰性清理，false表示每次读写都批量清理（默认）
+// 返回值:
+//   TimedOption: 用于配置缓存的选项函数
+func WithLazyCleanup(enabled bool) TimedOption {
+	return func(o *timedCacheOptions) {
+		o.lazyCleanup = enabled
+	}
+}
+
+// WithEarlyExpiration 启用概率性提前过期（XFetch算法），平滑热点条目过期时的回源尖峰
+// 启用后ShouldRefresh在条目临近过期时以逐渐增大的概率返回true，使某一个调用方提前重新计算，
+// 而不是所有调用方在过期瞬间同时回源。判定条件为 now - computeTime*beta*ln(rand) >= expiration，
+// 其中computeTime是通过SetWithComputeTime记录的计算耗时，计算越慢、越接近过期，提前刷新的概率越大
+// 参数:
+//   beta: 提前程度，必须不小于0；1为推荐值，大于1更倾向于提前刷新，0表示不启用（默认）
+// 返回值:
+//   TimedOption: 用于配置缓存的选项函数
+func WithEarlyExpiration(beta float64) TimedOption {
+	return func(o *timedCacheOptions) {
+		o.earlyBeta = beta
+	}
+}
+
 // TimedCache 基于过期时间的缓存实现
 // 支持设置默认TTL(Time-To-Live)，条目过期后自动失效
 // 当缓存达到容量限制时，会优先淘汰最早过期的条目
@@ -95,6 +195,13 @@ type TimedCache[K comparable, V any] struct {
 	capacity       int                    // 最大容量，防止内存溢出
 	defaultTTL     time.Duration          // 默认过期时间，当使用Set方法时应用
 	concurrentSafe bool                   // 是否启用并发安全
+	onEvict        func(K, V)             // 容量淘汰回调
+	onExpire       func(K, V)             // 过期回调
+	staleWindow    time.Duration          // 过期后的陈旧宽限窗口
+	ttlJitter      float64                // TTL随机抖动比例
+	lazyCleanup    bool                   // 是否启用惰性清理
+	earlyBeta      float64                // 概率性提前过期的beta参数，0表示不启用
+	events         eventHub[K, V]         // 缓存事件订阅者
 	mu             sync.RWMutex           // 读写锁，用于并发控制
 }
 
@@ -104,7 +211,8 @@ type TimedCache[K comparable, V any] struct {
 //   defaultTTL: 默认过期时间，必须大于0
 // 返回值:
 //   *TimedCache[K, V]: 成功创建的缓存实例
-//   error: 当capacity <= 0或defaultTTL <= 0时返回非nil错误
+//   error: 当capacity <= 0、defaultTTL <= 0、宽限窗口为负数、抖动比例不在[0, 1)内、
+//          提前过期的beta为负数或回调类型与缓存不匹配时返回非nil错误
 func NewTimedCache[K comparable, V any](capacity int, defaultTTL time.Duration, options ...TimedOption) (*TimedCache[K, V], error) {
 	if capacity <= 0 {
 		return nil, errors.New("capacity must be positive")
@@ -119,6 +227,32 @@ func NewTimedCache[K comparable, V any](capacity int, defaultTTL time.Duration,
 	for _, option := range options {
 		option(&opts)
 	}
+
+	if opts.staleWindow < 0 {
+		return nil, errors.New("stale window must not be negative")
+	}
+	if opts.ttlJitter < 0 || opts.ttlJitter >= 1 || math.IsNaN(opts.ttlJitter) {
+		return nil, errors.New("TTL jitter must be in [0, 1)")
+	}
+	if opts.earlyBeta < 0 || math.IsNaN(opts.earlyBeta) {
+		return nil, errors.New("early expiration beta must not be negative")
+	}
+
+	var onEvict, onExpire func(K, V)
+	if opts.onEvict != nil {
+		fn, ok := opts.onEvict.(func(K, V))
+		if !ok {
+			return nil, errors.New("OnEvict callback type does not match cache key/value types")
+		}
+		onEvict = fn
+	}
+	if opts.onExpire != nil {
+		fn, ok := opts.onExpire.(func(K, V))
+		if !ok {
+			return nil, errors.New("OnExpire callback type does not match cache key/value types")
+		}
+		onExpire = fn
+	}
 	
 	return &TimedCache[K, V]{
 		cache:          make(map[K]*timedEntry[V]),
@@ -127,12 +261,18 @@ func NewTimedCache[K comparable, V any](capacity int, defaultTTL time.Duration,
 		capacity:       capacity,
 		defaultTTL:     defaultTTL,
 		concurrentSafe: opts.concurrentSafe,
+		onEvict:        onEvict,
+		onExpire:       onExpire,
+		staleWindow:    opts.staleWindow,
+		ttlJitter:      opts.ttlJitter,
+		lazyCleanup:    opts.lazyCleanup,
+		earlyBeta:      opts.earlyBeta,
 		mu:             sync.RWMutex{},
 	}, nil
 }
 
 // Get 获取缓存中键对应的值
-// 调用此方法会先清理所有过期条目，然后检查指定键是否存在且有效
+// 调用此方法会先清理所有过期条目（启用惰性清理时只检查该键），然后检查指定键是否存在且有效
 // 参数:
 //   key: 要查找的键
 // 返回值:
@@ -144,22 +284,136 @@ func (t *TimedCache[K, V]) Get(key K) (value V, exists bool) {
 		defer t.mu.Unlock()
 	}
 	
-	t.cleanupExpired()
+	t.cleanupBeforeRead()
 
 	entry, exists := t.cache[key]
 	if !exists {
+		t.events.publish(EventGetMiss, key, value)
 		return value, false
 	}
 
 	now := time.Now().UnixNano()
 	if entry.expiration < now {
-		delete(t.cache, key)
+		if t.pastStaleWindow(entry, now) {
+			t.expire(key, entry)
+		}
+		t.events.publish(EventGetMiss, key, value)
+		return value, false
+	}
+	if entry.negative {
+		t.events.publish(EventGetMiss, key, value)
 		return value, false
 	}
 
+	t.events.publish(EventGetHit, key, entry.value)
 	return entry.value, true
 }
 
+// GetE 获取缓存中键对应的值，通过错误区分键不存在和已过期
+// 过期清理是惰性的：已过期的条目若已被其它操作（Set、Len等）清理，则返回ErrKeyNotFound
+// 参数:
+//   key: 要查找的键
+// 返回值:
+//   value: 键对应的值，如果键不存在或已过期则返回V类型的零值
+//   error: 键不存在时返回ErrKeyNotFound，已过期时返回ErrKeyExpired，
+//          命中负缓存条目时返回ErrKeyNegative，命中时为nil
+func (t *TimedCache[K, V]) GetE(key K) (value V, err error) {
+	if t.concurrentSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+
+	// 先判断过期再清理，避免过期条目被清理后无法区分
+	now := time.Now().UnixNano()
+	entry, exists := t.cache[key]
+	t.cleanupBeforeRead()
+	if !exists {
+		return value, ErrKeyNotFound
+	}
+	if entry.expiration < now {
+		if _, ok := t.cache[key]; ok && t.pastStaleWindow(entry, now) {
+			t.expire(key, entry)
+		}
+		return value, ErrKeyExpired
+	}
+	if entry.negative {
+		return value, ErrKeyNegative
+	}
+
+	return entry.value, nil
+}
+
+// GetWithTTL 获取缓存中键对应的值及其剩余存活时间
+// 与Get一样会先清理过期条目，已过期的条目返回exists=false
+// 可用于设置HTTP响应的Cache-Control max-age等场景
+// 参数:
+//   key: 要查找的键
+// 返回值:
+//   value: 键对应的值，如果键不存在或已过期则返回V类型的零值
+//   ttl: 距离过期的剩余时间，键不存在时为0
+//   exists: 布尔值，表示键是否存在且未过期
+func (t *TimedCache[K, V]) GetWithTTL(key K) (value V, ttl time.Duration, exists bool) {
+	if t.concurrentSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+
+	t.cleanupBeforeRead()
+
+	entry, exists := t.cache[key]
+	if !exists {
+		return value, 0, false
+	}
+
+	now := time.Now().UnixNano()
+	if entry.expiration < now {
+		if t.pastStaleWindow(entry, now) {
+			t.expire(key, entry)
+		}
+		return value, 0, false
+	}
+	if entry.negative {
+		return value, 0, false
+	}
+
+	return entry.value, time.Duration(entry.expiration - now), true
+}
+
+// GetStale 获取缓存中键对应的值，允许返回处于陈旧宽限窗口内的过期值
+// 需配合WithStaleWhileRevalidate使用，未启用宽限窗口时行为与Get一致（stale始终为false）
+// 参数:
+//   key: 要查找的键
+// 返回值:
+//   value: 键对应的值，如果键不存在或已超过宽限窗口则返回V类型的零值
+//   stale: 布尔值，表示值已过期但仍处于宽限窗口内，调用方应触发刷新
+//   exists: 布尔值，表示是否返回了可用的值（新鲜或陈旧）
+func (t *TimedCache[K, V]) GetStale(key K) (value V, stale bool, exists bool) {
+	if t.concurrentSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+
+	t.cleanupBeforeRead()
+
+	entry, exists := t.cache[key]
+	if !exists {
+		return value, false, false
+	}
+
+	now := time.Now().UnixNano()
+	if t.pastStaleWindow(entry, now) {
+		t.expire(key, entry)
+		return value, false, false
+	}
+	if entry.negative {
+		return value, false, false
+	}
+	if entry.expiration >= now {
+		return entry.value, false, true
+	}
+	return entry.value, true, true
+}
+
 // Set 使用默认TTL存储键值对
 // 等效于调用SetWithTTL(key, value, t.defaultTTL)
 // 参数:
@@ -181,39 +435,167 @@ func (t *TimedCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
 		t.mu.Lock()
 		defer t.mu.Unlock()
 	}
-	
-	t.cleanupExpired()
 
-	expiration := time.Now().Add(ttl).UnixNano()
+	t.set(key, value, ttl, false)
+}
+
+// SetWithComputeTime 使用默认TTL存储键值对，并记录计算该值的耗时
+// 记录的耗时用于WithEarlyExpiration的提前刷新判定，通过其他方法写入的值不记录耗时
+// 参数:
+//   key: 要存储的键
+//   value: 要存储的值
+//   computeTime: 计算或加载该值所用的时间，负数按0处理
+func (t *TimedCache[K, V]) SetWithComputeTime(key K, value V, computeTime time.Duration) {
+	if t.concurrentSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+
+	t.set(key, value, t.defaultTTL, false)
+	t.cache[key].computeTime = max(int64(computeTime), 0)
+}
+
+// ShouldRefresh 判断调用方是否应重新计算键对应的值
+// 键不存在、已过期或为负缓存条目时始终返回true；
+// 启用WithEarlyExpiration且记录了计算耗时时，未过期的条目按XFetch算法以随过期临近而增大的概率返回true，
+// 否则未过期的条目返回false。此方法不修改缓存，也不触发过期清理和事件
+// 参数:
+//   key: 要检查的键
+// 返回值:
+//   bool: 是否应重新计算该值
+func (t *TimedCache[K, V]) ShouldRefresh(key K) bool {
+	if t.concurrentSafe {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+
+	entry, exists := t.cache[key]
+	if !exists || entry.negative {
+		return true
+	}
+	now := time.Now().UnixNano()
+	if entry.expiration <= now {
+		return true
+	}
+	if t.earlyBeta == 0 || entry.computeTime == 0 {
+		return false
+	}
+	// -ln(rand)服从均值为1的指数分布，rand为0时gap为+Inf，视为需要刷新
+	gap := -float64(entry.computeTime) * t.earlyBeta * math.Log(rand.Float64())
+	return float64(now)+gap >= float64(entry.expiration)
+}
+
+// SetIfAbsent 仅当键不存在时使用默认TTL写入键值对
+// 已过期（包括处于宽限窗口内）和负缓存的条目均视为不存在，会被覆盖
+// 检查和写入在同一次加锁内完成，是原子的，可用于实现简单的带过期时间的锁
+// 参数:
+//   key: 要存储的键
+//   value: 要存储的值
+// 返回值:
+//   bool: 是否写入了值，键存在且未过期时返回false
+func (t *TimedCache[K, V]) SetIfAbsent(key K, value V) bool {
+	if t.concurrentSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+
+	if t.live(key) {
+		return false
+	}
+	t.set(key, value, t.defaultTTL, false)
+	return true
+}
+
+// SetIfPresent 仅当键存在且未过期时更新其值，与Set一样会按默认TTL重新计算过期时间
+// 已过期和负缓存的条目视为不存在，不会被更新
+// 检查和写入在同一次加锁内完成，是原子的
+// 参数:
+//   key: 要存储的键
+//   value: 要存储的值
+// 返回值:
+//   bool: 是否更新了值，键不存在或已过期时返回false
+func (t *TimedCache[K, V]) SetIfPresent(key K, value V) bool {
+	if t.concurrentSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+
+	if !t.live(key) {
+		return false
+	}
+	t.set(key, value, t.defaultTTL, false)
+	return true
+}
+
+// SetNegative 缓存键不存在这一结果（负缓存），在ttl内Get返回未命中，GetE返回ErrKeyNegative
+// 用于避免对已知不存在的键反复查询后端；负缓存条目与普通条目一样占用容量并计入Len，
+// 但不会出现在GetAll和PopExpired的结果中，也不会触发OnEvict和OnExpire回调
+// 之后调用Set或SetWithTTL会用实际的值覆盖负缓存条目
+// 参数:
+//   key: 不存在的键
+//   ttl: 负缓存的生存时间，通常应短于普通条目的TTL
+func (t *TimedCache[K, V]) SetNegative(key K, ttl time.Duration) {
+	if t.concurrentSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+
+	var zero V
+	t.set(key, zero, ttl, true)
+}
+
+// set 存储条目，negative表示是否为负缓存条目
+// 此方法应在持有锁的情况下调用
+func (t *TimedCache[K, V]) set(key K, value V, ttl time.Duration, negative bool) {
+	t.cleanupBeforeWrite(key)
+
+	expiration := time.Now().Add(t.jitter(ttl)).UnixNano()
 
 	// 如果键已存在，更新值和过期时间
 	if entry, exists := t.cache[key]; exists {
 		entry.value = value
-		oldExpiration := entry.expiration
 		entry.expiration = expiration
-		// 查找并移除堆中该键的旧条目
-		for i, e := range *t.heap {
-			if e.key == key && e.expiration == oldExpiration {
-				heap.Remove(t.heap, i)
-				break
+		entry.negative = negative
+		entry.computeTime = 0
+		if !negative {
+			t.events.publish(EventSet, key, value)
+		}
+		// 原地更新堆条目的过期时间并调整堆，保持heapEntries与堆一致
+		if he, ok := t.heapEntries[key]; ok && he.index >= 0 {
+			he.expiration = expiration
+			heap.Fix(t.heap, he.index)
+		} else {
+			newHeapEntry := &heapEntry[K]{
+				key:        key,
+				expiration: expiration,
 			}
+			heap.Push(t.heap, newHeapEntry)
+			t.heapEntries[key] = newHeapEntry
 		}
-		heap.Push(t.heap, &heapEntry[K]{
-			key:        key,
-			expiration: expiration,
-		})
 		return
 	}
 
-	// 如果缓存满了，驱逐最早过期的条目
+	// 如果缓存满了，先清理已过期的条目（惰性清理时可能尚未清理），仍然满时驱逐最早过期的条目
+	if len(t.cache) >= t.capacity {
+		t.cleanupExpired()
+	}
 	for len(t.cache) >= t.capacity {
 		if t.heap.Len() == 0 {
 			break // 理论上不会发生，防止死循环
 		}
 		oldest := heap.Pop(t.heap).(*heapEntry[K])
+		if t.heapEntries[oldest.key] == oldest {
+			delete(t.heapEntries, oldest.key)
+		}
 		// 检查堆条目是否仍然有效（缓存中存在且过期时间匹配）
 		if entry, exists := t.cache[oldest.key]; exists && entry.expiration == oldest.expiration {
 			delete(t.cache, oldest.key)
+			if !entry.negative {
+				if t.onEvict != nil {
+					t.onEvict(oldest.key, entry.value)
+				}
+				t.events.publish(EventEvict, oldest.key, entry.value)
+			}
 		}
 	}
 
@@ -221,8 +603,12 @@ func (t *TimedCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
 	newEntry := &timedEntry[V]{
 		value:      value,
 		expiration: expiration,
+		negative:   negative,
 	}
 	t.cache[key] = newEntry
+	if !negative {
+		t.events.publish(EventSet, key, value)
+	}
 
 	// 添加到堆
 	newHeapEntry := &heapEntry[K]{
@@ -233,6 +619,28 @@ func (t *TimedCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
 	t.heapEntries[key] = newHeapEntry
 }
 
+// update 在持有锁的情况下对键执行"读取-修改-写入"，返回写入的新值
+// 键存在且未过期时只更新值，保留原过期时间；否则以默认TTL创建新条目
+// fn接收当前值及其是否存在，返回新值
+func (t *TimedCache[K, V]) update(key K, fn func(old V, exists bool) V) V {
+	if t.concurrentSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+
+	if t.live(key) {
+		entry := t.cache[key]
+		entry.value = fn(entry.value, true)
+		t.events.publish(EventSet, key, entry.value)
+		return entry.value
+	}
+
+	var zero V
+	value := fn(zero, false)
+	t.set(key, value, t.defaultTTL, false)
+	return value
+}
+
 // Delete 从缓存中删除指定键
 // 如果键不存在，此操作无效果
 // 参数:
@@ -244,12 +652,165 @@ func (t *TimedCache[K, V]) Delete(key K) {
 	}
 
 	// 从堆和映射中删除
-	if heapEntry, exists := t.heapEntries[key]; exists {
-		heap.Remove(t.heap, heapEntry.index)
-		delete(t.heapEntries, key)
-	}
+	t.removeHeapEntry(key)
 	// 从缓存中删除
-	delete(t.cache, key)
+	if entry, exists := t.cache[key]; exists {
+		delete(t.cache, key)
+		if !entry.negative {
+			t.events.publish(EventDelete, key, entry.value)
+		}
+	}
+}
+
+// GetAll 返回所有未过期条目的快照
+// 调用此方法会先清理所有过期条目，然后在持有锁的情况下复制全部键值对
+// 返回的map是独立副本，修改它不会影响缓存；此后缓存的变化也不会反映到副本中
+// 返回值:
+//   map[K]V: 所有未过期的键值对
+func (t *TimedCache[K, V]) GetAll() map[K]V {
+	if t.concurrentSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+
+	t.cleanupExpired()
+
+	now := time.Now().UnixNano()
+	result := make(map[K]V, len(t.cache))
+	for key, entry := range t.cache {
+		if entry.expiration >= now && !entry.negative {
+			result[key] = entry.value
+		}
+	}
+	return result
+}
+
+// Range 依次对每个未过期的条目调用fn，fn返回false时停止遍历
+// 遍历在持有读锁的情况下进行，跳过已过期和负缓存条目但不会清理它们，遍历顺序不确定
+// fn中不能调用该缓存的方法，否则会死锁
+// 参数:
+//   fn: 处理每个键值对的函数，返回false表示停止
+func (t *TimedCache[K, V]) Range(fn func(key K, value V) bool) {
+	if t.concurrentSafe {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+
+	now := time.Now().UnixNano()
+	for key, entry := range t.cache {
+		if entry.expiration < now || entry.negative {
+			continue
+		}
+		if !fn(key, entry.value) {
+			return
+		}
+	}
+}
+
+// Subscribe 订阅缓存操作事件，用于调试或在多个节点间同步缓存
+// Get产生GetHit/GetMiss事件，Set、SetWithTTL产生Set事件，删除已存在的键产生Delete事件，
+// 容量淘汰和TTL到期分别产生Evict和Expire事件；负缓存条目、Clear和PopExpired不产生事件
+// 事件在缓存锁内以非阻塞方式发送：通道缓冲区已满时新事件被丢弃，不会阻塞缓存操作，
+// 因此订阅者应及时消费，且不能依赖收到全部事件
+// 返回值:
+//   <-chan CacheEvent[K, V]: 事件通道，调用Unsubscribe后关闭
+func (t *TimedCache[K, V]) Subscribe() <-chan CacheEvent[K, V] {
+	return t.events.subscribe()
+}
+
+// Unsubscribe 取消订阅并关闭事件通道，通道未订阅或已取消时无效果
+// 参数:
+//   ch: Subscribe返回的事件通道
+func (t *TimedCache[K, V]) Unsubscribe(ch <-chan CacheEvent[K, V]) {
+	t.events.unsubscribe(ch)
+}
+
+// Clone 返回缓存的独立副本，包含相同的条目和过期时间（包括负缓存和宽限窗口内的条目）
+// 副本中的条目与原缓存同时过期；之后对副本或原缓存的修改互不影响
+// 副本保留容量、默认TTL、宽限窗口、抖动和提前过期配置，但不复制OnEvict和OnExpire回调及事件订阅，
+// 避免副本中的淘汰和过期被重复统计
+// 返回值:
+//   *TimedCache[K, V]: 新的缓存实例
+func (t *TimedCache[K, V]) Clone() *TimedCache[K, V] {
+	if t.concurrentSafe {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+
+	clone := &TimedCache[K, V]{
+		cache:          make(map[K]*timedEntry[V], len(t.cache)),
+		heap:           &expirationHeap[K]{},
+		heapEntries:    make(map[K]*heapEntry[K], len(t.heapEntries)),
+		capacity:       t.capacity,
+		defaultTTL:     t.defaultTTL,
+		concurrentSafe: t.concurrentSafe,
+		staleWindow:    t.staleWindow,
+		ttlJitter:      t.ttlJitter,
+		lazyCleanup:    t.lazyCleanup,
+		earlyBeta:      t.earlyBeta,
+	}
+	for key, entry := range t.cache {
+		e := *entry
+		clone.cache[key] = &e
+	}
+	// 按原顺序复制堆数组即可保持堆性质，索引也保持不变
+	*clone.heap = make(expirationHeap[K], len(*t.heap))
+	for i, he := range *t.heap {
+		newHeapEntry := *he
+		(*clone.heap)[i] = &newHeapEntry
+		if t.heapEntries[he.key] == he {
+			clone.heapEntries[he.key] = &newHeapEntry
+		}
+	}
+	return clone
+}
+
+// PopExpired 移除并返回所有当前已过期的条目，按过期时间升序排列
+// 与自动清理不同，过期条目的值会返回给调用方处理，不会触发OnExpire回调
+// 过期清理是惰性的：其它方法（Get、Set、Len等）仍会直接删除过期条目并触发OnExpire，
+// 因此用于排空过期任务时应周期性调用PopExpired；启用宽限窗口时，窗口内的条目也会被返回
+// 返回值:
+//   []Entry[K, V]: 已过期的条目，没有过期条目时返回空切片
+func (t *TimedCache[K, V]) PopExpired() []Entry[K, V] {
+	if t.concurrentSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+
+	now := time.Now().UnixNano()
+	result := []Entry[K, V]{}
+	for t.heap.Len() > 0 && (*t.heap)[0].expiration < now {
+		he := heap.Pop(t.heap).(*heapEntry[K])
+		if t.heapEntries[he.key] == he {
+			delete(t.heapEntries, he.key)
+		}
+
+		// 跳过已被更新或删除的旧堆条目
+		entry, exists := t.cache[he.key]
+		if !exists || entry.expiration != he.expiration {
+			continue
+		}
+		delete(t.cache, he.key)
+		if entry.negative {
+			continue
+		}
+		result = append(result, Entry[K, V]{
+			Key:        he.key,
+			Value:      entry.value,
+			Expiration: time.Unix(0, entry.expiration),
+		})
+	}
+	return result
+}
+
+// Cleanup 立即清理所有已过期的条目并触发OnExpire回调
+// 启用惰性清理时，可由调用方在后台定期调用，及时释放过期条目占用的内存
+func (t *TimedCache[K, V]) Cleanup() {
+	if t.concurrentSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	t.cleanupExpired()
 }
 
 // Len 返回当前有效缓存条目数量
@@ -257,9 +818,10 @@ func (t *TimedCache[K, V]) Delete(key K) {
 // 返回值:
 //   int: 缓存中未过期的键值对数量
 func (t *TimedCache[K, V]) Len() int {
+	// cleanupExpired会修改内部状态并触发回调，需要持有写锁
 	if t.concurrentSafe {
-		t.mu.RLock()
-		defer t.mu.RUnlock()
+		t.mu.Lock()
+		defer t.mu.Unlock()
 	}
 	t.cleanupExpired()
 	return len(t.cache)
@@ -273,7 +835,8 @@ func (t *TimedCache[K, V]) Clear() {
 		defer t.mu.Unlock()
 	}
 	t.cache = make(map[K]*timedEntry[V])
-	*t.heap = (*t.heap)[:0] // 清空堆
+	t.heap = &expirationHeap[K]{}            // 清空堆，不复用底层数组以释放旧条目
+	t.heapEntries = make(map[K]*heapEntry[K]) // 与堆保持一致，避免残留的堆条目破坏后续的Delete和SetWithTTL
 }
 
 // cleanupExpired 清理所有过期的缓存条目
@@ -283,18 +846,86 @@ func (t *TimedCache[K, V]) cleanupExpired() {
 
 	// 循环检查并移除所有过期元素
 	for t.heap.Len() > 0 {
-		// 获取并弹出堆顶元素（最早过期）
-		entry := heap.Pop(t.heap).(*heapEntry[K])
-		if entry.expiration > now {
-			// 未过期，推回堆中并停止清理
-		heap.Push(t.heap, entry)
+		// 查看堆顶元素（最早过期），未过期时停止清理，避免无谓的出堆和入堆
+		entry := (*t.heap)[0]
+		if entry.expiration+int64(t.staleWindow) > now {
 			break
 		}
+		heap.Pop(t.heap)
 
 		// 从缓存和堆条目映射中删除过期条目
+		if t.heapEntries[entry.key] == entry {
+			delete(t.heapEntries, entry.key)
+		}
 		if cacheEntry, exists := t.cache[entry.key]; exists && cacheEntry.expiration == entry.expiration {
-			delete(t.cache, entry.key)
+			t.expire(entry.key, cacheEntry)
+		}
+	}
+}
+
+// live 判断键是否存在、未过期且不是负缓存条目
+// 此方法应在持有锁的情况下调用
+func (t *TimedCache[K, V]) live(key K) bool {
+	entry, exists := t.cache[key]
+	return exists && !entry.negative && entry.expiration >= time.Now().UnixNano()
+}
+
+// cleanupBeforeRead 读取前的过期清理：默认批量清理所有过期条目，启用惰性清理时不做任何事，
+// 由读取方法自行检查被访问的键是否过期
+// 此方法应在持有锁的情况下调用
+func (t *TimedCache[K, V]) cleanupBeforeRead() {
+	if !t.lazyCleanup {
+		t.cleanupExpired()
+	}
+}
+
+// cleanupBeforeWrite 写入前的过期清理：默认批量清理所有过期条目，启用惰性清理时只检查key，
+// 使被覆盖的过期值仍能触发OnExpire回调
+// 此方法应在持有锁的情况下调用
+func (t *TimedCache[K, V]) cleanupBeforeWrite(key K) {
+	if !t.lazyCleanup {
+		t.cleanupExpired()
+		return
+	}
+	if entry, exists := t.cache[key]; exists && t.pastStaleWindow(entry, time.Now().UnixNano()) {
+		t.expire(key, entry)
+	}
+}
+
+// pastStaleWindow 判断条目是否已超过TTL加宽限窗口，需要被彻底删除
+func (t *TimedCache[K, V]) pastStaleWindow(entry *timedEntry[V], now int64) bool {
+	return entry.expiration+int64(t.staleWindow) < now
+}
+
+// expire 删除因TTL到期的条目并触发过期回调
+// 此方法应在持有锁的情况下调用
+func (t *TimedCache[K, V]) expire(key K, entry *timedEntry[V]) {
+	t.removeHeapEntry(key)
+	delete(t.cache, key)
+	if !entry.negative {
+		if t.onExpire != nil {
+			t.onExpire(key, entry.value)
 		}
-		delete(t.heapEntries, entry.key)
+		t.events.publish(EventExpire, key, entry.value)
 	}
-}
\ No newline at end of file
+}
+
+// jitter 按抖动比例随机调整TTL
+// 使用math/rand/v2的全局随机源，由运行时在启动时播种一次，可并发调用
+func (t *TimedCache[K, V]) jitter(ttl time.Duration) time.Duration {
+	if t.ttlJitter == 0 {
+		return ttl
+	}
+	return ttl + time.Duration(float64(ttl)*t.ttlJitter*(2*rand.Float64()-1))
+}
+
+// removeHeapEntry 从堆和heapEntries中移除键对应的堆条目
+// 此方法应在持有锁的情况下调用
+func (t *TimedCache[K, V]) removeHeapEntry(key K) {
+	if he, ok := t.heapEntries[key]; ok {
+		if he.index >= 0 {
+			heap.Remove(t.heap, he.index)
+		}
+		delete(t.heapEntries, key)
+	}
+}
@@ -1,7 +1,9 @@
 package cache
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"sync"
 	"testing"
 	"time"
@@ -141,6 +143,723 @@ func TestTimedCache_Clear(t *testing.T) {
 	}
 }
 
+// TestTimedCache_GetWithTTL 测试获取剩余TTL
+func TestTimedCache_GetWithTTL(t *testing.T) {
+	cache, err := NewTimedCache[int, string](100, 1*time.Second)
+	if err != nil {
+		t.Fatalf("创建Timed缓存失败: %v", err)
+	}
+
+	cache.Set(1, "a")
+	val, ttl1, exists := cache.GetWithTTL(1)
+	if !exists || val != "a" {
+		t.Fatalf("GetWithTTL(1) = %v, %v; 期望 'a', true", val, exists)
+	}
+	if ttl1 <= 0 || ttl1 > time.Second {
+		t.Errorf("GetWithTTL(1) ttl = %v; 期望在(0, 1s]范围内", ttl1)
+	}
+
+	// 剩余时间应逐渐减少
+	time.Sleep(50 * time.Millisecond)
+	_, ttl2, exists := cache.GetWithTTL(1)
+	if !exists {
+		t.Fatal("GetWithTTL(1) 应该存在")
+	}
+	if ttl2 >= ttl1 {
+		t.Errorf("GetWithTTL(1) ttl = %v; 期望小于 %v", ttl2, ttl1)
+	}
+
+	// 不存在的键
+	if _, ttl, exists := cache.GetWithTTL(2); exists || ttl != 0 {
+		t.Errorf("GetWithTTL(2) = %v, %v; 期望 0, false", ttl, exists)
+	}
+
+	// 过期的键
+	cache.SetWithTTL(3, "c", 30*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	if _, ttl, exists := cache.GetWithTTL(3); exists || ttl != 0 {
+		t.Errorf("GetWithTTL(3) = %v, %v; 期望 0, false", ttl, exists)
+	}
+}
+
+// TestTimedCache_GetAll 测试GetAll只返回未过期条目且返回独立副本
+func TestTimedCache_GetAll(t *testing.T) {
+	cache, err := NewTimedCache[int, string](100, 1*time.Second)
+	if err != nil {
+		t.Fatalf("创建Timed缓存失败: %v", err)
+	}
+
+	cache.Set(1, "a")
+	cache.Set(2, "b")
+	cache.SetWithTTL(3, "c", 30*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+
+	all := cache.GetAll()
+	if len(all) != 2 || all[1] != "a" || all[2] != "b" {
+		t.Errorf("GetAll() = %v; 期望 map[1:a 2:b]", all)
+	}
+	if _, exists := all[3]; exists {
+		t.Error("GetAll() 不应包含过期条目3")
+	}
+
+	// 修改返回的map不影响缓存
+	all[1] = "modified"
+	delete(all, 2)
+	if val, exists := cache.Get(1); !exists || val != "a" {
+		t.Errorf("Get(1) = %v, %v; 期望 'a', true", val, exists)
+	}
+	if _, exists := cache.Get(2); !exists {
+		t.Error("Get(2) 应该存在")
+	}
+}
+
+// TestTimedCache_OnExpire 测试过期回调只在TTL到期时触发且每个条目只触发一次
+func TestTimedCache_OnExpire(t *testing.T) {
+	expired := make(map[int]string)
+	expireCount := 0
+	evicted := make(map[int]string)
+	cache, err := NewTimedCache[int, string](2, 1*time.Second,
+		WithOnExpire(func(key int, value string) {
+			expired[key] = value
+			expireCount++
+		}),
+		WithOnEvict(func(key int, value string) {
+			evicted[key] = value
+		}),
+	)
+	if err != nil {
+		t.Fatalf("创建Timed缓存失败: %v", err)
+	}
+
+	cache.SetWithTTL(1, "a", 30*time.Millisecond)
+	cache.Set(2, "b")
+	cache.Delete(2)
+	time.Sleep(50 * time.Millisecond)
+
+	// 惰性清理触发回调
+	if _, exists := cache.Get(1); exists {
+		t.Error("Get(1) 应该过期，但存在")
+	}
+	if expireCount != 1 || expired[1] != "a" {
+		t.Errorf("OnExpire 调用 = %v (%d次); 期望 map[1:a] (1次)", expired, expireCount)
+	}
+	if _, exists := expired[2]; exists {
+		t.Error("显式Delete不应触发OnExpire")
+	}
+
+	// 再次访问不应重复触发
+	cache.Get(1)
+	cache.Len()
+	if expireCount != 1 {
+		t.Errorf("OnExpire 调用次数 = %d; 期望 1", expireCount)
+	}
+
+	// 容量淘汰只触发OnEvict
+	cache.Set(3, "c")
+	cache.Set(4, "d")
+	cache.Set(5, "e")
+	if len(evicted) != 1 || evicted[3] != "c" {
+		t.Errorf("OnEvict 调用 = %v; 期望 map[3:c]", evicted)
+	}
+	if expireCount != 1 {
+		t.Errorf("容量淘汰后 OnExpire 调用次数 = %d; 期望 1", expireCount)
+	}
+}
+
+// TestTimedCache_CallbackTypeMismatch 测试回调类型与缓存不匹配时返回错误
+func TestTimedCache_CallbackTypeMismatch(t *testing.T) {
+	_, err := NewTimedCache[int, string](10, time.Second, WithOnExpire(func(key string, value int) {}))
+	if err == nil {
+		t.Error("回调类型不匹配时应返回错误")
+	}
+}
+
+// TestTimedCache_StaleWhileRevalidate 测试宽限窗口内返回陈旧值，超过TTL+窗口后彻底删除
+func TestTimedCache_StaleWhileRevalidate(t *testing.T) {
+	expired := 0
+	cache, err := NewTimedCache[int, string](100, 1*time.Second,
+		WithStaleWhileRevalidate(100*time.Millisecond),
+		WithOnExpire(func(key int, value string) { expired++ }),
+	)
+	if err != nil {
+		t.Fatalf("创建Timed缓存失败: %v", err)
+	}
+
+	cache.SetWithTTL(1, "a", 50*time.Millisecond)
+
+	// TTL内：新鲜值
+	val, stale, exists := cache.GetStale(1)
+	if !exists || stale || val != "a" {
+		t.Errorf("GetStale(1) = %v, %v, %v; 期望 'a', false, true", val, stale, exists)
+	}
+
+	// 越过TTL但在宽限窗口内：Get未命中，GetStale返回陈旧值
+	time.Sleep(80 * time.Millisecond)
+	if _, exists := cache.Get(1); exists {
+		t.Error("Get(1) 过期后应该未命中")
+	}
+	val, stale, exists = cache.GetStale(1)
+	if !exists || !stale || val != "a" {
+		t.Errorf("GetStale(1) = %v, %v, %v; 期望 'a', true, true", val, stale, exists)
+	}
+	if expired != 0 {
+		t.Errorf("宽限窗口内 OnExpire 调用次数 = %d; 期望 0", expired)
+	}
+
+	// 越过TTL+宽限窗口：彻底删除
+	time.Sleep(100 * time.Millisecond)
+	val, stale, exists = cache.GetStale(1)
+	if exists || stale || val != "" {
+		t.Errorf("GetStale(1) = %v, %v, %v; 期望 '', false, false", val, stale, exists)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Len() = %d; 期望 0", cache.Len())
+	}
+	if expired != 1 {
+		t.Errorf("OnExpire 调用次数 = %d; 期望 1", expired)
+	}
+
+	// 刷新后恢复为新鲜值
+	cache.SetWithTTL(1, "b", 50*time.Millisecond)
+	if val, stale, exists := cache.GetStale(1); !exists || stale || val != "b" {
+		t.Errorf("GetStale(1) = %v, %v, %v; 期望 'b', false, true", val, stale, exists)
+	}
+
+	if _, err := NewTimedCache[int, string](100, time.Second, WithStaleWhileRevalidate(-time.Second)); err == nil {
+		t.Error("宽限窗口为负数时应返回错误")
+	}
+}
+
+// TestTimedCache_PopExpired 测试PopExpired按过期顺序返回已过期条目且不触发OnExpire
+func TestTimedCache_PopExpired(t *testing.T) {
+	expired := 0
+	cache, err := NewTimedCache[int, string](10, 1*time.Second,
+		WithOnExpire(func(key int, value string) { expired++ }),
+	)
+	if err != nil {
+		t.Fatalf("创建Timed缓存失败: %v", err)
+	}
+
+	cache.SetWithTTL(1, "a", 60*time.Millisecond)
+	cache.SetWithTTL(2, "b", 20*time.Millisecond)
+	cache.SetWithTTL(3, "c", 40*time.Millisecond)
+	cache.SetWithTTL(4, "d", 1*time.Second)
+	// 更新后的旧过期时间不应被返回
+	cache.SetWithTTL(5, "e", 30*time.Millisecond)
+	cache.SetWithTTL(5, "e2", 1*time.Second)
+
+	if got := cache.PopExpired(); len(got) != 0 {
+		t.Errorf("PopExpired() = %v; 期望为空", got)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	got := cache.PopExpired()
+	wantKeys := []int{2, 3, 1}
+	wantValues := []string{"b", "c", "a"}
+	if len(got) != len(wantKeys) {
+		t.Fatalf("PopExpired() 返回 %d 个条目; 期望 %d 个: %v", len(got), len(wantKeys), got)
+	}
+	for i, e := range got {
+		if e.Key != wantKeys[i] || e.Value != wantValues[i] {
+			t.Errorf("PopExpired()[%d] = %v:%v; 期望 %v:%v", i, e.Key, e.Value, wantKeys[i], wantValues[i])
+		}
+		if i > 0 && e.Expiration.Before(got[i-1].Expiration) {
+			t.Errorf("PopExpired() 未按过期时间排序: %v", got)
+		}
+	}
+
+	if expired != 0 {
+		t.Errorf("OnExpire 调用次数 = %d; 期望 0", expired)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d; 期望 2", cache.Len())
+	}
+	if got := cache.PopExpired(); len(got) != 0 {
+		t.Errorf("再次调用 PopExpired() = %v; 期望为空", got)
+	}
+	if val, exists := cache.Get(5); !exists || val != "e2" {
+		t.Errorf("Get(5) = %v, %v; 期望 'e2', true", val, exists)
+	}
+}
+
+// TestTimedCache_GetE 测试GetE通过哨兵错误区分键不存在和已过期
+func TestTimedCache_GetE(t *testing.T) {
+	cache, err := NewTimedCache[int, string](10, 1*time.Second)
+	if err != nil {
+		t.Fatalf("创建Timed缓存失败: %v", err)
+	}
+
+	if _, err := cache.GetE(1); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("GetE(1) error = %v; 期望 ErrKeyNotFound", err)
+	}
+
+	cache.Set(1, "a")
+	if val, err := cache.GetE(1); err != nil || val != "a" {
+		t.Errorf("GetE(1) = %v, %v; 期望 'a', nil", val, err)
+	}
+
+	cache.SetWithTTL(2, "b", 30*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	val, err := cache.GetE(2)
+	if !errors.Is(err, ErrKeyExpired) || val != "" {
+		t.Errorf("GetE(2) = %v, %v; 期望 '', ErrKeyExpired", val, err)
+	}
+
+	// 过期条目已被删除，再次查询视为不存在
+	if _, err := cache.GetE(2); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("再次 GetE(2) error = %v; 期望 ErrKeyNotFound", err)
+	}
+	if val, exists := cache.Get(1); !exists || val != "a" {
+		t.Errorf("Get(1) = %v, %v; 期望 'a', true", val, exists)
+	}
+}
+
+// TestTimedCache_ClearThenReuse 回归测试：Clear后堆条目映射应被重置，后续的Set/Delete不应panic
+func TestTimedCache_ClearThenReuse(t *testing.T) {
+	cache, err := NewTimedCache[int, string](3, 1*time.Second)
+	if err != nil {
+		t.Fatalf("创建Timed缓存失败: %v", err)
+	}
+
+	cache.Set(1, "a")
+	cache.Set(2, "b")
+	cache.Set(2, "b2") // 更新已有键
+	cache.Clear()
+
+	if len(cache.heapEntries) != 0 || cache.heap.Len() != 0 {
+		t.Fatalf("Clear() 后 heapEntries=%d, heap=%d; 期望均为0", len(cache.heapEntries), cache.heap.Len())
+	}
+
+	cache.Delete(1) // Clear前存在的键
+	cache.Set(1, "x")
+	cache.Set(3, "y")
+	cache.Set(3, "y2")
+	cache.Delete(3)
+	if cache.Len() != 1 {
+		t.Errorf("Len() = %d; 期望 1", cache.Len())
+	}
+	if val, exists := cache.Get(1); !exists || val != "x" {
+		t.Errorf("Get(1) = %v, %v; 期望 'x', true", val, exists)
+	}
+
+	// 淘汰后再删除被淘汰的键
+	cache.Set(4, "d")
+	cache.Set(5, "e")
+	cache.Set(6, "f")
+	cache.Delete(1)
+	if cache.Len() != 3 {
+		t.Errorf("Len() = %d; 期望 3", cache.Len())
+	}
+	if len(cache.heapEntries) != cache.heap.Len() || cache.heap.Len() != cache.Len() {
+		t.Errorf("heapEntries=%d, heap=%d, Len()=%d; 期望一致", len(cache.heapEntries), cache.heap.Len(), cache.Len())
+	}
+}
+
+// TestTimedCache_SetNegative 测试负缓存条目表现为未命中且可被GetE区分
+func TestTimedCache_SetNegative(t *testing.T) {
+	evicted := 0
+	cache, err := NewTimedCache[int, string](2, 1*time.Second,
+		WithOnEvict(func(key int, value string) { evicted++ }),
+	)
+	if err != nil {
+		t.Fatalf("创建Timed缓存失败: %v", err)
+	}
+
+	cache.SetNegative(1, 50*time.Millisecond)
+	if _, exists := cache.Get(1); exists {
+		t.Error("Get(1) 负缓存条目应该未命中")
+	}
+	if _, err := cache.GetE(1); !errors.Is(err, ErrKeyNegative) {
+		t.Errorf("GetE(1) error = %v; 期望 ErrKeyNegative", err)
+	}
+	if all := cache.GetAll(); len(all) != 0 {
+		t.Errorf("GetAll() = %v; 期望为空", all)
+	}
+	if cache.Len() != 1 {
+		t.Errorf("Len() = %d; 期望 1", cache.Len())
+	}
+
+	// 负缓存过期后视为不存在
+	time.Sleep(80 * time.Millisecond)
+	if _, err := cache.GetE(1); !errors.Is(err, ErrKeyNotFound) && !errors.Is(err, ErrKeyExpired) {
+		t.Errorf("GetE(1) error = %v; 期望 ErrKeyNotFound 或 ErrKeyExpired", err)
+	}
+
+	// 写入实际值覆盖负缓存
+	cache.SetNegative(2, time.Second)
+	cache.Set(2, "b")
+	if val, exists := cache.Get(2); !exists || val != "b" {
+		t.Errorf("Get(2) = %v, %v; 期望 'b', true", val, exists)
+	}
+
+	// 淘汰负缓存条目不触发OnEvict
+	cache.SetNegative(3, 10*time.Millisecond)
+	cache.Set(4, "d")
+	if evicted != 0 {
+		t.Errorf("OnEvict 调用次数 = %d; 期望 0", evicted)
+	}
+}
+
+// TestTimedCache_TTLJitter 测试TTL抖动使过期时间分散在配置的范围内
+func TestTimedCache_TTLJitter(t *testing.T) {
+	const (
+		n        = 1000
+		ttl      = time.Hour
+		fraction = 0.1
+	)
+	cache, err := NewTimedCache[int, int](n, ttl, WithTTLJitter(fraction))
+	if err != nil {
+		t.Fatalf("创建Timed缓存失败: %v", err)
+	}
+
+	for i := 0; i < n; i++ {
+		cache.Set(i, i)
+	}
+
+	low, high := ttl-ttl/10, ttl+ttl/10 // ttl*(1±fraction)
+	var minTTL, maxTTL time.Duration
+	below, above := 0, 0
+	for i := 0; i < n; i++ {
+		_, remaining, exists := cache.GetWithTTL(i)
+		if !exists {
+			t.Fatalf("GetWithTTL(%d) 不存在", i)
+		}
+		if remaining < low-time.Second || remaining > high {
+			t.Fatalf("GetWithTTL(%d) ttl = %v; 期望在 [%v, %v] 内", i, remaining, low, high)
+		}
+		if i == 0 || remaining < minTTL {
+			minTTL = remaining
+		}
+		if remaining > maxTTL {
+			maxTTL = remaining
+		}
+		if remaining < ttl {
+			below++
+		} else {
+			above++
+		}
+	}
+
+	// 均匀分布下，1000个样本的极差应接近整个抖动范围，且两侧数量大致相当
+	if spread := maxTTL - minTTL; spread < (high-low)*8/10 {
+		t.Errorf("过期时间极差 = %v; 期望至少为抖动范围 %v 的80%%", spread, high-low)
+	}
+	if below < n/3 || above < n/3 {
+		t.Errorf("低于/高于名义TTL的条目数 = %d/%d; 期望大致相当", below, above)
+	}
+
+	if _, err := NewTimedCache[int, int](1, ttl, WithTTLJitter(1)); err == nil {
+		t.Error("抖动比例为1时应返回错误")
+	}
+	if _, err := NewTimedCache[int, int](1, ttl, WithTTLJitter(-0.1)); err == nil {
+		t.Error("抖动比例为负数时应返回错误")
+	}
+	if _, err := NewTimedCache[int, int](1, ttl, WithTTLJitter(math.NaN())); err == nil {
+		t.Error("抖动比例为NaN时应返回错误")
+	}
+}
+
+// TestTimedCache_GetByPrefix 测试按前缀查询，结果不包含过期和负缓存条目
+func TestTimedCache_GetByPrefix(t *testing.T) {
+	cache, err := NewTimedCache[string, string](10, time.Hour)
+	if err != nil {
+		t.Fatalf("创建Timed缓存失败: %v", err)
+	}
+
+	cache.Set("app.db.host", "localhost")
+	cache.Set("app.db.port", "5432")
+	cache.Set("app.dbx", "other")
+	cache.Set("app.cache.size", "100")
+	cache.Set("web.db.host", "remote")
+	cache.SetWithTTL("app.db.user", "expired", 10*time.Millisecond)
+	cache.SetNegative("app.db.password", time.Hour)
+	time.Sleep(20 * time.Millisecond)
+
+	got := GetByPrefix[string, string](cache, "app.db.")
+	want := map[string]string{"app.db.host": "localhost", "app.db.port": "5432"}
+	if len(got) != len(want) {
+		t.Fatalf("GetByPrefix(app.db.) = %v; 期望 %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("GetByPrefix(app.db.)[%q] = %q; 期望 %q", k, got[k], v)
+		}
+	}
+
+	if got := GetByPrefix[string, string](cache, ""); len(got) != 5 {
+		t.Errorf("GetByPrefix(\"\") 返回 %d 个条目; 期望 5", len(got))
+	}
+	if got := GetByPrefix[string, string](cache, "missing."); len(got) != 0 {
+		t.Errorf("GetByPrefix(missing.) = %v; 期望空map", got)
+	}
+
+	count := 0
+	cache.Range(func(string, string) bool {
+		count++
+		return count < 2
+	})
+	if count != 2 {
+		t.Errorf("Range 在fn返回false后应停止，实际调用 %d 次", count)
+	}
+}
+
+// TestTimedCache_Clone 测试副本保留过期时间，且修改副本不影响原缓存
+func TestTimedCache_Clone(t *testing.T) {
+	cache, err := NewTimedCache[int, string](3, time.Hour)
+	if err != nil {
+		t.Fatalf("创建Timed缓存失败: %v", err)
+	}
+	cache.SetWithTTL(1, "short", 30*time.Millisecond)
+	cache.SetWithTTL(2, "long", time.Hour)
+	cache.SetNegative(3, time.Hour)
+
+	clone := cache.Clone()
+	_, origTTL, _ := cache.GetWithTTL(2)
+	_, cloneTTL, exists := clone.GetWithTTL(2)
+	if !exists || cloneTTL > origTTL || origTTL-cloneTTL > time.Second {
+		t.Errorf("副本 GetWithTTL(2) ttl = %v, %v; 期望接近原缓存的 %v", cloneTTL, exists, origTTL)
+	}
+	if _, err := clone.GetE(3); !errors.Is(err, ErrKeyNegative) {
+		t.Errorf("副本 GetE(3) error = %v; 期望 ErrKeyNegative", err)
+	}
+
+	clone.Set(2, "clone")
+	clone.Delete(1)
+	if val, exists := cache.Get(2); !exists || val != "long" {
+		t.Errorf("原缓存 Get(2) = %v, %v; 期望 'long', true", val, exists)
+	}
+	if val, exists := cache.Get(1); !exists || val != "short" {
+		t.Errorf("原缓存 Get(1) = %v, %v; 期望 'short', true", val, exists)
+	}
+
+	// 两者中的短TTL条目同时过期
+	clone2 := cache.Clone()
+	time.Sleep(40 * time.Millisecond)
+	if _, exists := clone2.Get(1); exists {
+		t.Error("副本 Get(1) 应该已过期，但存在")
+	}
+	if _, exists := cache.Get(1); exists {
+		t.Error("原缓存 Get(1) 应该已过期，但存在")
+	}
+
+	// 副本的堆与映射保持一致：更新和删除后容量淘汰仍按过期时间进行
+	clone2.SetWithTTL(2, "soon", time.Minute)
+	clone2.Set(4, "d")
+	clone2.Set(5, "e") // 已满（2、3、4），淘汰最早过期的2
+	if _, exists := clone2.Get(2); exists {
+		t.Error("副本 Get(2) 应该被淘汰，但存在")
+	}
+}
+
+// TestTimedCache_Subscribe 测试订阅者按顺序收到各类缓存操作事件
+func TestTimedCache_Subscribe(t *testing.T) {
+	cache, err := NewTimedCache[string, int](2, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("创建Timed缓存失败: %v", err)
+	}
+	events := cache.Subscribe()
+
+	cache.Set("a", 1)
+	cache.Get("a")
+	cache.Get("x")
+	cache.Set("b", 2)
+	cache.Set("c", 3) // 淘汰a
+	cache.Delete("b")
+	cache.Delete("b") // 键不存在，不产生事件
+	cache.SetNegative("n", time.Minute) // 负缓存不产生事件
+	time.Sleep(60 * time.Millisecond)
+	cache.Get("c") // 先清理过期的c，再未命中
+
+	want := []CacheEvent[string, int]{
+		{EventSet, "a", 1},
+		{EventGetHit, "a", 1},
+		{EventGetMiss, "x", 0},
+		{EventSet, "b", 2},
+		{EventEvict, "a", 1},
+		{EventSet, "c", 3},
+		{EventDelete, "b", 2},
+		{EventExpire, "c", 3},
+		{EventGetMiss, "c", 0},
+	}
+	for i, w := range want {
+		select {
+		case got := <-events:
+			if got != w {
+				t.Errorf("事件[%d] = %v %v %v; 期望 %v %v %v", i, got.Type, got.Key, got.Value, w.Type, w.Key, w.Value)
+			}
+		default:
+			t.Fatalf("事件[%d] 缺失; 期望 %v %v %v", i, w.Type, w.Key, w.Value)
+		}
+	}
+	select {
+	case got := <-events:
+		t.Errorf("多余的事件 %v %v %v", got.Type, got.Key, got.Value)
+	default:
+	}
+
+	cache.Unsubscribe(events)
+	if _, ok := <-events; ok {
+		t.Error("Unsubscribe后通道应已关闭")
+	}
+	cache.Unsubscribe(events) // 重复取消无效果
+	cache.Set("d", 4)
+}
+
+// TestTimedCache_SubscribeBackpressure 测试订阅者不消费时缓存操作不会阻塞，多余事件被丢弃
+func TestTimedCache_SubscribeBackpressure(t *testing.T) {
+	cache, err := NewTimedCache[int, int](10, time.Minute)
+	if err != nil {
+		t.Fatalf("创建Timed缓存失败: %v", err)
+	}
+	slow := cache.Subscribe()
+	fast := cache.Subscribe()
+
+	const n = eventBufferSize * 3
+	done := make(chan struct{})
+	received := 0
+	go func() {
+		defer close(done)
+		for range fast {
+			received++
+		}
+	}()
+
+	for i := 0; i < n; i++ {
+		cache.Set(i%10, i)
+	}
+	cache.Unsubscribe(fast)
+	<-done
+
+	if len(slow) != eventBufferSize {
+		t.Errorf("未消费通道中的事件数 = %d; 期望 %d", len(slow), eventBufferSize)
+	}
+	// 前eventBufferSize个事件按顺序保留，之后的事件被丢弃
+	for i := 0; i < eventBufferSize; i++ {
+		if ev := <-slow; ev.Type != EventSet || ev.Value != i {
+			t.Fatalf("事件[%d] = %v %v; 期望 Set %d", i, ev.Type, ev.Value, i)
+		}
+	}
+	if received < eventBufferSize || received > n {
+		t.Errorf("消费者收到 %d 个事件; 期望在[%d, %d]内", received, eventBufferSize, n)
+	}
+}
+
+// TestTimedCache_LazyCleanup 测试惰性清理只检查被访问的键，其余过期条目推迟到Cleanup或容量不足时清理
+func TestTimedCache_LazyCleanup(t *testing.T) {
+	var expired []string
+	cache, err := NewTimedCache[string, int](3, 30*time.Millisecond,
+		WithLazyCleanup(true),
+		WithOnExpire[string, int](func(key string, _ int) {
+			expired = append(expired, key)
+		}),
+		WithOnEvict[string, int](func(key string, _ int) {
+			t.Errorf("未过期的条目%s不应被淘汰", key)
+		}))
+	if err != nil {
+		t.Fatalf("创建Timed缓存失败: %v", err)
+	}
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	time.Sleep(40 * time.Millisecond)
+
+	// 只检查被访问的键a，b仍留在缓存中
+	if _, exists := cache.Get("a"); exists {
+		t.Error("Get(a) 应该已过期，但存在")
+	}
+	if len(expired) != 1 || expired[0] != "a" {
+		t.Errorf("过期回调 = %v; 期望 [a]", expired)
+	}
+	cache.Set("c", 3)
+	if len(cache.cache) != 2 {
+		t.Errorf("内部条目数 = %d; 期望 2（b尚未清理）", len(cache.cache))
+	}
+
+	// 容量不足时先清理过期条目，而不是淘汰未过期的c
+	cache.Set("d", 4)
+	cache.Set("e", 5)
+	if len(expired) != 2 || expired[1] != "b" {
+		t.Errorf("过期回调 = %v; 期望 [a b]", expired)
+	}
+	if val, exists := cache.Get("c"); !exists || val != 3 {
+		t.Errorf("Get(c) = %v, %v; 期望 3, true", val, exists)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	cache.Cleanup()
+	if len(cache.cache) != 0 || len(expired) != 5 {
+		t.Errorf("Cleanup后内部条目数 = %d，过期回调 = %v; 期望 0 和5个键", len(cache.cache), expired)
+	}
+}
+
+// TestTimedCache_ShouldRefresh 测试XFetch提前刷新的概率随过期临近而增大，且过期条目始终需要刷新
+func TestTimedCache_ShouldRefresh(t *testing.T) {
+	const ttl = 200 * time.Millisecond
+	cache, err := NewTimedCache[string, int](10, ttl, WithEarlyExpiration(1))
+	if err != nil {
+		t.Fatalf("创建Timed缓存失败: %v", err)
+	}
+
+	refreshRate := func(key string) float64 {
+		const samples = 2000
+		n := 0
+		for i := 0; i < samples; i++ {
+			if cache.ShouldRefresh(key) {
+				n++
+			}
+		}
+		return float64(n) / samples
+	}
+
+	if !cache.ShouldRefresh("missing") {
+		t.Error("ShouldRefresh(missing) 键不存在时应返回true")
+	}
+
+	// 计算耗时为TTL的1/4：剩余200ms时概率约为e^-4≈0.02，剩余30ms时约为e^-0.6≈0.55
+	cache.SetWithComputeTime("a", 1, ttl/4)
+	cache.Set("plain", 1)
+	far := refreshRate("a")
+	if far > 0.1 {
+		t.Errorf("刚写入时的刷新概率 = %.3f; 期望接近0", far)
+	}
+
+	time.Sleep(ttl - 30*time.Millisecond)
+	near := refreshRate("a")
+	if near < 0.3 || near <= far {
+		t.Errorf("临近过期时的刷新概率 = %.3f（刚写入时 %.3f）; 期望明显增大", near, far)
+	}
+	if rate := refreshRate("plain"); rate != 0 {
+		t.Errorf("未记录计算耗时的条目刷新概率 = %.3f; 期望 0", rate)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	if rate := refreshRate("a"); rate != 1 {
+		t.Errorf("过期后的刷新概率 = %.3f; 期望 1", rate)
+	}
+	if !cache.ShouldRefresh("plain") {
+		t.Error("ShouldRefresh(plain) 已过期时应返回true")
+	}
+
+	// 未启用提前过期时，未过期的条目不需要刷新
+	plain, err := NewTimedCache[string, int](10, time.Minute)
+	if err != nil {
+		t.Fatalf("创建Timed缓存失败: %v", err)
+	}
+	plain.SetWithComputeTime("a", 1, time.Hour)
+	if plain.ShouldRefresh("a") {
+		t.Error("未启用WithEarlyExpiration时 ShouldRefresh(a) 应返回false")
+	}
+	plain.SetNegative("n", time.Minute)
+	if !plain.ShouldRefresh("n") {
+		t.Error("ShouldRefresh(n) 负缓存条目应返回true")
+	}
+
+	if _, err := NewTimedCache[string, int](10, time.Minute, WithEarlyExpiration(-1)); err == nil {
+		t.Error("beta为负数时应返回错误")
+	}
+}
+
 // TestTimedCacheConcurrent 测试并发环境下TimedCache的正确性
 func TestTimedCacheConcurrent(t *testing.T) {
 	// 使用较长TTL避免测试过程中条目过期
@@ -246,4 +965,26 @@ func BenchmarkTimedCache_Expiration(b *testing.B) {
 			cache.Get(0)
 		}
 	}
-}
\ No newline at end of file
+}
+
+// BenchmarkTimedCache_GetLazyCleanup 基准测试大量未过期条目时惰性清理对Get的影响
+func BenchmarkTimedCache_GetLazyCleanup(b *testing.B) {
+	const size = 100000
+	for _, lazy := range []bool{false, true} {
+		name := "eager"
+		if lazy {
+			name = "lazy"
+		}
+		b.Run(name, func(b *testing.B) {
+			cache, _ := NewTimedCache[int, int](size, time.Hour, WithLazyCleanup(lazy))
+			for i := 0; i < size; i++ {
+				cache.Set(i, i)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				cache.Get(i % size)
+			}
+		})
+	}
+}
@@ -0,0 +1,362 @@
+package cache
+
+import (
+	"container/list"
+	"errors"
+	"hash/maphash"
+	"sync"
+)
+
+// tinyLFUSegment 条目所在的分区
+type tinyLFUSegment int
+
+const (
+	segmentWindow    tinyLFUSegment = iota // 准入窗口
+	segmentProbation                       // 主区的试用段
+	segmentProtected                       // 主区的保护段
+)
+
+// tinyLFUEntry 链表节点存储的数据结构
+type tinyLFUEntry[K comparable, V any] struct {
+	key     K              // 缓存键
+	value   V              // 缓存值
+	segment tinyLFUSegment // 所在分区
+}
+
+// TinyLFUOption 定义TinyLFU缓存的配置选项函数类型
+type TinyLFUOption func(*tinyLFUCacheOptions)
+
+// tinyLFUCacheOptions TinyLFU缓存的配置选项
+type tinyLFUCacheOptions struct {
+	concurrentSafe bool
+}
+
+// WithTinyLFUConcurrentSafe 设置是否启用并发安全模式
+func WithTinyLFUConcurrentSafe(concurrentSafe bool) TinyLFUOption {
+	return func(o *tinyLFUCacheOptions) {
+		o.concurrentSafe = concurrentSafe
+	}
+}
+
+// TinyLFUCache 基于W-TinyLFU策略的缓存实现（Caffeine、Ristretto采用的策略）
+// 缓存分为两部分:
+//   - 准入窗口: 约占容量1%的LRU，新条目先进入窗口，用于吸收突发的新访问
+//   - 主区: 分段LRU（SLRU），由试用段和保护段（约占主区80%）组成，再次命中的试用条目晋升到保护段
+// 条目被挤出窗口时，通过Count-Min Sketch估计其访问频率，与主区的淘汰候选比较，
+// 只有频率更高的一方留在缓存中，因此一次性扫描不会冲掉高频访问的热点键
+// 在Zipf分布等倾斜访问模式下，命中率通常优于单纯的LRU或LFU
+// K为键类型，必须支持比较操作；V为值类型，可以是任意类型
+type TinyLFUCache[K comparable, V any] struct {
+	cache          map[K]*list.Element // 键到链表元素的映射
+	window         *list.List          // 准入窗口LRU，头部为最近访问
+	probation      *list.List          // 主区试用段LRU
+	protected      *list.List          // 主区保护段LRU
+	windowCap      int                 // 窗口容量
+	mainCap        int                 // 主区容量
+	protectedCap   int                 // 保护段容量
+	sketch         *frequencySketch    // 访问频率估计器
+	seed           maphash.Seed        // 键哈希种子
+	concurrentSafe bool                // 是否启用并发安全
+	mu             sync.Mutex          // 互斥锁，Get也会修改内部状态
+}
+
+// NewTinyLFUCache 创建新的TinyLFU缓存实例
+// capacity为缓存容量，必须大于0，否则返回错误
+// 返回值:
+//   *TinyLFUCache[K, V]: 成功创建的缓存实例
+//   error: 当capacity <= 0时返回非nil错误
+func NewTinyLFUCache[K comparable, V any](capacity int, options ...TinyLFUOption) (*TinyLFUCache[K, V], error) {
+	if capacity <= 0 {
+		return nil, errors.New("capacity must be positive")
+	}
+
+	opts := tinyLFUCacheOptions{
+		concurrentSafe: true, // 默认启用并发安全
+	}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	windowCap := capacity / 100
+	if windowCap < 1 {
+		windowCap = 1
+	}
+	mainCap := capacity - windowCap
+
+	return &TinyLFUCache[K, V]{
+		cache:          make(map[K]*list.Element),
+		window:         list.New(),
+		probation:      list.New(),
+		protected:      list.New(),
+		windowCap:      windowCap,
+		mainCap:        mainCap,
+		protectedCap:   mainCap * 80 / 100,
+		sketch:         newFrequencySketch(capacity),
+		seed:           maphash.MakeSeed(),
+		concurrentSafe: opts.concurrentSafe,
+	}, nil
+}
+
+// Get 从缓存中获取键对应的值
+// 每次调用（无论是否命中）都会记录一次访问频率；命中时更新条目在所属分区中的位置
+// 参数:
+//   key: 要查找的键
+// 返回值:
+//   value: 键对应的值，如果键不存在则返回V类型的零值
+//   exists: 布尔值，表示键是否存在于缓存中
+func (c *TinyLFUCache[K, V]) Get(key K) (value V, exists bool) {
+	if c.concurrentSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+
+	c.sketch.increment(c.hash(key))
+
+	elem, exists := c.cache[key]
+	if !exists {
+		return value, false
+	}
+	c.onHit(elem)
+	return elem.Value.(*tinyLFUEntry[K, V]).value, true
+}
+
+// Set 将键值对存入缓存
+// 如果键已存在，更新值并按命中处理；否则新条目进入准入窗口，
+// 窗口溢出时被挤出的条目需与主区的淘汰候选比较访问频率，频率更高者被保留
+// 参数:
+//   key: 要存储的键
+//   value: 要存储的值
+func (c *TinyLFUCache[K, V]) Set(key K, value V) {
+	if c.concurrentSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+
+	c.set(key, value)
+}
+
+// SetIfAbsent 仅当键不存在时写入键值对，写入方式与Set相同（新条目进入准入窗口）
+// 检查和写入在同一次加锁内完成，是原子的
+// 参数:
+//   key: 要存储的键
+//   value: 要存储的值
+// 返回值:
+//   bool: 是否写入了值，键已存在时返回false；
+//         写入后条目仍可能因准入竞争失败而被立即淘汰
+func (c *TinyLFUCache[K, V]) SetIfAbsent(key K, value V) bool {
+	if c.concurrentSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+
+	if _, exists := c.cache[key]; exists {
+		return false
+	}
+	c.set(key, value)
+	return true
+}
+
+// SetIfPresent 仅当键已存在时更新其值，并按命中处理
+// 检查和写入在同一次加锁内完成，是原子的
+// 参数:
+//   key: 要存储的键
+//   value: 要存储的值
+// 返回值:
+//   bool: 是否更新了值，键不存在时返回false
+func (c *TinyLFUCache[K, V]) SetIfPresent(key K, value V) bool {
+	if c.concurrentSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+
+	if _, exists := c.cache[key]; !exists {
+		return false
+	}
+	c.set(key, value)
+	return true
+}
+
+// set 存储键值对并记录访问频率，此方法应在持有锁的情况下调用
+func (c *TinyLFUCache[K, V]) set(key K, value V) {
+	c.sketch.increment(c.hash(key))
+
+	if elem, exists := c.cache[key]; exists {
+		elem.Value.(*tinyLFUEntry[K, V]).value = value
+		c.onHit(elem)
+		return
+	}
+
+	c.cache[key] = c.window.PushFront(&tinyLFUEntry[K, V]{key: key, value: value, segment: segmentWindow})
+	if c.window.Len() > c.windowCap {
+		c.admit(c.window.Back())
+	}
+}
+
+// Delete 从缓存中删除指定键
+// 如果键不存在，此操作无效果
+// 参数:
+//   key: 要删除的键
+func (c *TinyLFUCache[K, V]) Delete(key K) {
+	if c.concurrentSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+
+	elem, exists := c.cache[key]
+	if !exists {
+		return
+	}
+	c.segmentList(elem).Remove(elem)
+	delete(c.cache, key)
+}
+
+// GetAll 返回所有条目的快照
+// 不会记录访问频率，也不会改变条目的位置
+// 返回值:
+//   map[K]V: 缓存中的所有键值对
+func (c *TinyLFUCache[K, V]) GetAll() map[K]V {
+	if c.concurrentSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+
+	result := make(map[K]V, len(c.cache))
+	for key, elem := range c.cache {
+		result[key] = elem.Value.(*tinyLFUEntry[K, V]).value
+	}
+	return result
+}
+
+// Clone 返回缓存的独立副本，包含相同的条目、分区及顺序和访问频率统计
+// 复制在持有锁的情况下完成，之后对副本或原缓存的修改互不影响
+// 返回值:
+//   *TinyLFUCache[K, V]: 容量和并发安全配置与原缓存相同的新缓存
+func (c *TinyLFUCache[K, V]) Clone() *TinyLFUCache[K, V] {
+	if c.concurrentSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+
+	clone := &TinyLFUCache[K, V]{
+		cache:          make(map[K]*list.Element, len(c.cache)),
+		window:         list.New(),
+		probation:      list.New(),
+		protected:      list.New(),
+		windowCap:      c.windowCap,
+		mainCap:        c.mainCap,
+		protectedCap:   c.protectedCap,
+		sketch:         c.sketch.clone(),
+		seed:           c.seed, // 与频率估计器中的计数保持对应
+		concurrentSafe: c.concurrentSafe,
+	}
+	for _, segment := range [][2]*list.List{
+		{c.window, clone.window},
+		{c.probation, clone.probation},
+		{c.protected, clone.protected},
+	} {
+		for elem := segment[0].Front(); elem != nil; elem = elem.Next() {
+			e := elem.Value.(*tinyLFUEntry[K, V])
+			clone.cache[e.key] = segment[1].PushBack(&tinyLFUEntry[K, V]{key: e.key, value: e.value, segment: e.segment})
+		}
+	}
+	return clone
+}
+
+// Len 返回当前缓存中的元素数量
+// 返回值:
+//   int: 缓存中已存储的键值对数量
+func (c *TinyLFUCache[K, V]) Len() int {
+	if c.concurrentSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+
+	return len(c.cache)
+}
+
+// Clear 清空缓存中的所有元素和访问频率统计
+func (c *TinyLFUCache[K, V]) Clear() {
+	if c.concurrentSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+
+	c.cache = make(map[K]*list.Element)
+	c.window.Init()
+	c.probation.Init()
+	c.protected.Init()
+	c.sketch.clear()
+}
+
+// hash 计算键的哈希值
+func (c *TinyLFUCache[K, V]) hash(key K) uint64 {
+	return maphash.Comparable(c.seed, key)
+}
+
+// segmentList 返回条目所在分区的链表
+func (c *TinyLFUCache[K, V]) segmentList(elem *list.Element) *list.List {
+	switch elem.Value.(*tinyLFUEntry[K, V]).segment {
+	case segmentProbation:
+		return c.probation
+	case segmentProtected:
+		return c.protected
+	default:
+		return c.window
+	}
+}
+
+// onHit 处理命中：窗口和保护段内移到头部，试用段条目晋升到保护段
+// 保护段溢出时，其最久未访问的条目降级回试用段头部
+func (c *TinyLFUCache[K, V]) onHit(elem *list.Element) {
+	e := elem.Value.(*tinyLFUEntry[K, V])
+	switch e.segment {
+	case segmentWindow:
+		c.window.MoveToFront(elem)
+	case segmentProtected:
+		c.protected.MoveToFront(elem)
+	case segmentProbation:
+		c.probation.Remove(elem)
+		e.segment = segmentProtected
+		c.cache[e.key] = c.protected.PushFront(e)
+
+		if c.protected.Len() > c.protectedCap {
+			demoted := c.protected.Remove(c.protected.Back()).(*tinyLFUEntry[K, V])
+			demoted.segment = segmentProbation
+			c.cache[demoted.key] = c.probation.PushFront(demoted)
+		}
+	}
+}
+
+// admit 处理被挤出窗口的候选条目
+// 主区未满时直接进入试用段；否则与主区的淘汰候选比较频率，频率更高者留下
+func (c *TinyLFUCache[K, V]) admit(elem *list.Element) {
+	candidate := c.window.Remove(elem).(*tinyLFUEntry[K, V])
+
+	if c.probation.Len()+c.protected.Len() < c.mainCap {
+		candidate.segment = segmentProbation
+		c.cache[candidate.key] = c.probation.PushFront(candidate)
+		return
+	}
+
+	// 淘汰候选优先取试用段尾部，试用段为空时取保护段尾部
+	victimList := c.probation
+	if victimList.Len() == 0 {
+		victimList = c.protected
+	}
+	victimElem := victimList.Back()
+	if victimElem == nil {
+		// 主区容量为0，候选条目无法进入主区
+		delete(c.cache, candidate.key)
+		return
+	}
+
+	victim := victimElem.Value.(*tinyLFUEntry[K, V])
+	if c.sketch.estimate(c.hash(candidate.key)) > c.sketch.estimate(c.hash(victim.key)) {
+		victimList.Remove(victimElem)
+		delete(c.cache, victim.key)
+		candidate.segment = segmentProbation
+		c.cache[candidate.key] = c.probation.PushFront(candidate)
+		return
+	}
+	delete(c.cache, candidate.key)
+}
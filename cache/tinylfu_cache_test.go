@@ -0,0 +1,199 @@
+package cache
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// 确保TinyLFUCache实现了Cache接口
+var _ Cache[string, int] = (*TinyLFUCache[string, int])(nil)
+
+// TestTinyLFUCache_Basic 测试基本的Set、Get、Delete和Clear操作
+func TestTinyLFUCache_Basic(t *testing.T) {
+	cache, err := NewTinyLFUCache[int, string](10)
+	if err != nil {
+		t.Fatalf("创建TinyLFU缓存失败: %v", err)
+	}
+
+	cache.Set(1, "a")
+	cache.Set(2, "b")
+	val, exists := cache.Get(1)
+	if !exists || val != "a" {
+		t.Errorf("Get(1) = %v, %v; 期望 'a', true", val, exists)
+	}
+
+	cache.Set(1, "a_updated")
+	val, exists = cache.Get(1)
+	if !exists || val != "a_updated" {
+		t.Errorf("Get(1) = %v, %v; 期望 'a_updated', true", val, exists)
+	}
+
+	cache.Delete(1)
+	if _, exists := cache.Get(1); exists {
+		t.Error("Get(1) 在删除后应该不存在")
+	}
+	if cache.Len() != 1 {
+		t.Errorf("Len() = %d; 期望 1", cache.Len())
+	}
+
+	cache.Clear()
+	if cache.Len() != 0 {
+		t.Errorf("Clear() 后 Len() = %d; 期望 0", cache.Len())
+	}
+
+	if _, err := NewTinyLFUCache[int, string](0); err == nil {
+		t.Error("容量为0时应返回错误")
+	}
+}
+
+// TestTinyLFUCache_Capacity 测试条目数不超过容量
+func TestTinyLFUCache_Capacity(t *testing.T) {
+	for _, capacity := range []int{1, 2, 10, 250} {
+		cache, err := NewTinyLFUCache[int, int](capacity)
+		if err != nil {
+			t.Fatalf("创建TinyLFU缓存失败: %v", err)
+		}
+		for i := 0; i < capacity*5; i++ {
+			cache.Set(i, i)
+			cache.Get(i % 7)
+		}
+		if cache.Len() > capacity {
+			t.Errorf("容量 %d: Len() = %d; 不应超过容量", capacity, cache.Len())
+		}
+		if len(cache.GetAll()) != cache.Len() {
+			t.Errorf("容量 %d: GetAll() 条目数与 Len() 不一致", capacity)
+		}
+	}
+}
+
+// TestTinyLFUCache_ScanResistance 测试一次性扫描不会淘汰高频访问的热点键，而LRU会淘汰
+func TestTinyLFUCache_ScanResistance(t *testing.T) {
+	const capacity = 100
+	tiny, err := NewTinyLFUCache[string, int](capacity)
+	if err != nil {
+		t.Fatalf("创建TinyLFU缓存失败: %v", err)
+	}
+	lru, err := NewLRUCache[string, int](capacity)
+	if err != nil {
+		t.Fatalf("创建LRU缓存失败: %v", err)
+	}
+
+	for _, c := range []Cache[string, int]{tiny, lru} {
+		c.Set("hot", 1)
+		for i := 0; i < 20; i++ {
+			c.Get("hot")
+		}
+		// 一次性扫描大量只访问一次的键
+		for i := 0; i < 1000; i++ {
+			c.Set(fmt.Sprintf("scan_%d", i), i)
+		}
+	}
+
+	if _, exists := tiny.Get("hot"); !exists {
+		t.Error("TinyLFU 应该在扫描后保留热点键")
+	}
+	if _, exists := lru.Get("hot"); exists {
+		t.Error("LRU 应该在扫描后淘汰热点键")
+	}
+}
+
+// TestTinyLFUCache_HitRatio 测试Zipf分布访问下命中率不低于LRU
+func TestTinyLFUCache_HitRatio(t *testing.T) {
+	const capacity = 100
+	tiny, _ := NewTinyLFUCache[uint64, uint64](capacity)
+	lru, _ := NewLRUCache[uint64, uint64](capacity)
+
+	hitRatio := func(c Cache[uint64, uint64]) float64 {
+		zipf := rand.NewZipf(rand.New(rand.NewSource(42)), 1.1, 1, 10000)
+		hits := 0
+		const requests = 50000
+		for i := 0; i < requests; i++ {
+			key := zipf.Uint64()
+			if _, exists := c.Get(key); exists {
+				hits++
+			} else {
+				c.Set(key, key)
+			}
+		}
+		return float64(hits) / requests
+	}
+
+	tinyRatio, lruRatio := hitRatio(tiny), hitRatio(lru)
+	if tinyRatio < lruRatio {
+		t.Errorf("TinyLFU 命中率 %.4f 低于 LRU 命中率 %.4f", tinyRatio, lruRatio)
+	}
+}
+
+// TestTinyLFUCache_Clone 测试副本保留条目和频率统计，且修改副本不影响原缓存
+func TestTinyLFUCache_Clone(t *testing.T) {
+	cache, err := NewTinyLFUCache[int, int](100)
+	if err != nil {
+		t.Fatalf("创建TinyLFU缓存失败: %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		cache.Set(i, i)
+	}
+	for i := 0; i < 5; i++ {
+		cache.Get(7)
+	}
+
+	clone := cache.Clone()
+	if got, want := clone.sketch.estimate(clone.hash(7)), cache.sketch.estimate(cache.hash(7)); got != want {
+		t.Errorf("副本中7的频率估计 = %d; 期望 %d", got, want)
+	}
+	if all := clone.GetAll(); len(all) != cache.Len() {
+		t.Errorf("副本 GetAll() 返回 %d 个条目; 期望 %d", len(all), cache.Len())
+	}
+
+	clone.Clear()
+	clone.Set(1000, 1000)
+	if cache.Len() == 0 {
+		t.Error("清空副本后原缓存不应为空")
+	}
+	if _, exists := cache.Get(1000); exists {
+		t.Error("原缓存 Get(1000) 不应该存在")
+	}
+	if cache.sketch.estimate(cache.hash(7)) == 0 {
+		t.Error("清空副本不应重置原缓存的频率统计")
+	}
+}
+
+// TestFrequencySketch 测试频率估计与衰减
+func TestFrequencySketch(t *testing.T) {
+	s := newFrequencySketch(64)
+	for i := 0; i < 5; i++ {
+		s.increment(42)
+	}
+	if got := s.estimate(42); got < 5 {
+		t.Errorf("estimate(42) = %d; 期望至少 5", got)
+	}
+	for i := 0; i < 100; i++ {
+		s.increment(42)
+	}
+	if got := s.estimate(42); got != sketchMaxCount {
+		t.Errorf("estimate(42) = %d; 期望封顶为 %d", got, sketchMaxCount)
+	}
+
+	s.reset()
+	if got := s.estimate(42); got != sketchMaxCount/2 {
+		t.Errorf("reset() 后 estimate(42) = %d; 期望 %d", got, sketchMaxCount/2)
+	}
+
+	s.clear()
+	if got := s.estimate(42); got != 0 {
+		t.Errorf("clear() 后 estimate(42) = %d; 期望 0", got)
+	}
+}
+
+// BenchmarkTinyLFUCache_SetGet 基准测试Set和Get操作性能
+func BenchmarkTinyLFUCache_SetGet(b *testing.B) {
+	cache, _ := NewTinyLFUCache[int, int](1000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		key := i % 2000
+		cache.Set(key, i)
+		cache.Get(key)
+	}
+}
@@ -0,0 +1,231 @@
+package dateutil
+
+import (
+	"errors"
+	"time"
+)
+
+// dateKey 以年月日作为节假日索引，忽略时分秒和时区
+type dateKey struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+// newDateKey 根据时间生成年月日索引
+func newDateKey(t time.Time) dateKey {
+	year, month, day := t.Date()
+	return dateKey{year: year, month: month, day: day}
+}
+
+// BusinessCalendar 工作日历，用于判断某天是否为工作日
+// 默认周六、周日为周末，可额外登记节假日
+// 注意: 登记节假日的方法不是并发安全的，应在初始化阶段完成配置
+type BusinessCalendar struct {
+	holidays map[dateKey]bool
+}
+
+// NewBusinessCalendar 创建工作日历
+// holidays: 节假日列表，仅使用其年月日部分
+// 返回值: 工作日历实例
+func NewBusinessCalendar(holidays ...time.Time) *BusinessCalendar {
+	cal := &BusinessCalendar{
+		holidays: make(map[dateKey]bool),
+	}
+	cal.AddHolidays(holidays...)
+	return cal
+}
+
+// AddHolidays 登记节假日
+// dates: 节假日列表，仅使用其年月日部分
+func (c *BusinessCalendar) AddHolidays(dates ...time.Time) {
+	for _, d := range dates {
+		c.holidays[newDateKey(d)] = true
+	}
+}
+
+// IsHoliday 判断日期是否为登记的节假日
+// 日历为nil时始终返回false
+func (c *BusinessCalendar) IsHoliday(t time.Time) bool {
+	if c == nil {
+		return false
+	}
+	return c.holidays[newDateKey(t)]
+}
+
+// IsBusinessDay 判断日期是否为工作日（非周末且非节假日）
+// 日历为nil时仅排除周末
+func (c *BusinessCalendar) IsBusinessDay(t time.Time) bool {
+	return !IsWeekend(t) && !c.IsHoliday(t)
+}
+
+// NextBusinessDay 返回t之后（不含t当天）的第一个工作日的开始时间
+// 日历为nil时仅排除周末
+func (c *BusinessCalendar) NextBusinessDay(t time.Time) time.Time {
+	day := BeginOfDay(t)
+	for {
+		day = AddDaysWallClock(day, 1)
+		if c.IsBusinessDay(day) {
+			return day
+		}
+	}
+}
+
+// NextBusinessMorning 返回下一个工作日早上指定整点的时间，用于"下一个工作日早上"的调度
+// 如果t当天是工作日且当天的hour:00还在t之后，返回当天hour:00；否则返回下一个工作日的hour:00
+// t: 参考时间
+// hour: 整点小时（0-23）
+// cal: 工作日历，为nil时仅排除周末
+// 返回值: 下一个工作日早上的时间，时区与t一致
+func NextBusinessMorning(t time.Time, hour int, cal *BusinessCalendar) time.Time {
+	day := BeginOfDay(t)
+	morning := time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, t.Location())
+	if morning.After(t) && cal.IsBusinessDay(day) {
+		return morning
+	}
+
+	next := cal.NextBusinessDay(t)
+	return time.Date(next.Year(), next.Month(), next.Day(), hour, 0, 0, 0, t.Location())
+}
+
+// businessWindow 某天的营业时间窗口，以距当天零点的挂钟偏移表示
+type businessWindow struct {
+	open  time.Duration
+	close time.Duration
+}
+
+// maxOpenSearchDays NextOpen向后查找营业时间的最大天数
+const maxOpenSearchDays = 373
+
+// BusinessHours 营业时间表，按星期几配置每天的营业时间窗口（如周一至周五09:00-17:00）
+// 可结合BusinessCalendar排除节假日：节假日当天全天不营业
+// 注意: 配置方法不是并发安全的，应在初始化阶段完成配置
+type BusinessHours struct {
+	windows  map[time.Weekday]businessWindow
+	calendar *BusinessCalendar
+}
+
+// NewBusinessHours 创建周一至周五营业的营业时间表
+// open: 每天开始营业的时刻，以距零点的偏移表示（如9*time.Hour）
+// close: 每天结束营业的时刻，必须晚于open且不超过24小时
+// cal: 工作日历，用于排除节假日，可为nil
+// 返回值: 营业时间表和可能的错误（时间窗口无效）
+func NewBusinessHours(open, close time.Duration, cal *BusinessCalendar) (*BusinessHours, error) {
+	bh := &BusinessHours{
+		windows:  make(map[time.Weekday]businessWindow),
+		calendar: cal,
+	}
+	for day := time.Monday; day <= time.Friday; day++ {
+		if err := bh.SetHours(day, open, close); err != nil {
+			return nil, err
+		}
+	}
+	return bh, nil
+}
+
+// SetHours 设置星期几的营业时间窗口，覆盖已有设置
+// day: 星期几
+// open: 开始营业的时刻，以距零点的偏移表示
+// close: 结束营业的时刻，必须晚于open且不超过24小时
+// 返回值: 时间窗口无效时返回错误
+func (b *BusinessHours) SetHours(day time.Weekday, open, close time.Duration) error {
+	if open < 0 || close > 24*time.Hour || open >= close {
+		return errors.New("invalid business hours window")
+	}
+	b.windows[day] = businessWindow{open: open, close: close}
+	return nil
+}
+
+// ClearHours 将星期几设置为全天不营业
+// day: 星期几
+func (b *BusinessHours) ClearHours(day time.Weekday) {
+	delete(b.windows, day)
+}
+
+// IsOpen 判断时间是否处于营业时间内
+// 营业窗口为左闭右开区间：恰好在开始时刻视为营业，恰好在结束时刻视为已打烊
+// t: 待判断的时间
+// 返回值: 营业中返回true，否则返回false
+func (b *BusinessHours) IsOpen(t time.Time) bool {
+	open, close, ok := b.window(t)
+	return ok && !t.Before(open) && t.Before(close)
+}
+
+// NextOpen 返回t之后（含t）最近的营业时刻
+// 如果t正处于营业时间内，直接返回t；否则返回下一个营业窗口的开始时刻
+// t: 参考时间
+// 返回值: 最近的营业时刻，一年内都没有营业时间时返回零值时间
+func (b *BusinessHours) NextOpen(t time.Time) time.Time {
+	if b.IsOpen(t) {
+		return t
+	}
+
+	day := BeginOfDay(t)
+	for i := 0; i < maxOpenSearchDays; i++ {
+		open, _, ok := b.window(day)
+		if ok && open.After(t) {
+			return open
+		}
+		day = AddDaysWallClock(day, 1)
+	}
+	return time.Time{}
+}
+
+// UntilClose 返回距当前营业窗口结束的时长
+// t: 参考时间
+// 返回值: 距打烊的时长，不在营业时间内时返回0
+func (b *BusinessHours) UntilClose(t time.Time) time.Duration {
+	open, close, ok := b.window(t)
+	if !ok || t.Before(open) || !t.Before(close) {
+		return 0
+	}
+	return close.Sub(t)
+}
+
+// window 返回t所在日期的营业时间窗口，当天不营业（未配置或为节假日）时ok为false
+func (b *BusinessHours) window(t time.Time) (open, close time.Time, ok bool) {
+	w, exists := b.windows[t.Weekday()]
+	if !exists || b.calendar.IsHoliday(t) {
+		return time.Time{}, time.Time{}, false
+	}
+	year, month, day := t.Date()
+	// 通过time.Date的纳秒进位得到挂钟时刻，避免夏令时切换日的偏差
+	open = time.Date(year, month, day, 0, 0, 0, int(w.open), t.Location())
+	close = time.Date(year, month, day, 0, 0, 0, int(w.close), t.Location())
+	return open, close, true
+}
+
+// BusinessSecondsBetween 计算两个时间之间处于营业时间内的秒数，用于精确的SLA计时
+// 只累计营业窗口与[start, end)区间的重叠部分，夜间、不营业的日期以及cal中的非工作日均不计入
+// start: 开始时间
+// end: 结束时间，早于或等于start时返回0；按start的时区确定每天的营业窗口
+// hours: 营业时间表，不能为nil
+// cal: 工作日历，其中的周末和节假日不计入；为nil时只按营业时间表判断
+// 返回值: 营业时间内的秒数，不足一秒的部分舍去
+func BusinessSecondsBetween(start, end time.Time, hours *BusinessHours, cal *BusinessCalendar) int64 {
+	if !end.After(start) {
+		return 0
+	}
+	end = end.In(start.Location())
+
+	var total time.Duration
+	for day := BeginOfDay(start); day.Before(end); day = AddDaysWallClock(day, 1) {
+		if cal != nil && !cal.IsBusinessDay(day) {
+			continue
+		}
+		open, close, ok := hours.window(day)
+		if !ok {
+			continue
+		}
+		if open.Before(start) {
+			open = start
+		}
+		if close.After(end) {
+			close = end
+		}
+		if close.After(open) {
+			total += close.Sub(open)
+		}
+	}
+	return int64(total / time.Second)
+}
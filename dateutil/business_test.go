@@ -0,0 +1,322 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBusinessCalendar(t *testing.T) {
+	cal := NewBusinessCalendar(time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name        string
+		cal         *BusinessCalendar
+		t           time.Time
+		wantHoliday bool
+		wantBizDay  bool
+	}{{
+		name:        "normal weekday",
+		cal:         cal,
+		t:           time.Date(2023, 10, 3, 10, 0, 0, 0, time.UTC),
+		wantHoliday: false,
+		wantBizDay:  true,
+	}, {
+		name:        "holiday",
+		cal:         cal,
+		t:           time.Date(2023, 10, 2, 15, 0, 0, 0, time.UTC),
+		wantHoliday: true,
+		wantBizDay:  false,
+	}, {
+		name:        "weekend",
+		cal:         cal,
+		t:           time.Date(2023, 10, 7, 10, 0, 0, 0, time.UTC),
+		wantHoliday: false,
+		wantBizDay:  false,
+	}, {
+		name:        "nil calendar weekday",
+		cal:         nil,
+		t:           time.Date(2023, 10, 2, 10, 0, 0, 0, time.UTC),
+		wantHoliday: false,
+		wantBizDay:  true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cal.IsHoliday(tt.t); got != tt.wantHoliday {
+				t.Errorf("IsHoliday() = %v, want %v", got, tt.wantHoliday)
+			}
+			if got := tt.cal.IsBusinessDay(tt.t); got != tt.wantBizDay {
+				t.Errorf("IsBusinessDay() = %v, want %v", got, tt.wantBizDay)
+			}
+		})
+	}
+}
+
+func TestNextBusinessDay(t *testing.T) {
+	cal := NewBusinessCalendar(time.Date(2023, 10, 9, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name string
+		cal  *BusinessCalendar
+		t    time.Time
+		want time.Time
+	}{{
+		name: "weekday to next day",
+		cal:  nil,
+		t:    time.Date(2023, 10, 3, 10, 0, 0, 0, time.UTC),
+		want: time.Date(2023, 10, 4, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "friday to monday",
+		cal:  nil,
+		t:    time.Date(2023, 10, 6, 10, 0, 0, 0, time.UTC),
+		want: time.Date(2023, 10, 9, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "friday to tuesday over holiday",
+		cal:  cal,
+		t:    time.Date(2023, 10, 6, 10, 0, 0, 0, time.UTC),
+		want: time.Date(2023, 10, 10, 0, 0, 0, 0, time.UTC),
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cal.NextBusinessDay(tt.t); !got.Equal(tt.want) {
+				t.Errorf("NextBusinessDay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextBusinessMorning(t *testing.T) {
+	cal := NewBusinessCalendar(time.Date(2023, 10, 9, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name string
+		t    time.Time
+		hour int
+		cal  *BusinessCalendar
+		want time.Time
+	}{{
+		name: "friday afternoon rolls to monday",
+		t:    time.Date(2023, 10, 6, 15, 0, 0, 0, time.UTC),
+		hour: 9,
+		cal:  nil,
+		want: time.Date(2023, 10, 9, 9, 0, 0, 0, time.UTC),
+	}, {
+		name: "weekday before cutoff stays same day",
+		t:    time.Date(2023, 10, 4, 7, 30, 0, 0, time.UTC),
+		hour: 9,
+		cal:  nil,
+		want: time.Date(2023, 10, 4, 9, 0, 0, 0, time.UTC),
+	}, {
+		name: "exactly at cutoff rolls to next day",
+		t:    time.Date(2023, 10, 4, 9, 0, 0, 0, time.UTC),
+		hour: 9,
+		cal:  nil,
+		want: time.Date(2023, 10, 5, 9, 0, 0, 0, time.UTC),
+	}, {
+		name: "saturday morning rolls to monday",
+		t:    time.Date(2023, 10, 7, 7, 0, 0, 0, time.UTC),
+		hour: 9,
+		cal:  nil,
+		want: time.Date(2023, 10, 9, 9, 0, 0, 0, time.UTC),
+	}, {
+		name: "holiday monday rolls to tuesday",
+		t:    time.Date(2023, 10, 6, 15, 0, 0, 0, time.UTC),
+		hour: 9,
+		cal:  cal,
+		want: time.Date(2023, 10, 10, 9, 0, 0, 0, time.UTC),
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextBusinessMorning(tt.t, tt.hour, tt.cal); !got.Equal(tt.want) {
+				t.Errorf("NextBusinessMorning() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBusinessHours(t *testing.T) {
+	cal := NewBusinessCalendar(time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC))
+	bh, err := NewBusinessHours(9*time.Hour, 17*time.Hour, cal)
+	if err != nil {
+		t.Fatalf("NewBusinessHours() error = %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		t              time.Time
+		wantOpen       bool
+		wantNextOpen   time.Time
+		wantUntilClose time.Duration
+	}{{
+		name:           "inside hours",
+		t:              time.Date(2023, 10, 3, 10, 30, 0, 0, time.UTC),
+		wantOpen:       true,
+		wantNextOpen:   time.Date(2023, 10, 3, 10, 30, 0, 0, time.UTC),
+		wantUntilClose: 6*time.Hour + 30*time.Minute,
+	}, {
+		name:           "before open",
+		t:              time.Date(2023, 10, 3, 8, 0, 0, 0, time.UTC),
+		wantOpen:       false,
+		wantNextOpen:   time.Date(2023, 10, 3, 9, 0, 0, 0, time.UTC),
+		wantUntilClose: 0,
+	}, {
+		name:           "after close",
+		t:              time.Date(2023, 10, 3, 18, 0, 0, 0, time.UTC),
+		wantOpen:       false,
+		wantNextOpen:   time.Date(2023, 10, 4, 9, 0, 0, 0, time.UTC),
+		wantUntilClose: 0,
+	}, {
+		name:           "exactly at open",
+		t:              time.Date(2023, 10, 3, 9, 0, 0, 0, time.UTC),
+		wantOpen:       true,
+		wantNextOpen:   time.Date(2023, 10, 3, 9, 0, 0, 0, time.UTC),
+		wantUntilClose: 8 * time.Hour,
+	}, {
+		name:           "exactly at close",
+		t:              time.Date(2023, 10, 3, 17, 0, 0, 0, time.UTC),
+		wantOpen:       false,
+		wantNextOpen:   time.Date(2023, 10, 4, 9, 0, 0, 0, time.UTC),
+		wantUntilClose: 0,
+	}, {
+		name:           "weekend",
+		t:              time.Date(2023, 10, 7, 10, 0, 0, 0, time.UTC),
+		wantOpen:       false,
+		wantNextOpen:   time.Date(2023, 10, 9, 9, 0, 0, 0, time.UTC),
+		wantUntilClose: 0,
+	}, {
+		name:           "holiday",
+		t:              time.Date(2023, 10, 2, 10, 0, 0, 0, time.UTC),
+		wantOpen:       false,
+		wantNextOpen:   time.Date(2023, 10, 3, 9, 0, 0, 0, time.UTC),
+		wantUntilClose: 0,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bh.IsOpen(tt.t); got != tt.wantOpen {
+				t.Errorf("IsOpen() = %v, want %v", got, tt.wantOpen)
+			}
+			if got := bh.NextOpen(tt.t); !got.Equal(tt.wantNextOpen) {
+				t.Errorf("NextOpen() = %v, want %v", got, tt.wantNextOpen)
+			}
+			if got := bh.UntilClose(tt.t); got != tt.wantUntilClose {
+				t.Errorf("UntilClose() = %v, want %v", got, tt.wantUntilClose)
+			}
+		})
+	}
+}
+
+func TestBusinessHours_SetHours(t *testing.T) {
+	bh, err := NewBusinessHours(9*time.Hour, 17*time.Hour, nil)
+	if err != nil {
+		t.Fatalf("NewBusinessHours() error = %v", err)
+	}
+	if err := bh.SetHours(time.Saturday, 10*time.Hour, 12*time.Hour); err != nil {
+		t.Fatalf("SetHours() error = %v", err)
+	}
+	bh.ClearHours(time.Friday)
+
+	if !bh.IsOpen(time.Date(2023, 10, 7, 11, 0, 0, 0, time.UTC)) {
+		t.Error("IsOpen(saturday 11:00) = false, want true")
+	}
+	if bh.IsOpen(time.Date(2023, 10, 6, 11, 0, 0, 0, time.UTC)) {
+		t.Error("IsOpen(friday 11:00) = true, want false")
+	}
+	if err := bh.SetHours(time.Sunday, 17*time.Hour, 9*time.Hour); err == nil {
+		t.Error("SetHours() with close before open should fail")
+	}
+	if _, err := NewBusinessHours(9*time.Hour, 25*time.Hour, nil); err == nil {
+		t.Error("NewBusinessHours() with close beyond 24h should fail")
+	}
+
+	empty, _ := NewBusinessHours(9*time.Hour, 17*time.Hour, nil)
+	for day := time.Monday; day <= time.Friday; day++ {
+		empty.ClearHours(day)
+	}
+	if got := empty.NextOpen(time.Date(2023, 10, 3, 8, 0, 0, 0, time.UTC)); !got.IsZero() {
+		t.Errorf("NextOpen() without hours = %v, want zero time", got)
+	}
+}
+
+func TestBusinessSecondsBetween(t *testing.T) {
+	cal := NewBusinessCalendar(time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC))
+	bh, err := NewBusinessHours(9*time.Hour, 17*time.Hour, nil)
+	if err != nil {
+		t.Fatalf("NewBusinessHours() error = %v", err)
+	}
+	saturdayHours, _ := NewBusinessHours(9*time.Hour, 17*time.Hour, nil)
+	if err := saturdayHours.SetHours(time.Saturday, 10*time.Hour, 12*time.Hour); err != nil {
+		t.Fatalf("SetHours() error = %v", err)
+	}
+
+	const hour = int64(time.Hour / time.Second)
+	tests := []struct {
+		name  string
+		start time.Time
+		end   time.Time
+		hours *BusinessHours
+		cal   *BusinessCalendar
+		want  int64
+	}{{
+		name:  "within one window",
+		start: time.Date(2023, 10, 3, 10, 0, 0, 0, time.UTC),
+		end:   time.Date(2023, 10, 3, 11, 30, 15, 0, time.UTC),
+		hours: bh,
+		want:  hour + 30*60 + 15,
+	}, {
+		name:  "across a night",
+		start: time.Date(2023, 10, 3, 16, 0, 0, 0, time.UTC),
+		end:   time.Date(2023, 10, 4, 10, 0, 0, 0, time.UTC),
+		hours: bh,
+		want:  2 * hour,
+	}, {
+		name:  "across a weekend",
+		start: time.Date(2023, 10, 6, 15, 0, 0, 0, time.UTC),
+		end:   time.Date(2023, 10, 9, 11, 0, 0, 0, time.UTC),
+		hours: bh,
+		cal:   cal,
+		want:  4 * hour,
+	}, {
+		name:  "outside hours on both ends",
+		start: time.Date(2023, 10, 3, 6, 0, 0, 0, time.UTC),
+		end:   time.Date(2023, 10, 4, 20, 0, 0, 0, time.UTC),
+		hours: bh,
+		want:  16 * hour,
+	}, {
+		name:  "holiday skipped",
+		start: time.Date(2023, 9, 29, 9, 0, 0, 0, time.UTC),
+		end:   time.Date(2023, 10, 3, 9, 0, 0, 0, time.UTC),
+		hours: bh,
+		cal:   cal,
+		want:  8 * hour,
+	}, {
+		name:  "saturday hours counted without calendar",
+		start: time.Date(2023, 10, 6, 17, 0, 0, 0, time.UTC),
+		end:   time.Date(2023, 10, 8, 0, 0, 0, 0, time.UTC),
+		hours: saturdayHours,
+		want:  2 * hour,
+	}, {
+		name:  "saturday hours excluded by calendar",
+		start: time.Date(2023, 10, 6, 17, 0, 0, 0, time.UTC),
+		end:   time.Date(2023, 10, 8, 0, 0, 0, 0, time.UTC),
+		hours: saturdayHours,
+		cal:   cal,
+		want:  0,
+	}, {
+		name:  "end before start",
+		start: time.Date(2023, 10, 4, 10, 0, 0, 0, time.UTC),
+		end:   time.Date(2023, 10, 3, 10, 0, 0, 0, time.UTC),
+		hours: bh,
+		want:  0,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BusinessSecondsBetween(tt.start, tt.end, tt.hours, tt.cal); got != tt.want {
+				t.Errorf("BusinessSecondsBetween() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package dateutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxCronSearchYears Next向后搜索的最大年数，超过仍无匹配时返回零值
+// 闰年2月29日在最坏情况下8年才出现一次
+const maxCronSearchYears = 8
+
+// CronSchedule 解析后的5字段cron表达式（分 时 日 月 周）
+// 每个字段以位图表示允许的取值
+type CronSchedule struct {
+	minute     uint64 // 0-59
+	hour       uint64 // 0-23
+	dayOfMonth uint64 // 1-31
+	month      uint64 // 1-12
+	dayOfWeek  uint64 // 0-6，0表示周日
+	domAny     bool   // 日字段为*
+	dowAny     bool   // 周字段为*
+}
+
+// cronField cron字段的取值范围
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var (
+	cronMinute     = cronField{"minute", 0, 59}
+	cronHour       = cronField{"hour", 0, 23}
+	cronDayOfMonth = cronField{"day-of-month", 1, 31}
+	cronMonth      = cronField{"month", 1, 12}
+	cronDayOfWeek  = cronField{"day-of-week", 0, 7} // 0和7都表示周日
+)
+
+// ParseCron 解析标准的5字段cron表达式
+// 字段依次为: 分(0-59) 时(0-23) 日(1-31) 月(1-12) 周(0-7，0和7都表示周日)
+// 每个字段支持: *、单个值、范围(1-5)、列表(1,3,5)、步长(*/15、0-30/10、5/20)
+// 与标准cron一致，日和周字段都不为*时，满足任意一个即匹配
+// expr: cron表达式，如"0 9 * * 1-5"
+// 返回值: 解析后的调度和可能的错误
+func ParseCron(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+
+	s := &CronSchedule{
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}
+	targets := []*uint64{&s.minute, &s.hour, &s.dayOfMonth, &s.month, &s.dayOfWeek}
+	specs := []cronField{cronMinute, cronHour, cronDayOfMonth, cronMonth, cronDayOfWeek}
+	for i, field := range fields {
+		bits, err := parseCronField(field, specs[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		*targets[i] = bits
+	}
+
+	// 7同样表示周日
+	if s.dayOfWeek&(1<<7) != 0 {
+		s.dayOfWeek = s.dayOfWeek&^(1<<7) | 1
+	}
+	return s, nil
+}
+
+// parseCronField 将单个cron字段解析为位图
+func parseCronField(field string, spec cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%s: invalid step in %q", spec.name, part)
+			}
+			rangePart, step = part[:i], n
+		}
+
+		var lo, hi int
+		switch {
+		case rangePart == "*":
+			lo, hi = spec.min, spec.max
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err1, err2 error
+			lo, err1 = strconv.Atoi(bounds[0])
+			hi, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil {
+				return 0, fmt.Errorf("%s: invalid range %q", spec.name, part)
+			}
+		default:
+			n, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("%s: invalid value %q", spec.name, part)
+			}
+			lo, hi = n, n
+			// 形如"5/20"表示从5开始到最大值
+			if strings.Contains(part, "/") {
+				hi = spec.max
+			}
+		}
+
+		if lo < spec.min || hi > spec.max || lo > hi {
+			return 0, fmt.Errorf("%s: %q out of range %d-%d", spec.name, part, spec.min, spec.max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// Next 返回after之后（不含after本身）第一个满足调度的时刻，精确到分钟
+// 计算基于after所在的时区；在maxCronSearchYears年内找不到匹配时（如"0 0 30 2 *"）返回零值
+// after: 起始时间
+// 返回值: 下一次运行时间
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxCronSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchDay 判断t所在日期是否满足日和周字段
+func (s *CronSchedule) matchDay(t time.Time) bool {
+	domMatch := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dowMatch := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,119 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	tests := []struct {
+		name  string
+		expr  string
+		after time.Time
+		want  time.Time
+	}{{
+		name:  "weekdays from saturday",
+		expr:  "0 9 * * 1-5",
+		after: time.Date(2023, 10, 7, 10, 0, 0, 0, time.UTC),
+		want:  time.Date(2023, 10, 9, 9, 0, 0, 0, time.UTC),
+	}, {
+		name:  "every 15 minutes",
+		expr:  "*/15 * * * *",
+		after: time.Date(2023, 10, 5, 12, 34, 56, 0, time.UTC),
+		want:  time.Date(2023, 10, 5, 12, 45, 0, 0, time.UTC),
+	}, {
+		name:  "every 15 minutes rolls over hour",
+		expr:  "*/15 * * * *",
+		after: time.Date(2023, 10, 5, 12, 45, 0, 0, time.UTC),
+		want:  time.Date(2023, 10, 5, 13, 0, 0, 0, time.UTC),
+	}, {
+		name:  "list and range step",
+		expr:  "5,35 8-18/5 * * *",
+		after: time.Date(2023, 10, 5, 13, 40, 0, 0, time.UTC),
+		want:  time.Date(2023, 10, 5, 18, 5, 0, 0, time.UTC),
+	}, {
+		name:  "start with step",
+		expr:  "10/20 * * * *",
+		after: time.Date(2023, 10, 5, 12, 31, 0, 0, time.UTC),
+		want:  time.Date(2023, 10, 5, 12, 50, 0, 0, time.UTC),
+	}, {
+		name:  "month rollover into next year",
+		expr:  "0 0 1 1 *",
+		after: time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC),
+		want:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:  "sunday as seven",
+		expr:  "30 6 * * 7",
+		after: time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC),
+		want:  time.Date(2023, 10, 8, 6, 30, 0, 0, time.UTC),
+	}, {
+		name:  "day of month or day of week",
+		expr:  "0 0 13 * 5",
+		after: time.Date(2023, 10, 7, 0, 0, 0, 0, time.UTC),
+		want:  time.Date(2023, 10, 13, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:  "leap day",
+		expr:  "0 0 29 2 *",
+		after: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+		want:  time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:  "never matches",
+		expr:  "0 0 30 2 *",
+		after: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		want:  time.Time{},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) error = %v", tt.expr, err)
+			}
+			if got := s.Next(tt.after); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronScheduleNextSequence(t *testing.T) {
+	s, err := ParseCron("*/15 * * * *")
+	if err != nil {
+		t.Fatalf("ParseCron() error = %v", err)
+	}
+	next := time.Date(2023, 10, 5, 23, 50, 0, 0, time.UTC)
+	want := []time.Time{
+		time.Date(2023, 10, 6, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 10, 6, 0, 15, 0, 0, time.UTC),
+		time.Date(2023, 10, 6, 0, 30, 0, 0, time.UTC),
+	}
+	for i, w := range want {
+		next = s.Next(next)
+		if !next.Equal(w) {
+			t.Errorf("Next() #%d = %v, want %v", i, next, w)
+		}
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{name: "too few fields", expr: "* * * *"},
+		{name: "minute out of range", expr: "60 * * * *"},
+		{name: "zero day of month", expr: "0 0 0 * *"},
+		{name: "reversed range", expr: "0 5-1 * * *"},
+		{name: "zero step", expr: "*/0 * * * *"},
+		{name: "not a number", expr: "a * * * *"},
+		{name: "empty list item", expr: "1,,2 * * * *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCron(tt.expr); err == nil {
+				t.Errorf("ParseCron(%q) expected error", tt.expr)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package dateutil
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 	"time"
 )
 
@@ -19,6 +21,7 @@ const (
 	MonthUnit                   // 月
 	YearUnit                    // 年
 	QuarterUnit                 // 季度
+	Nanosecond                  // 纳秒
 )
 
 // DateRange 日期范围生成器
@@ -57,6 +60,38 @@ func FormatTime(t time.Time) string {
 	return t.Format("15:04:05")
 }
 
+// FormatTime12 将时间格式化为12小时制的 hh:mm:ss AM/PM 格式
+// 正午为"12:00:00 PM"，午夜为"12:00:00 AM"
+// t: 待格式化的时间
+// 返回值: 格式化后的时间字符串，如"03:30:45 PM"
+func FormatTime12(t time.Time) string {
+	return t.Format("03:04:05 PM")
+}
+
+// FormatDateTime12 将时间格式化为12小时制的 yyyy-MM-dd hh:mm:ss AM/PM 格式
+// t: 待格式化的时间
+// 返回值: 格式化后的字符串，如"2023-10-05 03:30:45 PM"
+func FormatDateTime12(t time.Time) string {
+	return t.Format("2006-01-02 03:04:05 PM")
+}
+
+// ParseTime12 解析12小时制的 hh:mm:ss AM/PM 格式的字符串，与FormatTime12互逆
+// AM/PM必须大写，小时必须为两位数字（01-12）
+// s: 待解析的字符串
+// 返回值: 解析后的时间（日期部分为UTC的0000-01-01）和可能的错误（空输入或格式错误）
+func ParseTime12(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, errors.New("empty input string")
+	}
+	return time.Parse("03:04:05 PM", s)
+}
+
+// ParseDateTime和ParseDate使用的预处理解析器
+var (
+	dateTimeParser = NewParser("2006-01-02 15:04:05")
+	dateParser     = NewParser("2006-01-02")
+)
+
 // ParseDateTime 解析 yyyy-MM-dd HH:mm:ss 格式的字符串为时间
 // s: 待解析的字符串
 // 返回值: 解析后的时间和可能的错误（空输入或格式错误）
@@ -64,7 +99,7 @@ func ParseDateTime(s string) (time.Time, error) {
 	if s == "" {
 		return time.Time{}, errors.New("empty input string")
 	}
-	return time.Parse("2006-01-02 15:04:05", s)
+	return dateTimeParser.Parse(s)
 }
 
 // ParseDate 解析 yyyy-MM-dd 格式的字符串为时间
@@ -74,7 +109,27 @@ func ParseDate(s string) (time.Time, error) {
 	if s == "" {
 		return time.Time{}, errors.New("empty input string")
 	}
-	return time.Parse("2006-01-02", s)
+	return dateParser.Parse(s)
+}
+
+// ParseDateTimeLenient 解析 yyyy-MM-dd HH:mm:ss 格式的字符串，并容忍闰秒
+// 部分数据源在闰秒时会输出"23:59:60"，ParseDateTime会拒绝这种输入；
+// 本函数将秒字段为60的时间规范化为下一分钟的第0秒，如"2016-12-31 23:59:60"解析为2017-01-01 00:00:00
+// Go的time.Time不能表示闰秒，因此闰秒与其后的一秒解析结果相同；不检查该时刻是否真的发生过闰秒
+// 其他输入的解析结果与ParseDateTime完全一致
+// s: 待解析的字符串
+// 返回值: 解析后的时间和可能的错误（空输入或格式错误）
+func ParseDateTimeLenient(s string) (time.Time, error) {
+	t, err := ParseDateTime(s)
+	if err == nil || len(s) != len("2006-01-02 15:04:05") || s[16:] != ":60" {
+		return t, err
+	}
+	// 按59秒解析以校验其余字段，再加1秒得到下一分钟的第0秒
+	t, leapErr := ParseDateTime(s[:17] + "59")
+	if leapErr != nil {
+		return time.Time{}, err
+	}
+	return t.Add(time.Second), nil
 }
 
 // Year 获取时间的年份
@@ -127,6 +182,28 @@ func IsWeekend(t time.Time) bool {
 	return weekday == time.Saturday || weekday == time.Sunday
 }
 
+// 常用地区的周末定义，用于IsWeekendInRegion
+var (
+	// WeekendWestern 周六和周日，大多数国家和地区采用，与IsWeekend一致
+	WeekendWestern = []time.Weekday{time.Saturday, time.Sunday}
+	// WeekendMiddleEast 周五和周六，沙特阿拉伯、埃及、以色列等中东和北非地区采用
+	WeekendMiddleEast = []time.Weekday{time.Friday, time.Saturday}
+)
+
+// IsWeekendInRegion 按指定的周末定义判断时间是否为周末
+// t: 时间
+// weekendDays: 周末包含的星期，如WeekendWestern、WeekendMiddleEast
+// 返回值: 如果t的星期在weekendDays中则为true，否则为false
+func IsWeekendInRegion(t time.Time, weekendDays []time.Weekday) bool {
+	weekday := t.Weekday()
+	for _, d := range weekendDays {
+		if d == weekday {
+			return true
+		}
+	}
+	return false
+}
+
 // AddYears 为时间添加指定年数
 // t: 原始时间
 // years: 要添加的年数（可为负数）
@@ -151,6 +228,27 @@ func AddDays(t time.Time, days int) time.Time {
 	return t.AddDate(0, 0, days)
 }
 
+// AddDaysWallClock 为时间添加指定天数，并保持本地挂钟时间（时、分、秒）不变
+// 适用于夏令时时区中"明天同一时刻"的语义：结果通过 time.Date 在 t 的时区中重建。
+// 如果目标日期的挂钟时间因夏令时开始（拨快）而不存在，则按跳过的间隔顺延，
+// 例如 02:30 在拨快当天会得到 03:30。
+// t: 原始时间
+// days: 要添加的天数（可为负数）
+// 返回值: 添加后的时间
+func AddDaysWallClock(t time.Time, days int) time.Time {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	result := time.Date(year, month, day+days, hour, min, sec, t.Nanosecond(), t.Location())
+
+	// 挂钟时间不存在时 time.Date 的结果不确定，这里统一按间隔向后顺延
+	want := time.Date(year, month, day+days, hour, min, sec, t.Nanosecond(), time.UTC)
+	got := time.Date(result.Year(), result.Month(), result.Day(), result.Hour(), result.Minute(), result.Second(), result.Nanosecond(), time.UTC)
+	if gap := want.Sub(got); gap > 0 {
+		result = result.Add(gap)
+	}
+	return result
+}
+
 // AddHours 为时间添加指定小时数
 // t: 原始时间
 // hours: 要添加的小时数（可为负数）
@@ -384,6 +482,89 @@ func EndOfYear(t time.Time) time.Time {
 	return EndOfMonth(time.Date(t.Year(), 12, 1, 0, 0, 0, 0, t.Location()))
 }
 
+// RoundTo 将时间四舍五入到最近的单位边界，恰好位于中点时向上取整
+// 例如按天取整时，12:00及之后取整为次日零点，之前取整为当天零点
+// 周以周一为第一天；不支持的单位原样返回t
+// t: 时间
+// unit: 取整单位
+// 返回值: 取整后的时间
+func RoundTo(t time.Time, unit TimeUnit) time.Time {
+	begin, next, ok := unitBounds(t, unit)
+	if !ok {
+		return t
+	}
+	if t.Sub(begin) >= next.Sub(t) {
+		return next
+	}
+	return begin
+}
+
+// RoundToHour 将时间四舍五入到最近的整点，30分及之后向上取整
+// t: 时间
+// 返回值: 取整后的时间
+func RoundToHour(t time.Time) time.Time {
+	return RoundTo(t, HourUnit)
+}
+
+// RoundToDay 将时间四舍五入到最近的零点，12:00及之后取整为次日零点
+// t: 时间
+// 返回值: 取整后的时间
+func RoundToDay(t time.Time) time.Time {
+	return RoundTo(t, DayUnit)
+}
+
+// NextBoundary 返回t之后的下一个按日历对齐的时刻，如下一个整点、次日零点、下月1日零点
+// 与time.Ticker不同，结果按挂钟时间对齐，可用于"每小时整点执行"之类的任务：
+//
+//	time.Sleep(time.Until(NextBoundary(time.Now(), HourUnit)))
+//
+// t恰好位于边界上时返回下一个边界；周以周一为第一天
+// t: 时间
+// unit: 对齐单位，不支持的单位（如Nanosecond）原样返回t
+// 返回值: 下一个对齐时刻，时区与t相同
+func NextBoundary(t time.Time, unit TimeUnit) time.Time {
+	_, next, ok := unitBounds(t, unit)
+	if !ok {
+		return t
+	}
+	return next
+}
+
+// unitBounds 返回t所在单位的起始时间和下一个单位的起始时间
+func unitBounds(t time.Time, unit TimeUnit) (begin, next time.Time, ok bool) {
+	switch unit {
+	case Millisecond:
+		begin = t.Truncate(time.Millisecond)
+		return begin, begin.Add(time.Millisecond), true
+	case SecondUnit:
+		begin = BeginOfSecond(t)
+		return begin, begin.Add(time.Second), true
+	case MinuteUnit:
+		begin = BeginOfMinute(t)
+		return begin, begin.Add(time.Minute), true
+	case HourUnit:
+		begin = BeginOfHour(t)
+		return begin, begin.Add(time.Hour), true
+	case DayUnit:
+		begin = BeginOfDay(t)
+		return begin, BeginOfDay(begin.AddDate(0, 0, 1)), true
+	case WeekUnit:
+		begin = BeginOfWeek(t)
+		return begin, BeginOfDay(begin.AddDate(0, 0, 7)), true
+	case MonthUnit:
+		begin = BeginOfMonth(t)
+		return begin, begin.AddDate(0, 1, 0), true
+	case QuarterUnit:
+		begin = BeginOfQuarter(t)
+		return begin, begin.AddDate(0, 3, 0), true
+	case YearUnit:
+		begin = BeginOfYear(t)
+		return begin, begin.AddDate(1, 0, 0), true
+	default:
+		return time.Time{}, time.Time{}, false
+	}
+}
+
 // Yesterday 返回昨天的开始时间
 // 返回值: 昨天00:00:00
 func Yesterday() time.Time {
@@ -489,6 +670,8 @@ func Between(begin, end time.Time, unit TimeUnit, isAbs bool) int64 {
 	var diff int64
 
 	switch unit {
+	case Nanosecond:
+		diff = end.Sub(begin).Nanoseconds()
 	case Millisecond:
 		diff = end.Sub(begin).Milliseconds()
 	case SecondUnit:
@@ -515,6 +698,19 @@ func Between(begin, end time.Time, unit TimeUnit, isAbs bool) int64 {
 	return diff
 }
 
+// BetweenDuration 计算两个日期之间的精确时长，不做任何单位取整
+// begin: 起始日期
+// end: 结束日期
+// isAbs: 是否取绝对值，为false时begin晚于end返回负值
+// 返回值: end与begin的时间差
+func BetweenDuration(begin, end time.Time, isAbs bool) time.Duration {
+	diff := end.Sub(begin)
+	if isAbs && diff < 0 {
+		return -diff
+	}
+	return diff
+}
+
 // BetweenMs 计算两个日期相差的毫秒数
 func BetweenMs(begin, end time.Time) int64 {
 	return Between(begin, end, Millisecond, true)
@@ -605,6 +801,34 @@ func IsSameTime(a, b time.Time) bool {
 	return a.UnixNano() == b.UnixNano()
 }
 
+// EqualWithin 判断两个时间之差的绝对值是否不超过tolerance
+// 用于比较从数据库读回的被截断到毫秒或微秒的时间，与IsSameTime的纳秒精确比较不同
+// a, b: 待比较的时间
+// tolerance: 允许的误差，为负数时始终返回false
+// 返回值: |a - b| <= tolerance时返回true
+func EqualWithin(a, b time.Time, tolerance time.Duration) bool {
+	if tolerance < 0 {
+		return false
+	}
+	d := a.Sub(b)
+	return d >= -tolerance && d <= tolerance
+}
+
+// TruncateCompare 判断两个时间向下取整到unit后是否相同，即是否落在同一个单位内
+// 如SecondUnit下12:00:00.100与12:00:00.900相同，而12:00:00.900与12:00:01.000不同
+// 天及以上的单位按各自的时区取整，比较不同时区的时间前应先转换到同一时区；周以周一为第一天
+// a, b: 待比较的时间
+// unit: 取整单位，Nanosecond或不支持的单位按纳秒精确比较
+// 返回值: 取整后相同时返回true
+func TruncateCompare(a, b time.Time, unit TimeUnit) bool {
+	beginA, _, okA := unitBounds(a, unit)
+	beginB, _, okB := unitBounds(b, unit)
+	if !okA || !okB {
+		return a.Equal(b)
+	}
+	return beginA.Equal(beginB)
+}
+
 // IsSameDay 判断两个日期是否为同一天
 func IsSameDay(a, b time.Time) bool {
 	return BeginOfDay(a).Equal(BeginOfDay(b))
@@ -630,7 +854,7 @@ func IsLeapYear(year int) bool {
 
 // AgeOfNow 根据生日计算当前年龄
 func AgeOfNow(birthDay time.Time) int {
-	return age(birthDay, time.Now())
+	return AgeAt(birthDay, time.Now())
 }
 
 // AgeOfNowString 解析生日字符串并计算当前年龄
@@ -643,25 +867,48 @@ func AgeOfNowString(birthDayStr string) (int, error) {
 	return AgeOfNow(birthDay), nil
 }
 
-// age 计算两个日期之间的年龄差
-func age(birthDay, today time.Time) int {
-	if today.Before(birthDay) {
+// AgeAt 计算在指定日期时的年龄（周岁）
+// 2月29日出生的人在平年的3月1日增长一岁
+// birthDay: 生日
+// at: 计算年龄的日期，早于生日时返回0
+// 返回值: 年龄
+func AgeAt(birthDay, at time.Time) int {
+	if at.Before(birthDay) {
 		return 0
 	}
 
-	yearDiff := today.Year() - birthDay.Year()
-	birthMonth := birthDay.Month()
-	birthDayOfMonth := birthDay.Day()
-	todayMonth := today.Month()
-	todayDayOfMonth := today.Day()
-
-	if todayMonth < birthMonth || (todayMonth == birthMonth && todayDayOfMonth < birthDayOfMonth) {
+	yearDiff := at.Year() - birthDay.Year()
+	if at.Month() < birthDay.Month() || (at.Month() == birthDay.Month() && at.Day() < birthDay.Day()) {
 		yearDiff--
 	}
 
 	return yearDiff
 }
 
+// DaysUntilBirthday 计算从今天到下一个生日还有多少天，今天就是生日时返回0
+// 2月29日出生的人在平年按3月1日计算，与AgeAt增长年龄的日期一致
+// birthDay: 生日
+// 返回值: 距离下一个生日的天数
+func DaysUntilBirthday(birthDay time.Time) int {
+	return daysUntilBirthday(birthDay, Now())
+}
+
+// daysUntilBirthday 计算从today到下一个生日的天数，按日历日计算，不受夏令时影响
+func daysUntilBirthday(birthDay, today time.Time) int {
+	from := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	next := birthdayIn(birthDay, today.Year())
+	if next.Before(from) {
+		next = birthdayIn(birthDay, today.Year()+1)
+	}
+	return int(next.Sub(from).Hours() / 24)
+}
+
+// birthdayIn 返回生日在指定年份的日期（UTC零点），平年的2月29日顺延为3月1日
+func birthdayIn(birthDay time.Time, year int) time.Time {
+	// time.Date会将平年的2月29日规范化为3月1日
+	return time.Date(year, birthDay.Month(), birthDay.Day(), 0, 0, 0, 0, time.UTC)
+}
+
 // GetChineseZodiac 计算生肖（仅支持1900年及以后）
 // year: 农历年份
 func GetChineseZodiac(year int) string {
@@ -677,6 +924,57 @@ func GetChineseZodiac(year int) string {
 	return zodiacs[index]
 }
 
+// zodiacSignNames 各语言的星座名称，下标i对应从第i+1个月开始的星座（一月开始的是水瓶座）
+var zodiacSignNames = map[string][12]string{
+	LangEnglish: {"Aquarius", "Pisces", "Aries", "Taurus", "Gemini", "Cancer",
+		"Leo", "Virgo", "Libra", "Scorpio", "Sagittarius", "Capricorn"},
+	LangChinese: {"水瓶座", "双鱼座", "白羊座", "金牛座", "双子座", "巨蟹座",
+		"狮子座", "处女座", "天秤座", "天蝎座", "射手座", "摩羯座"},
+}
+
+// zodiacSignStartDays 每个月中新星座开始的日期，如3月21日起为白羊座
+var zodiacSignStartDays = [12]int{20, 19, 21, 20, 21, 21, 23, 23, 23, 23, 22, 22}
+
+// GetZodiacSign 计算日期对应的西方星座（太阳星座）
+// 边界日期: 水瓶座1/20-2/18 双鱼座2/19-3/20 白羊座3/21-4/19 金牛座4/20-5/20 双子座5/21-6/20 巨蟹座6/21-7/22
+// 狮子座7/23-8/22 处女座8/23-9/22 天秤座9/23-10/22 天蝎座10/23-11/21 射手座11/22-12/21 摩羯座12/22-1/19
+// t: 日期，只使用月和日
+// lang: 语言，LangEnglish或LangChinese
+// 返回值: 星座名称，如"Aries"、"白羊座"；语言不支持时返回空字符串
+func GetZodiacSign(t time.Time, lang string) string {
+	names, ok := zodiacSignNames[lang]
+	if !ok {
+		return ""
+	}
+	index := int(t.Month()) - 1
+	if t.Day() < zodiacSignStartDays[index] {
+		// 尚未到本月的新星座，属于上个月开始的星座（一月上旬为摩羯座）
+		index = (index + 11) % 12
+	}
+	return names[index]
+}
+
+// Season 按气象学划分返回日期所属的季节
+// 北半球: 3-5月为春季(spring)，6-8月为夏季(summer)，9-11月为秋季(autumn)，12-2月为冬季(winter)
+// 南半球在此基础上偏移6个月
+// t: 时间
+// hemisphere: 半球，"north"或"south"
+// 返回值: "spring"/"summer"/"autumn"/"winter"，半球参数无效时返回空字符串
+func Season(t time.Time, hemisphere string) string {
+	seasons := []string{"winter", "spring", "summer", "autumn"}
+	// 将月份映射为季节索引: 12、1、2月为0，3-5月为1，以此类推
+	index := int(t.Month()) % 12 / 3
+
+	switch hemisphere {
+	case "north":
+		return seasons[index]
+	case "south":
+		return seasons[(index+2)%4]
+	default:
+		return ""
+	}
+}
+
 // LengthOfYear 获取指定年份的总天数
 func LengthOfYear(year int) int {
 	if IsLeapYear(year) {
@@ -724,6 +1022,60 @@ func GetLastDayOfMonth(date time.Time) int {
 	return LengthOfMonth(int(date.Month()), isLeap)
 }
 
+// NthWeekdayOfMonth 计算某月第n个星期几的日期，如"1月的第3个星期一"
+// year: 年份
+// month: 月份
+// weekday: 星期几
+// n: 第几个，1表示第一个；负数表示倒数，-1表示最后一个
+// 返回值: 对应日期的零点（UTC）和可能的错误（n为0或超出该月实际的个数）
+func NthWeekdayOfMonth(year int, month time.Month, weekday time.Weekday, n int) (time.Time, error) {
+	if n == 0 {
+		return time.Time{}, errors.New("n must not be zero")
+	}
+
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+
+	// 该月第一个符合条件的日期
+	firstDay := 1 + (int(weekday)-int(first.Weekday())+7)%7
+	count := (lastDay-firstDay)/7 + 1
+
+	if n < 0 {
+		n = count + n + 1
+	}
+	if n < 1 || n > count {
+		return time.Time{}, fmt.Errorf("%d-%02d has only %d %s(s)", year, int(month), count, weekday)
+	}
+	return time.Date(year, month, firstDay+(n-1)*7, 0, 0, 0, 0, time.UTC), nil
+}
+
+// WeekdaysInRange 返回[start, end]内所有落在指定星期几的日期，如某月所有的周一和周三
+// 日期按start的时区确定，end会先转换到该时区；结果按时间先后排列，首尾两天均包含在内
+// start: 起始时间
+// end: 结束时间
+// days: 需要的星期几，可以有多个，重复的会被忽略
+// 返回值: 匹配日期的零点（BeginOfDay），没有匹配或end早于start时返回空切片
+func WeekdaysInRange(start, end time.Time, days ...time.Weekday) []time.Time {
+	var wanted [7]bool
+	result := []time.Time{}
+	for _, d := range days {
+		if d >= time.Sunday && d <= time.Saturday {
+			wanted[d] = true
+		}
+	}
+	if wanted == [7]bool{} {
+		return result
+	}
+
+	last := BeginOfDay(end.In(start.Location()))
+	for day := BeginOfDay(start); !day.After(last); day = BeginOfDay(day.AddDate(0, 0, 1)) {
+		if wanted[day.Weekday()] {
+			result = append(result, day)
+		}
+	}
+	return result
+}
+
 // Range 创建日期范围生成器
 // start: 起始日期时间（包括）
 // end: 结束日期时间（包括）
@@ -842,3 +1194,51 @@ func RangeNotContains(start, end *DateRange) []time.Time {
 
 	return difference
 }
+
+// Min 返回最早的时间
+// times: 时间列表
+// 返回值: 最早的时间，未传入任何时间时返回零值时间
+func Min(times ...time.Time) time.Time {
+	if len(times) == 0 {
+		return time.Time{}
+	}
+	earliest := times[0]
+	for _, t := range times[1:] {
+		if t.Before(earliest) {
+			earliest = t
+		}
+	}
+	return earliest
+}
+
+// Max 返回最晚的时间
+// times: 时间列表
+// 返回值: 最晚的时间，未传入任何时间时返回零值时间
+func Max(times ...time.Time) time.Time {
+	if len(times) == 0 {
+		return time.Time{}
+	}
+	latest := times[0]
+	for _, t := range times[1:] {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// Sort 将时间列表按从早到晚原地排序
+// times: 待排序的时间列表
+func Sort(times []time.Time) {
+	sort.SliceStable(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+}
+
+// SortDesc 将时间列表按从晚到早原地排序
+// times: 待排序的时间列表
+func SortDesc(times []time.Time) {
+	sort.SliceStable(times, func(i, j int) bool {
+		return times[i].After(times[j])
+	})
+}
@@ -72,6 +72,96 @@ func TestFormatTime(t *testing.T) {
 	}
 }
 
+func TestFormatTime12(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{{
+		name: "afternoon",
+		t:    time.Date(2023, 10, 5, 15, 30, 45, 0, time.UTC),
+		want: "03:30:45 PM",
+	}, {
+		name: "morning",
+		t:    time.Date(2023, 10, 5, 9, 5, 7, 0, time.UTC),
+		want: "09:05:07 AM",
+	}, {
+		name: "noon",
+		t:    time.Date(2023, 10, 5, 12, 0, 0, 0, time.UTC),
+		want: "12:00:00 PM",
+	}, {
+		name: "midnight",
+		t:    time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC),
+		want: "12:00:00 AM",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatTime12(tt.t); got != tt.want {
+				t.Errorf("FormatTime12() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDateTime12(t *testing.T) {
+	got := FormatDateTime12(time.Date(2023, 10, 5, 23, 59, 1, 0, time.UTC))
+	if want := "2023-10-05 11:59:01 PM"; got != want {
+		t.Errorf("FormatDateTime12() = %v, want %v", got, want)
+	}
+}
+
+func TestParseTime12(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		wantHour int
+		wantErr  bool
+	}{{
+		name:     "afternoon",
+		s:        "03:30:45 PM",
+		wantHour: 15,
+	}, {
+		name:     "noon",
+		s:        "12:00:00 PM",
+		wantHour: 12,
+	}, {
+		name:     "midnight",
+		s:        "12:00:00 AM",
+		wantHour: 0,
+	}, {
+		name:    "empty string",
+		s:       "",
+		wantErr: true,
+	}, {
+		name:    "24-hour input",
+		s:       "15:30:45",
+		wantErr: true,
+	}, {
+		name:    "hour out of range",
+		s:       "13:00:00 PM",
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTime12(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTime12() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Hour() != tt.wantHour {
+				t.Errorf("ParseTime12() hour = %v, want %v", got.Hour(), tt.wantHour)
+			}
+			if back := FormatTime12(got); back != tt.s {
+				t.Errorf("FormatTime12(ParseTime12(%q)) = %q", tt.s, back)
+			}
+		})
+	}
+}
+
 func TestParseDateTime(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -109,6 +199,60 @@ func TestParseDateTime(t *testing.T) {
 	}
 }
 
+func TestParseDateTimeLenient(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    time.Time
+		wantErr bool
+	}{{
+		name: "leap second at year end",
+		s:    "2016-12-31 23:59:60",
+		want: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "leap second mid year",
+		s:    "2015-06-30 23:59:60",
+		want: time.Date(2015, 7, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "second 60 in any minute",
+		s:    "2023-10-05 15:30:60",
+		want: time.Date(2023, 10, 5, 15, 31, 0, 0, time.UTC),
+	}, {
+		name:    "second 61",
+		s:       "2016-12-31 23:59:61",
+		wantErr: true,
+	}, {
+		name:    "leap second with invalid minute",
+		s:       "2016-12-31 23:60:60",
+		wantErr: true,
+	}, {
+		name:    "leap second with invalid date",
+		s:       "2016-13-31 23:59:60",
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDateTimeLenient(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDateTimeLenient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDateTimeLenient() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// 普通输入的结果与ParseDateTime一致
+	for _, s := range []string{"2023-10-05 15:30:45", "2016-12-31 23:59:59", "", "2023/10/05 15:30:45", "2023-10-05 15:30"} {
+		want, wantErr := ParseDateTime(s)
+		got, err := ParseDateTimeLenient(s)
+		if !got.Equal(want) || (err != nil) != (wantErr != nil) {
+			t.Errorf("ParseDateTimeLenient(%q) = %v, %v; ParseDateTime = %v, %v", s, got, err, want, wantErr)
+		}
+	}
+}
+
 func TestParseDate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -560,6 +704,35 @@ func TestIsWeekend(t *testing.T) {
 	}
 }
 
+func TestIsWeekendInRegion(t *testing.T) {
+	friday := time.Date(2023, 10, 6, 0, 0, 0, 0, time.UTC)
+	saturday := time.Date(2023, 10, 7, 0, 0, 0, 0, time.UTC)
+	sunday := time.Date(2023, 10, 8, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		t           time.Time
+		weekendDays []time.Weekday
+		want        bool
+	}{
+		{"friday western", friday, WeekendWestern, false},
+		{"friday middle east", friday, WeekendMiddleEast, true},
+		{"saturday middle east", saturday, WeekendMiddleEast, true},
+		{"sunday western", sunday, WeekendWestern, true},
+		{"sunday middle east", sunday, WeekendMiddleEast, false},
+		{"custom single day", sunday, []time.Weekday{time.Sunday}, true},
+		{"no weekend", saturday, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsWeekendInRegion(tt.t, tt.weekendDays); got != tt.want {
+				t.Errorf("IsWeekendInRegion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestAddYears(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -641,6 +814,56 @@ func TestAddDays(t *testing.T) {
 	}
 }
 
+func TestAddDaysWallClock(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		t    time.Time
+		days int
+		want time.Time
+	}{{
+		name: "across spring forward keeps wall clock",
+		t:    time.Date(2024, 3, 9, 9, 0, 0, 0, loc),
+		days: 1,
+		want: time.Date(2024, 3, 10, 9, 0, 0, 0, loc),
+	}, {
+		name: "backwards across spring forward",
+		t:    time.Date(2024, 3, 11, 9, 0, 0, 0, loc),
+		days: -2,
+		want: time.Date(2024, 3, 9, 9, 0, 0, 0, loc),
+	}, {
+		name: "nonexistent wall clock rolls forward",
+		t:    time.Date(2024, 3, 9, 2, 30, 0, 0, loc),
+		days: 1,
+		want: time.Date(2024, 3, 10, 7, 30, 0, 0, time.UTC), // 03:30 EDT
+	}, {
+		name: "utc behaves like AddDays",
+		t:    time.Date(2023, 10, 5, 15, 30, 45, 0, time.UTC),
+		days: 3,
+		want: time.Date(2023, 10, 8, 15, 30, 45, 0, time.UTC),
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AddDaysWallClock(tt.t, tt.days); !got.Equal(tt.want) {
+				t.Errorf("AddDaysWallClock() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	start := time.Date(2024, 3, 9, 9, 0, 0, 0, loc)
+	if got := start.Add(24 * time.Hour).Hour(); got != 10 {
+		t.Errorf("Add(24h).Hour() = %d, want 10", got)
+	}
+	if got := AddDaysWallClock(start, 1).Hour(); got != 9 {
+		t.Errorf("AddDaysWallClock().Hour() = %d, want 9", got)
+	}
+}
+
 func TestAddHours(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -1186,6 +1409,132 @@ func TestEndOfYear(t *testing.T) {
 	}
 }
 
+func TestRoundTo(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		unit TimeUnit
+		want time.Time
+	}{{
+		name: "hour rounds down",
+		t:    time.Date(2023, 10, 5, 11, 29, 0, 0, time.UTC),
+		unit: HourUnit,
+		want: time.Date(2023, 10, 5, 11, 0, 0, 0, time.UTC),
+	}, {
+		name: "hour half rounds up",
+		t:    time.Date(2023, 10, 5, 11, 30, 0, 0, time.UTC),
+		unit: HourUnit,
+		want: time.Date(2023, 10, 5, 12, 0, 0, 0, time.UTC),
+	}, {
+		name: "day noon rounds up",
+		t:    time.Date(2023, 10, 5, 12, 0, 0, 0, time.UTC),
+		unit: DayUnit,
+		want: time.Date(2023, 10, 6, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "day morning rounds down",
+		t:    time.Date(2023, 10, 5, 11, 59, 59, 0, time.UTC),
+		unit: DayUnit,
+		want: time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "minute",
+		t:    time.Date(2023, 10, 5, 11, 29, 45, 0, time.UTC),
+		unit: MinuteUnit,
+		want: time.Date(2023, 10, 5, 11, 30, 0, 0, time.UTC),
+	}, {
+		name: "month late rounds up",
+		t:    time.Date(2023, 2, 20, 0, 0, 0, 0, time.UTC),
+		unit: MonthUnit,
+		want: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "year early rounds down",
+		t:    time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+		unit: YearUnit,
+		want: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "unsupported unit",
+		t:    time.Date(2023, 10, 5, 11, 29, 0, 0, time.UTC),
+		unit: TimeUnit(-1),
+		want: time.Date(2023, 10, 5, 11, 29, 0, 0, time.UTC),
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RoundTo(tt.t, tt.unit); !got.Equal(tt.want) {
+				t.Errorf("RoundTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundToHour(t *testing.T) {
+	got := RoundToHour(time.Date(2023, 10, 5, 23, 45, 0, 0, time.UTC))
+	want := time.Date(2023, 10, 6, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("RoundToHour() = %v, want %v", got, want)
+	}
+}
+
+func TestRoundToDay(t *testing.T) {
+	got := RoundToDay(time.Date(2023, 12, 31, 18, 0, 0, 0, time.UTC))
+	want := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("RoundToDay() = %v, want %v", got, want)
+	}
+}
+
+func TestNextBoundary(t *testing.T) {
+	base := time.Date(2023, 10, 5, 12, 34, 56, 0, time.UTC)
+	tests := []struct {
+		name string
+		t    time.Time
+		unit TimeUnit
+		want time.Time
+	}{{
+		name: "next hour",
+		t:    base,
+		unit: HourUnit,
+		want: time.Date(2023, 10, 5, 13, 0, 0, 0, time.UTC),
+	}, {
+		name: "next day",
+		t:    base,
+		unit: DayUnit,
+		want: time.Date(2023, 10, 6, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "next minute",
+		t:    base,
+		unit: MinuteUnit,
+		want: time.Date(2023, 10, 5, 12, 35, 0, 0, time.UTC),
+	}, {
+		name: "next week starts monday",
+		t:    base,
+		unit: WeekUnit,
+		want: time.Date(2023, 10, 9, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "next month",
+		t:    base,
+		unit: MonthUnit,
+		want: time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "on boundary moves forward",
+		t:    time.Date(2023, 10, 5, 13, 0, 0, 0, time.UTC),
+		unit: HourUnit,
+		want: time.Date(2023, 10, 5, 14, 0, 0, 0, time.UTC),
+	}, {
+		name: "unsupported unit",
+		t:    base,
+		unit: TimeUnit(-1),
+		want: base,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextBoundary(tt.t, tt.unit); !got.Equal(tt.want) {
+				t.Errorf("NextBoundary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestYesterday(t *testing.T) {
 	today := time.Now()
 	yesterday := BeginOfDay(today.AddDate(0, 0, -1))
@@ -1440,6 +1789,13 @@ func TestBetween(t *testing.T) {
 		unit:  Millisecond,
 		isAbs: true,
 		want:  500,
+	}, {
+		name:  "nanoseconds",
+		begin: time.Date(2023, 10, 5, 15, 30, 45, 0, time.UTC),
+		end:   time.Date(2023, 10, 5, 15, 30, 45, 250, time.UTC),
+		unit:  Nanosecond,
+		isAbs: true,
+		want:  250,
 	}, {
 		name:  "seconds",
 		begin: time.Date(2023, 10, 5, 15, 30, 45, 0, time.UTC),
@@ -1515,6 +1871,42 @@ func TestBetweenMs(t *testing.T) {
 	}
 }
 
+func TestBetweenDuration(t *testing.T) {
+	tests := []struct {
+		name  string
+		begin time.Time
+		end   time.Time
+		isAbs bool
+		want  time.Duration
+	}{{
+		name:  "sub-millisecond gap",
+		begin: time.Date(2023, 10, 5, 15, 30, 45, 0, time.UTC),
+		end:   time.Date(2023, 10, 5, 15, 30, 45, 1500, time.UTC),
+		isAbs: true,
+		want:  1500 * time.Nanosecond,
+	}, {
+		name:  "negative without abs",
+		begin: time.Date(2023, 10, 5, 15, 30, 46, 0, time.UTC),
+		end:   time.Date(2023, 10, 5, 15, 30, 45, 0, time.UTC),
+		isAbs: false,
+		want:  -time.Second,
+	}, {
+		name:  "negative with abs",
+		begin: time.Date(2023, 10, 5, 15, 30, 46, 0, time.UTC),
+		end:   time.Date(2023, 10, 5, 15, 30, 45, 0, time.UTC),
+		isAbs: true,
+		want:  time.Second,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BetweenDuration(tt.begin, tt.end, tt.isAbs); got != tt.want {
+				t.Errorf("BetweenDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestBetweenDay(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -1674,6 +2066,132 @@ func TestIsSameTime(t *testing.T) {
 	}
 }
 
+func TestEqualWithin(t *testing.T) {
+	base := time.Date(2023, 10, 5, 15, 30, 45, 123000000, time.UTC)
+	tests := []struct {
+		name      string
+		a         time.Time
+		b         time.Time
+		tolerance time.Duration
+		want      bool
+	}{{
+		name:      "500us apart within 1ms",
+		a:         base,
+		b:         base.Add(500 * time.Microsecond),
+		tolerance: time.Millisecond,
+		want:      true,
+	}, {
+		name:      "order does not matter",
+		a:         base.Add(500 * time.Microsecond),
+		b:         base,
+		tolerance: time.Millisecond,
+		want:      true,
+	}, {
+		name:      "exactly at tolerance",
+		a:         base,
+		b:         base.Add(time.Millisecond),
+		tolerance: time.Millisecond,
+		want:      true,
+	}, {
+		name:      "beyond tolerance",
+		a:         base,
+		b:         base.Add(time.Millisecond + 1),
+		tolerance: time.Millisecond,
+		want:      false,
+	}, {
+		name:      "zero tolerance equal",
+		a:         base,
+		b:         base.In(time.FixedZone("UTC+8", 8*3600)),
+		tolerance: 0,
+		want:      true,
+	}, {
+		name:      "negative tolerance",
+		a:         base,
+		b:         base,
+		tolerance: -time.Second,
+		want:      false,
+	}, {
+		name:      "far apart",
+		a:         time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+		b:         time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC),
+		tolerance: time.Hour,
+		want:      false,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EqualWithin(tt.a, tt.b, tt.tolerance); got != tt.want {
+				t.Errorf("EqualWithin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	a, b := base, base.Add(500*time.Microsecond)
+	if IsSameTime(a, b) {
+		t.Errorf("IsSameTime(%v, %v) = true, want false", a, b)
+	}
+}
+
+func TestTruncateCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		a    time.Time
+		b    time.Time
+		unit TimeUnit
+		want bool
+	}{{
+		name: "same second",
+		a:    time.Date(2023, 10, 5, 15, 30, 45, 100000000, time.UTC),
+		b:    time.Date(2023, 10, 5, 15, 30, 45, 900000000, time.UTC),
+		unit: SecondUnit,
+		want: true,
+	}, {
+		name: "adjacent seconds",
+		a:    time.Date(2023, 10, 5, 15, 30, 45, 900000000, time.UTC),
+		b:    time.Date(2023, 10, 5, 15, 30, 46, 0, time.UTC),
+		unit: SecondUnit,
+		want: false,
+	}, {
+		name: "same millisecond",
+		a:    time.Date(2023, 10, 5, 15, 30, 45, 123100000, time.UTC),
+		b:    time.Date(2023, 10, 5, 15, 30, 45, 123900000, time.UTC),
+		unit: Millisecond,
+		want: true,
+	}, {
+		name: "same day",
+		a:    time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC),
+		b:    time.Date(2023, 10, 5, 23, 59, 59, 0, time.UTC),
+		unit: DayUnit,
+		want: true,
+	}, {
+		name: "same week monday first",
+		a:    time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC),
+		b:    time.Date(2023, 10, 8, 12, 0, 0, 0, time.UTC),
+		unit: WeekUnit,
+		want: true,
+	}, {
+		name: "different month",
+		a:    time.Date(2023, 10, 31, 0, 0, 0, 0, time.UTC),
+		b:    time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC),
+		unit: MonthUnit,
+		want: false,
+	}, {
+		name: "nanosecond exact",
+		a:    time.Date(2023, 10, 5, 15, 30, 45, 1, time.UTC),
+		b:    time.Date(2023, 10, 5, 15, 30, 45, 2, time.UTC),
+		unit: Nanosecond,
+		want: false,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TruncateCompare(tt.a, tt.b, tt.unit); got != tt.want {
+				t.Errorf("TruncateCompare() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsSameDay(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1792,7 +2310,7 @@ func TestAgeOfNow(t *testing.T) {
 	now := time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC)
 	// 使用匿名函数包装AgeOfNow，以便注入当前时间进行测试
 	ageOfNow := func(birthDay time.Time) int {
-		return age(birthDay, now)
+		return AgeAt(birthDay, now)
 	}
 
 	tests := []struct {
@@ -1826,6 +2344,91 @@ func TestAgeOfNow(t *testing.T) {
 	}
 }
 
+func TestAgeAt(t *testing.T) {
+	tests := []struct {
+		name     string
+		birthDay time.Time
+		at       time.Time
+		want     int
+	}{{
+		name:     "before birthday",
+		birthDay: time.Date(1990, 6, 15, 0, 0, 0, 0, time.UTC),
+		at:       time.Date(2020, 6, 14, 0, 0, 0, 0, time.UTC),
+		want:     29,
+	}, {
+		name:     "on birthday",
+		birthDay: time.Date(1990, 6, 15, 0, 0, 0, 0, time.UTC),
+		at:       time.Date(2020, 6, 15, 0, 0, 0, 0, time.UTC),
+		want:     30,
+	}, {
+		name:     "leap day birthday on feb 28 of non-leap year",
+		birthDay: time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC),
+		at:       time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC),
+		want:     22,
+	}, {
+		name:     "leap day birthday on mar 1 of non-leap year",
+		birthDay: time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC),
+		at:       time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+		want:     23,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AgeAt(tt.birthDay, tt.at); got != tt.want {
+				t.Errorf("AgeAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDaysUntilBirthday(t *testing.T) {
+	today := time.Date(2023, 10, 5, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		birthDay time.Time
+		today    time.Time
+		want     int
+	}{{
+		name:     "later this year",
+		birthDay: time.Date(1990, 12, 25, 0, 0, 0, 0, time.UTC),
+		today:    today,
+		want:     81,
+	}, {
+		name:     "already passed",
+		birthDay: time.Date(1990, 5, 15, 0, 0, 0, 0, time.UTC),
+		today:    today,
+		want:     223,
+	}, {
+		name:     "today",
+		birthDay: time.Date(1990, 10, 5, 0, 0, 0, 0, time.UTC),
+		today:    today,
+		want:     0,
+	}, {
+		name:     "leap day birthday in non-leap year",
+		birthDay: time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC),
+		today:    time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+		want:     28,
+	}, {
+		name:     "leap day birthday in leap year",
+		birthDay: time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC),
+		today:    time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		want:     28,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := daysUntilBirthday(tt.birthDay, tt.today); got != tt.want {
+				t.Errorf("DaysUntilBirthday() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := DaysUntilBirthday(Now()); got != 0 {
+		t.Errorf("DaysUntilBirthday(Now()) = %v, want 0", got)
+	}
+}
+
 func TestAgeOfNowString(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -1935,6 +2538,135 @@ func TestGetChineseZodiac(t *testing.T) {
 	}
 }
 
+func TestGetZodiacSign(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		lang string
+		want string
+	}{{
+		name: "aries first day",
+		t:    time.Date(2023, 3, 21, 0, 0, 0, 0, time.UTC),
+		lang: LangEnglish,
+		want: "Aries",
+	}, {
+		name: "pisces last day",
+		t:    time.Date(2023, 3, 20, 23, 59, 0, 0, time.UTC),
+		lang: LangEnglish,
+		want: "Pisces",
+	}, {
+		name: "aries last day chinese",
+		t:    time.Date(2023, 4, 19, 0, 0, 0, 0, time.UTC),
+		lang: LangChinese,
+		want: "白羊座",
+	}, {
+		name: "taurus first day chinese",
+		t:    time.Date(2023, 4, 20, 0, 0, 0, 0, time.UTC),
+		lang: LangChinese,
+		want: "金牛座",
+	}, {
+		name: "inside leo",
+		t:    time.Date(2023, 8, 5, 0, 0, 0, 0, time.UTC),
+		lang: LangEnglish,
+		want: "Leo",
+	}, {
+		name: "inside leo chinese",
+		t:    time.Date(2023, 8, 5, 0, 0, 0, 0, time.UTC),
+		lang: LangChinese,
+		want: "狮子座",
+	}, {
+		name: "capricorn in december",
+		t:    time.Date(2023, 12, 22, 0, 0, 0, 0, time.UTC),
+		lang: LangEnglish,
+		want: "Capricorn",
+	}, {
+		name: "capricorn in january",
+		t:    time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC),
+		lang: LangChinese,
+		want: "摩羯座",
+	}, {
+		name: "aquarius first day",
+		t:    time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC),
+		lang: LangEnglish,
+		want: "Aquarius",
+	}, {
+		name: "sagittarius last day",
+		t:    time.Date(2023, 12, 21, 0, 0, 0, 0, time.UTC),
+		lang: LangEnglish,
+		want: "Sagittarius",
+	}, {
+		name: "unsupported language",
+		t:    time.Date(2023, 8, 5, 0, 0, 0, 0, time.UTC),
+		lang: "fr",
+		want: "",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetZodiacSign(tt.t, tt.lang); got != tt.want {
+				t.Errorf("GetZodiacSign() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeason(t *testing.T) {
+	tests := []struct {
+		name       string
+		t          time.Time
+		hemisphere string
+		want       string
+	}{{
+		name:       "march in north",
+		t:          time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC),
+		hemisphere: "north",
+		want:       "spring",
+	}, {
+		name:       "march in south",
+		t:          time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC),
+		hemisphere: "south",
+		want:       "autumn",
+	}, {
+		name:       "december in north",
+		t:          time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC),
+		hemisphere: "north",
+		want:       "winter",
+	}, {
+		name:       "december in south",
+		t:          time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC),
+		hemisphere: "south",
+		want:       "summer",
+	}, {
+		name:       "february in north",
+		t:          time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		hemisphere: "north",
+		want:       "winter",
+	}, {
+		name:       "august in north",
+		t:          time.Date(2023, 8, 31, 0, 0, 0, 0, time.UTC),
+		hemisphere: "north",
+		want:       "summer",
+	}, {
+		name:       "september in south",
+		t:          time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC),
+		hemisphere: "south",
+		want:       "spring",
+	}, {
+		name:       "invalid hemisphere",
+		t:          time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC),
+		hemisphere: "east",
+		want:       "",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Season(tt.t, tt.hemisphere); got != tt.want {
+				t.Errorf("Season() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestLengthOfYear(t *testing.T) {
 	tests := []struct {
 		name string
@@ -2064,3 +2796,216 @@ func TestGetLastDayOfMonth(t *testing.T) {
 		})
 	}
 }
+
+func TestNthWeekdayOfMonth(t *testing.T) {
+	tests := []struct {
+		name    string
+		year    int
+		month   time.Month
+		weekday time.Weekday
+		n       int
+		want    time.Time
+		wantErr bool
+	}{{
+		name:    "third monday of january",
+		year:    2024,
+		month:   time.January,
+		weekday: time.Monday,
+		n:       3,
+		want:    time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:    "last friday of february",
+		year:    2024,
+		month:   time.February,
+		weekday: time.Friday,
+		n:       -1,
+		want:    time.Date(2024, 2, 23, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:    "first day is the weekday",
+		year:    2023,
+		month:   time.October,
+		weekday: time.Sunday,
+		n:       1,
+		want:    time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:    "fifth tuesday exists",
+		year:    2023,
+		month:   time.October,
+		weekday: time.Tuesday,
+		n:       5,
+		want:    time.Date(2023, 10, 31, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:    "second to last",
+		year:    2023,
+		month:   time.October,
+		weekday: time.Tuesday,
+		n:       -2,
+		want:    time.Date(2023, 10, 24, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:    "fifth saturday out of range",
+		year:    2023,
+		month:   time.February,
+		weekday: time.Saturday,
+		n:       5,
+		wantErr: true,
+	}, {
+		name:    "zero n",
+		year:    2023,
+		month:   time.February,
+		weekday: time.Saturday,
+		n:       0,
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NthWeekdayOfMonth(tt.year, tt.month, tt.weekday, tt.n)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NthWeekdayOfMonth() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("NthWeekdayOfMonth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeekdaysInRange(t *testing.T) {
+	date := func(day int) time.Time {
+		return time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name  string
+		start time.Time
+		end   time.Time
+		days  []time.Weekday
+		want  []time.Time
+	}{{
+		name:  "mondays and wednesdays in january",
+		start: date(1),
+		end:   date(31),
+		days:  []time.Weekday{time.Wednesday, time.Monday},
+		want:  []time.Time{date(1), date(3), date(8), date(10), date(15), date(17), date(22), date(24), date(29), date(31)},
+	}, {
+		name:  "endpoints inclusive with times of day",
+		start: time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC),
+		end:   time.Date(2024, 1, 8, 6, 0, 0, 0, time.UTC),
+		days:  []time.Weekday{time.Monday},
+		want:  []time.Time{date(1), date(8)},
+	}, {
+		name:  "duplicate days ignored",
+		start: date(1),
+		end:   date(7),
+		days:  []time.Weekday{time.Sunday, time.Sunday},
+		want:  []time.Time{date(7)},
+	}, {
+		name:  "no matching day",
+		start: date(2),
+		end:   date(6),
+		days:  []time.Weekday{time.Sunday, time.Monday},
+		want:  []time.Time{},
+	}, {
+		name:  "no days given",
+		start: date(1),
+		end:   date(31),
+		want:  []time.Time{},
+	}, {
+		name:  "end before start",
+		start: date(10),
+		end:   date(1),
+		days:  []time.Weekday{time.Monday},
+		want:  []time.Time{},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WeekdaysInRange(tt.start, tt.end, tt.days...)
+			if got == nil || len(got) != len(tt.want) {
+				t.Fatalf("WeekdaysInRange() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("WeekdaysInRange()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data not available: %v", err)
+	}
+	// 跨越夏令时切换（2024-03-10）时仍返回每天的零点
+	got := WeekdaysInRange(time.Date(2024, 3, 9, 12, 0, 0, 0, loc), time.Date(2024, 3, 12, 0, 0, 0, 0, loc), time.Sunday, time.Monday)
+	want := []time.Time{time.Date(2024, 3, 10, 0, 0, 0, 0, loc), time.Date(2024, 3, 11, 0, 0, 0, 0, loc)}
+	if len(got) != len(want) || !got[0].Equal(want[0]) || !got[1].Equal(want[1]) {
+		t.Errorf("WeekdaysInRange() across DST = %v, want %v", got, want)
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	t1 := time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC)
+	t2 := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	t3 := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		times   []time.Time
+		wantMin time.Time
+		wantMax time.Time
+	}{{
+		name:    "unordered",
+		times:   []time.Time{t1, t2, t3},
+		wantMin: t2,
+		wantMax: t3,
+	}, {
+		name:    "single",
+		times:   []time.Time{t1},
+		wantMin: t1,
+		wantMax: t1,
+	}, {
+		name:    "empty",
+		times:   nil,
+		wantMin: time.Time{},
+		wantMax: time.Time{},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Min(tt.times...); !got.Equal(tt.wantMin) {
+				t.Errorf("Min() = %v, want %v", got, tt.wantMin)
+			}
+			if got := Max(tt.times...); !got.Equal(tt.wantMax) {
+				t.Errorf("Max() = %v, want %v", got, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	t1 := time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC)
+	t2 := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	t3 := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	times := []time.Time{t1, t2, t3}
+	Sort(times)
+	for i, want := range []time.Time{t2, t1, t3} {
+		if !times[i].Equal(want) {
+			t.Errorf("Sort()[%d] = %v, want %v", i, times[i], want)
+		}
+	}
+
+	SortDesc(times)
+	for i, want := range []time.Time{t3, t1, t2} {
+		if !times[i].Equal(want) {
+			t.Errorf("SortDesc()[%d] = %v, want %v", i, times[i], want)
+		}
+	}
+
+	single := []time.Time{t1}
+	Sort(single)
+	if !single[0].Equal(t1) {
+		t.Errorf("Sort() single = %v, want %v", single[0], t1)
+	}
+	Sort(nil)
+}
@@ -0,0 +1,293 @@
+package dateutil
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseISO8601Duration 解析ISO 8601格式的时长字符串，如"P3Y6M4DT12H30M5S"
+// 年、月、日属于日历分量，长度不固定，无法折算为time.Duration，因此单独返回；
+// 时、分、秒折算为time.Duration返回。周(W)按7天计入days。
+// 支持可选的前导负号（如"-P1D"），此时所有分量均为负数；秒分量允许小数（如"PT0.5S"）
+// s: 待解析的字符串
+// 返回值: 年数、月数、天数、时分秒对应的时长以及可能的错误
+func ParseISO8601Duration(s string) (years, months, days int, d time.Duration, err error) {
+	if s == "" {
+		return 0, 0, 0, 0, errors.New("empty input string")
+	}
+
+	sign := 1
+	rest := s
+	if strings.HasPrefix(rest, "-") {
+		sign = -1
+		rest = rest[1:]
+	} else if strings.HasPrefix(rest, "+") {
+		rest = rest[1:]
+	}
+	if !strings.HasPrefix(rest, "P") {
+		return 0, 0, 0, 0, fmt.Errorf("invalid ISO 8601 duration %q: missing 'P' designator", s)
+	}
+	rest = rest[1:]
+
+	datePart, timePart, hasTime := strings.Cut(rest, "T")
+	if datePart == "" && timePart == "" {
+		return 0, 0, 0, 0, fmt.Errorf("invalid ISO 8601 duration %q: no components", s)
+	}
+	if hasTime && timePart == "" {
+		return 0, 0, 0, 0, fmt.Errorf("invalid ISO 8601 duration %q: empty time part", s)
+	}
+
+	// 日期部分: Y、M、W、D 必须按顺序出现
+	dateUnits := "YMWD"
+	for datePart != "" {
+		num, unit, remain, perr := nextDurationComponent(datePart)
+		if perr != nil {
+			return 0, 0, 0, 0, fmt.Errorf("invalid ISO 8601 duration %q: %w", s, perr)
+		}
+		idx := strings.IndexByte(dateUnits, unit)
+		if idx < 0 {
+			return 0, 0, 0, 0, fmt.Errorf("invalid ISO 8601 duration %q: unexpected designator %q", s, unit)
+		}
+		if strings.Contains(num, ".") {
+			return 0, 0, 0, 0, fmt.Errorf("invalid ISO 8601 duration %q: fraction only allowed for seconds", s)
+		}
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			return 0, 0, 0, 0, fmt.Errorf("invalid ISO 8601 duration %q: %w", s, err)
+		}
+		switch unit {
+		case 'Y':
+			years = n
+		case 'M':
+			months = n
+		case 'W', 'D':
+			perDay := 1
+			if unit == 'W' {
+				perDay = 7
+			}
+			if n > (math.MaxInt-days)/perDay {
+				return 0, 0, 0, 0, fmt.Errorf("invalid ISO 8601 duration %q: days out of range", s)
+			}
+			days += n * perDay
+		}
+		dateUnits = dateUnits[idx+1:]
+		datePart = remain
+	}
+
+	// 时间部分: H、M、S 必须按顺序出现
+	timeUnits := "HMS"
+	for timePart != "" {
+		num, unit, remain, perr := nextDurationComponent(timePart)
+		if perr != nil {
+			return 0, 0, 0, 0, fmt.Errorf("invalid ISO 8601 duration %q: %w", s, perr)
+		}
+		idx := strings.IndexByte(timeUnits, unit)
+		if idx < 0 {
+			return 0, 0, 0, 0, fmt.Errorf("invalid ISO 8601 duration %q: unexpected designator %q", s, unit)
+		}
+		if unit != 'S' && strings.Contains(num, ".") {
+			return 0, 0, 0, 0, fmt.Errorf("invalid ISO 8601 duration %q: fraction only allowed for seconds", s)
+		}
+		var part time.Duration
+		if unit == 'S' {
+			f, err := strconv.ParseFloat(num, 64)
+			if err != nil || f*float64(time.Second) >= math.MaxInt64 {
+				return 0, 0, 0, 0, fmt.Errorf("invalid ISO 8601 duration %q: seconds out of range", s)
+			}
+			part = time.Duration(f * float64(time.Second))
+		} else {
+			n, err := strconv.Atoi(num)
+			if err != nil {
+				return 0, 0, 0, 0, fmt.Errorf("invalid ISO 8601 duration %q: %w", s, err)
+			}
+			scale := time.Hour
+			if unit == 'M' {
+				scale = time.Minute
+			}
+			if int64(n) > math.MaxInt64/int64(scale) {
+				return 0, 0, 0, 0, fmt.Errorf("invalid ISO 8601 duration %q: %c out of range", s, unit)
+			}
+			part = time.Duration(n) * scale
+		}
+		if d > math.MaxInt64-part {
+			return 0, 0, 0, 0, fmt.Errorf("invalid ISO 8601 duration %q: time part out of range", s)
+		}
+		d += part
+		timeUnits = timeUnits[idx+1:]
+		timePart = remain
+	}
+
+	if sign < 0 {
+		years, months, days, d = -years, -months, -days, -d
+	}
+	return years, months, days, d, nil
+}
+
+// ApplyISO8601Duration 将ISO 8601格式的时长加到指定时间上
+// 年、月、日通过AddDate添加，时、分、秒通过Add添加
+// t: 原始时间
+// s: ISO 8601时长字符串
+// 返回值: 添加后的时间和可能的解析错误
+func ApplyISO8601Duration(t time.Time, s string) (time.Time, error) {
+	years, months, days, d, err := ParseISO8601Duration(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.AddDate(years, months, days).Add(d), nil
+}
+
+// nextDurationComponent 从字符串开头读取一个"数字+单位"分量
+// 逗号作为小数分隔符时统一转换为点号
+func nextDurationComponent(s string) (num string, unit byte, rest string, err error) {
+	i := 0
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.' || s[i] == ',') {
+		i++
+	}
+	if i == 0 {
+		return "", 0, "", errors.New("missing number")
+	}
+	if i == len(s) {
+		return "", 0, "", errors.New("missing designator")
+	}
+	num = strings.ReplaceAll(s[:i], ",", ".")
+	if strings.Count(num, ".") > 1 || strings.HasPrefix(num, ".") || strings.HasSuffix(num, ".") {
+		return "", 0, "", fmt.Errorf("invalid number %q", s[:i])
+	}
+	return num, s[i], s[i+1:], nil
+}
+
+// extendedDurationUnits ParseExtendedDuration支持的单位，多字符单位需排在前面以优先匹配
+var extendedDurationUnits = []struct {
+	name string
+	unit time.Duration
+}{
+	{"ns", time.Nanosecond},
+	{"us", time.Microsecond},
+	{"µs", time.Microsecond},
+	{"ms", time.Millisecond},
+	{"w", 7 * 24 * time.Hour},
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+}
+
+// ParseExtendedDuration 解析扩展的时长字符串，在time.ParseDuration的基础上增加天(d)和周(w)单位
+// 天固定按24小时、周固定按7天计算，不考虑夏令时；支持可选的正负号、小数和多个分量组合，如"1w2d"、"-1.5d"、"+3d12h"
+// s: 待解析的字符串
+// 返回值: 解析得到的时长和可能的错误
+func ParseExtendedDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, errors.New("empty input string")
+	}
+
+	sign := time.Duration(1)
+	rest := s
+	if strings.HasPrefix(rest, "-") {
+		sign = -1
+		rest = rest[1:]
+	} else if strings.HasPrefix(rest, "+") {
+		rest = rest[1:]
+	}
+	if rest == "" {
+		return 0, fmt.Errorf("invalid duration %q: no components", s)
+	}
+
+	var total time.Duration
+	for rest != "" {
+		i := 0
+		for i < len(rest) && (rest[i] >= '0' && rest[i] <= '9' || rest[i] == '.') {
+			i++
+		}
+		num := rest[:i]
+		if num == "" || strings.Count(num, ".") > 1 || num == "." {
+			return 0, fmt.Errorf("invalid duration %q: missing number", s)
+		}
+		rest = rest[i:]
+
+		var unit time.Duration
+		for _, u := range extendedDurationUnits {
+			if strings.HasPrefix(rest, u.name) {
+				unit = u.unit
+				rest = rest[len(u.name):]
+				break
+			}
+		}
+		if unit == 0 {
+			return 0, fmt.Errorf("invalid duration %q: missing or unknown unit", s)
+		}
+
+		intPart, fracPart, _ := strings.Cut(num, ".")
+		if intPart != "" {
+			n, err := strconv.ParseInt(intPart, 10, 64)
+			if err != nil || n > int64(math.MaxInt64/unit) {
+				return 0, fmt.Errorf("invalid duration %q: overflow", s)
+			}
+			total += time.Duration(n) * unit
+		}
+		if fracPart != "" {
+			f, _ := strconv.ParseFloat("0."+fracPart, 64)
+			total += time.Duration(f * float64(unit))
+		}
+		if total < 0 {
+			return 0, fmt.Errorf("invalid duration %q: overflow", s)
+		}
+	}
+	return sign * total, nil
+}
+
+// DurationParts 时长按天、时、分、秒、毫秒分解后的各分量，用于倒计时等展示场景
+// 各分量均为非负数，负时长通过Negative标记表示
+type DurationParts struct {
+	Negative     bool // 原时长是否为负数
+	Weeks        int  // 周数，仅BreakdownWithWeeks会填充
+	Days         int  // 天数，固定按24小时计算
+	Hours        int  // 小时（0-23）
+	Minutes      int  // 分钟（0-59）
+	Seconds      int  // 秒（0-59）
+	Milliseconds int  // 毫秒（0-999），不足一毫秒的部分舍去
+}
+
+// Breakdown 将时长分解为天、时、分、秒、毫秒，Weeks始终为0
+// 如90061秒分解为1天1小时1分1秒
+// d: 时长，负数时设置Negative并按绝对值分解
+// 返回值: 分解后的各分量
+func Breakdown(d time.Duration) DurationParts {
+	return breakdown(d, false)
+}
+
+// BreakdownWithWeeks 将时长分解为周、天、时、分、秒、毫秒，Days的范围为0-6
+// d: 时长，负数时设置Negative并按绝对值分解
+// 返回值: 分解后的各分量
+func BreakdownWithWeeks(d time.Duration) DurationParts {
+	return breakdown(d, true)
+}
+
+// breakdown 按绝对值分解时长，使用无符号数以正确处理math.MinInt64
+func breakdown(d time.Duration, withWeeks bool) DurationParts {
+	parts := DurationParts{Negative: d < 0}
+	abs := uint64(d)
+	if parts.Negative {
+		abs = -abs
+	}
+
+	abs /= uint64(time.Millisecond)
+	parts.Milliseconds = int(abs % 1000)
+	abs /= 1000
+	parts.Seconds = int(abs % 60)
+	abs /= 60
+	parts.Minutes = int(abs % 60)
+	abs /= 60
+	parts.Hours = int(abs % 24)
+	abs /= 24
+	if withWeeks {
+		parts.Weeks = int(abs / 7)
+		abs %= 7
+	}
+	parts.Days = int(abs)
+	return parts
+}
@@ -0,0 +1,273 @@
+package dateutil
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseISO8601Duration(t *testing.T) {
+	tests := []struct {
+		name       string
+		s          string
+		wantYears  int
+		wantMonths int
+		wantDays   int
+		wantD      time.Duration
+		wantErr    bool
+	}{{
+		name:       "full example",
+		s:          "P3Y6M4DT12H30M5S",
+		wantYears:  3,
+		wantMonths: 6,
+		wantDays:   4,
+		wantD:      12*time.Hour + 30*time.Minute + 5*time.Second,
+	}, {
+		name:  "minutes only",
+		s:     "PT30M",
+		wantD: 30 * time.Minute,
+	}, {
+		name:     "one day",
+		s:        "P1D",
+		wantDays: 1,
+	}, {
+		name:     "weeks",
+		s:        "P2W",
+		wantDays: 14,
+	}, {
+		name:  "fractional seconds",
+		s:     "PT1.5S",
+		wantD: 1500 * time.Millisecond,
+	}, {
+		name:     "negative",
+		s:        "-P1DT2H",
+		wantDays: -1,
+		wantD:    -2 * time.Hour,
+	}, {
+		name:    "empty string",
+		s:       "",
+		wantErr: true,
+	}, {
+		name:    "missing designator",
+		s:       "3Y",
+		wantErr: true,
+	}, {
+		name:    "no components",
+		s:       "P",
+		wantErr: true,
+	}, {
+		name:    "empty time part",
+		s:       "P1DT",
+		wantErr: true,
+	}, {
+		name:    "wrong order",
+		s:       "P1D2Y",
+		wantErr: true,
+	}, {
+		name:    "hours in date part",
+		s:       "P1H",
+		wantErr: true,
+	}, {
+		name:    "fractional days",
+		s:       "P1.5D",
+		wantErr: true,
+	}, {
+		name:    "trailing number",
+		s:       "PT30",
+		wantErr: true,
+	}, {
+		name:    "days overflow",
+		s:       "P99999999999999999999D",
+		wantErr: true,
+	}, {
+		name:    "weeks overflow",
+		s:       "P9999999999999999999W",
+		wantErr: true,
+	}, {
+		name:    "years overflow",
+		s:       "P99999999999999999999Y",
+		wantErr: true,
+	}, {
+		name:    "hours overflow",
+		s:       "PT99999999999999999999H",
+		wantErr: true,
+	}, {
+		name:    "hours exceed time.Duration",
+		s:       "PT3000000H",
+		wantErr: true,
+	}, {
+		name:    "seconds exceed time.Duration",
+		s:       "PT99999999999S",
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			years, months, days, d, err := ParseISO8601Duration(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseISO8601Duration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if years != tt.wantYears || months != tt.wantMonths || days != tt.wantDays || d != tt.wantD {
+				t.Errorf("ParseISO8601Duration() = %d, %d, %d, %v, want %d, %d, %d, %v",
+					years, months, days, d, tt.wantYears, tt.wantMonths, tt.wantDays, tt.wantD)
+			}
+		})
+	}
+}
+
+func TestApplyISO8601Duration(t *testing.T) {
+	base := time.Date(2023, 1, 31, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		s       string
+		want    time.Time
+		wantErr bool
+	}{{
+		name: "full example",
+		s:    "P3Y6M4DT12H30M5S",
+		want: time.Date(2026, 8, 4, 20, 30, 5, 0, time.UTC),
+	}, {
+		name: "minutes only",
+		s:    "PT30M",
+		want: time.Date(2023, 1, 31, 8, 30, 0, 0, time.UTC),
+	}, {
+		name: "one day",
+		s:    "P1D",
+		want: time.Date(2023, 2, 1, 8, 0, 0, 0, time.UTC),
+	}, {
+		name:    "invalid",
+		s:       "P1X",
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyISO8601Duration(base, tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyISO8601Duration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("ApplyISO8601Duration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseExtendedDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    time.Duration
+		wantErr bool
+	}{{
+		name: "days",
+		s:    "3d",
+		want: 72 * time.Hour,
+	}, {
+		name: "weeks and days",
+		s:    "1w2d",
+		want: 9 * 24 * time.Hour,
+	}, {
+		name: "signed combination",
+		s:    "+3d12h",
+		want: 84 * time.Hour,
+	}, {
+		name: "negative minutes",
+		s:    "-90m",
+		want: -90 * time.Minute,
+	}, {
+		name: "fractional day",
+		s:    "1.5d",
+		want: 36 * time.Hour,
+	}, {
+		name: "milliseconds not minutes",
+		s:    "1m500ms",
+		want: time.Minute + 500*time.Millisecond,
+	}, {
+		name:    "empty",
+		s:       "",
+		wantErr: true,
+	}, {
+		name:    "sign only",
+		s:       "+",
+		wantErr: true,
+	}, {
+		name:    "missing unit",
+		s:       "3",
+		wantErr: true,
+	}, {
+		name:    "unknown unit",
+		s:       "3y",
+		wantErr: true,
+	}, {
+		name:    "overflow",
+		s:       "999999999w",
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseExtendedDuration(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseExtendedDuration(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseExtendedDuration(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakdown(t *testing.T) {
+	tests := []struct {
+		name      string
+		d         time.Duration
+		withWeeks bool
+		want      DurationParts
+	}{{
+		name: "one of each",
+		d:    90061 * time.Second,
+		want: DurationParts{Days: 1, Hours: 1, Minutes: 1, Seconds: 1},
+	}, {
+		name: "negative",
+		d:    -(2*time.Hour + 30*time.Minute + 1500*time.Millisecond),
+		want: DurationParts{Negative: true, Hours: 2, Minutes: 30, Seconds: 1, Milliseconds: 500},
+	}, {
+		name: "sub-millisecond truncated",
+		d:    999*time.Microsecond + 1500*time.Microsecond,
+		want: DurationParts{Milliseconds: 2},
+	}, {
+		name: "zero",
+		d:    0,
+		want: DurationParts{},
+	}, {
+		name: "days beyond a week without weeks",
+		d:    10 * 24 * time.Hour,
+		want: DurationParts{Days: 10},
+	}, {
+		name:      "with weeks",
+		d:         17*24*time.Hour + 3*time.Hour,
+		withWeeks: true,
+		want:      DurationParts{Weeks: 2, Days: 3, Hours: 3},
+	}, {
+		name:      "minimum duration",
+		d:         math.MinInt64,
+		withWeeks: true,
+		want:      DurationParts{Negative: true, Weeks: 15250, Days: 1, Hours: 23, Minutes: 47, Seconds: 16, Milliseconds: 854},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Breakdown(tt.d)
+			if tt.withWeeks {
+				got = BreakdownWithWeeks(tt.d)
+			}
+			if got != tt.want {
+				t.Errorf("Breakdown(%v) = %+v, want %+v", tt.d, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package dateutil
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MarshalRFC3339 将时间序列化为RFC3339格式的字符串，保留时区偏移和纳秒精度
+// t: 待序列化的时间
+// 返回值: 如"2023-10-05T15:30:45.123+08:00"，小数秒末尾的0会被省略
+func MarshalRFC3339(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+// UnmarshalRFC3339 解析RFC3339格式的字符串，小数秒可有可无
+// s: 待解析的字符串
+// 返回值: 解析后的时间（保留原始时区偏移）和可能的错误（空输入或格式错误）
+func UnmarshalRFC3339(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, errors.New("empty input string")
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+// httpTimeFormat HTTP头部（Date、Expires、Last-Modified等）使用的时间格式，即GMT时区的RFC1123
+const httpTimeFormat = "Mon, 02 Jan 2006 15:04:05 GMT"
+
+// httpTimeLayouts ParseHTTP依次尝试的格式: RFC1123、RFC850和ANSI C的asctime
+var httpTimeLayouts = []string{
+	httpTimeFormat,
+	time.RFC850,
+	time.ANSIC,
+}
+
+// FormatHTTP 将时间格式化为HTTP头部使用的日期格式
+// t: 待格式化的时间，会先转换为UTC
+// 返回值: 如"Mon, 02 Jan 2006 15:04:05 GMT"
+func FormatHTTP(t time.Time) string {
+	return t.UTC().Format(httpTimeFormat)
+}
+
+// ParseHTTP 解析HTTP头部中的日期，兼容HTTP/1.1规定的三种格式:
+// RFC1123（"Sun, 06 Nov 1994 08:49:37 GMT"）、RFC850（"Sunday, 06-Nov-94 08:49:37 GMT"）
+// 和ANSI C asctime（"Sun Nov  6 08:49:37 1994"）
+// s: 待解析的字符串
+// 返回值: 解析后的UTC时间和可能的错误（空输入或格式错误）
+func ParseHTTP(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, errors.New("empty input string")
+	}
+	for _, layout := range httpTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid HTTP date %q", s)
+}
+
+// FormatFlexible 使用yyyy-MM-dd风格的模式格式化时间，比FormatDateTime、FormatDate更灵活
+// 支持的占位符: yyyy(四位年) yy(两位年) MM/M(月) dd/d(日) HH/H(24小时制) hh/h(12小时制)
+// mm/m(分) ss/s(秒) SSS(毫秒) a(AM/PM) Z(时区偏移，如+08:00)
+// 单引号中的内容原样输出（如'T'），两个连续单引号表示单引号本身，其余字符原样输出
+// t: 待格式化的时间
+// layout: 格式模式，如"yyyy/MM/dd HH:mm:ss.SSS"
+// 返回值: 格式化后的字符串
+func FormatFlexible(t time.Time, layout string) string {
+	var b strings.Builder
+	runes := []rune(layout)
+
+	for i := 0; i < len(runes); {
+		c := runes[i]
+
+		// 单引号包裹的字面量
+		if c == '\'' {
+			if i+1 < len(runes) && runes[i+1] == '\'' {
+				b.WriteRune('\'')
+				i += 2
+				continue
+			}
+			j := i + 1
+			for j < len(runes) && runes[j] != '\'' {
+				j++
+			}
+			b.WriteString(string(runes[i+1 : j]))
+			i = j + 1
+			continue
+		}
+
+		// 统计连续相同占位字母的个数
+		n := 1
+		for i+n < len(runes) && runes[i+n] == c {
+			n++
+		}
+
+		switch c {
+		case 'y':
+			if n == 2 {
+				b.WriteString(pad2(t.Year() % 100))
+			} else {
+				b.WriteString(strconv.Itoa(t.Year()))
+			}
+		case 'M':
+			writeNumber(&b, int(t.Month()), n)
+		case 'd':
+			writeNumber(&b, t.Day(), n)
+		case 'H':
+			writeNumber(&b, t.Hour(), n)
+		case 'h':
+			hour := t.Hour() % 12
+			if hour == 0 {
+				hour = 12
+			}
+			writeNumber(&b, hour, n)
+		case 'm':
+			writeNumber(&b, t.Minute(), n)
+		case 's':
+			writeNumber(&b, t.Second(), n)
+		case 'S':
+			ms := strconv.Itoa(t.Nanosecond() / int(time.Millisecond))
+			b.WriteString(strings.Repeat("0", 3-len(ms)) + ms)
+		case 'a':
+			if t.Hour() < 12 {
+				b.WriteString("AM")
+			} else {
+				b.WriteString("PM")
+			}
+		case 'Z':
+			b.WriteString(t.Format("-07:00"))
+		default:
+			b.WriteString(strings.Repeat(string(c), n))
+		}
+		i += n
+	}
+	return b.String()
+}
+
+// ToMap 将时间拆分为各个组成部分，便于模板渲染
+// t: 时间
+// 返回值: 包含year、month、day、hour、minute、second、nanosecond、weekday(0表示周日)、yearDay的映射
+func ToMap(t time.Time) map[string]int {
+	return map[string]int{
+		"year":       t.Year(),
+		"month":      int(t.Month()),
+		"day":        t.Day(),
+		"hour":       t.Hour(),
+		"minute":     t.Minute(),
+		"second":     t.Second(),
+		"nanosecond": t.Nanosecond(),
+		"weekday":    int(t.Weekday()),
+		"yearDay":    t.YearDay(),
+	}
+}
+
+// TimeAgo 以社交信息流的风格描述t距今多久，如"刚刚"、"5分钟前"、"昨天"
+// 分档规则见TimeAgoAt
+// t: 时间
+// lang: 语言，LangEnglish或LangChinese
+// 返回值: 相对时间描述；语言不支持时返回空字符串
+func TimeAgo(t time.Time, lang string) string {
+	return TimeAgoAt(t, time.Now(), lang)
+}
+
+// TimeAgoAt 以社交信息流的风格描述t相对于now的时间，按以下分档（d = now - t）:
+// d < 60秒: "刚刚"/"just now"（t晚于now时同样视为刚刚）
+// d < 60分钟: "N分钟前"/"N minutes ago"
+// d < 24小时: "N小时前"/"N hours ago"
+// d < 48小时: "昨天"/"yesterday"
+// d < 7天: "N天前"/"N days ago"
+// 其余: 绝对日期，如"2006年1月2日"/"Jan 2, 2006"，按t自身的时区显示
+// 各档中的N向下取整，英文在N为1时使用单数
+// t: 时间
+// now: 参照时间，通常为当前时间
+// lang: 语言，LangEnglish或LangChinese
+// 返回值: 相对时间描述；语言不支持时返回空字符串
+func TimeAgoAt(t, now time.Time, lang string) string {
+	if lang != LangEnglish && lang != LangChinese {
+		return ""
+	}
+	zh := lang == LangChinese
+
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		if zh {
+			return "刚刚"
+		}
+		return "just now"
+	case d < time.Hour:
+		return timeAgoUnits(int(d/time.Minute), "分钟前", "minute", zh)
+	case d < 24*time.Hour:
+		return timeAgoUnits(int(d/time.Hour), "小时前", "hour", zh)
+	case d < 48*time.Hour:
+		if zh {
+			return "昨天"
+		}
+		return "yesterday"
+	case d < 7*24*time.Hour:
+		return timeAgoUnits(int(d/(24*time.Hour)), "天前", "day", zh)
+	}
+	if zh {
+		return t.Format("2006年1月2日")
+	}
+	return t.Format("Jan 2, 2006")
+}
+
+// timeAgoUnits 生成"N单位前"形式的描述，英文在n为1时使用单数
+func timeAgoUnits(n int, zhSuffix, enUnit string, zh bool) string {
+	if zh {
+		return strconv.Itoa(n) + zhSuffix
+	}
+	if n == 1 {
+		return "1 " + enUnit + " ago"
+	}
+	return strconv.Itoa(n) + " " + enUnit + "s ago"
+}
+
+// writeNumber 写入数字，占位字母重复两次及以上时补齐为两位
+func writeNumber(b *strings.Builder, v, width int) {
+	if width >= 2 {
+		b.WriteString(pad2(v))
+		return
+	}
+	b.WriteString(strconv.Itoa(v))
+}
+
+// pad2 将数字格式化为至少两位，不足时前补0
+func pad2(v int) string {
+	if v < 10 {
+		return "0" + strconv.Itoa(v)
+	}
+	return strconv.Itoa(v)
+}
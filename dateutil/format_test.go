@@ -0,0 +1,252 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMarshalRFC3339(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{{
+		name: "offset and fraction",
+		t:    time.Date(2023, 10, 5, 15, 30, 45, 123000000, loc),
+		want: "2023-10-05T15:30:45.123+08:00",
+	}, {
+		name: "utc without fraction",
+		t:    time.Date(2023, 10, 5, 15, 30, 45, 0, time.UTC),
+		want: "2023-10-05T15:30:45Z",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MarshalRFC3339(tt.t)
+			if got != tt.want {
+				t.Errorf("MarshalRFC3339() = %q, want %q", got, tt.want)
+			}
+			back, err := UnmarshalRFC3339(got)
+			if err != nil {
+				t.Fatalf("UnmarshalRFC3339() error = %v", err)
+			}
+			if !back.Equal(tt.t) {
+				t.Errorf("round trip = %v, want %v", back, tt.t)
+			}
+			_, wantOffset := tt.t.Zone()
+			if _, offset := back.Zone(); offset != wantOffset {
+				t.Errorf("round trip offset = %d, want %d", offset, wantOffset)
+			}
+		})
+	}
+}
+
+func TestUnmarshalRFC3339(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    time.Time
+		wantErr bool
+	}{{
+		name: "negative offset with nanoseconds",
+		s:    "2023-10-05T15:30:45.000000001-05:00",
+		want: time.Date(2023, 10, 5, 20, 30, 45, 1, time.UTC),
+	}, {
+		name:    "empty",
+		s:       "",
+		wantErr: true,
+	}, {
+		name:    "missing offset",
+		s:       "2023-10-05T15:30:45",
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalRFC3339(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalRFC3339() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("UnmarshalRFC3339() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatHTTP(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	got := FormatHTTP(time.Date(1994, 11, 6, 16, 49, 37, 0, loc))
+	want := "Sun, 06 Nov 1994 08:49:37 GMT"
+	if got != want {
+		t.Errorf("FormatHTTP() = %q, want %q", got, want)
+	}
+
+	back, err := ParseHTTP(got)
+	if err != nil {
+		t.Fatalf("ParseHTTP() error = %v", err)
+	}
+	if FormatHTTP(back) != want {
+		t.Errorf("round trip = %q, want %q", FormatHTTP(back), want)
+	}
+}
+
+func TestParseHTTP(t *testing.T) {
+	want := time.Date(1994, 11, 6, 8, 49, 37, 0, time.UTC)
+	tests := []struct {
+		name    string
+		s       string
+		wantErr bool
+	}{{
+		name: "rfc1123",
+		s:    "Sun, 06 Nov 1994 08:49:37 GMT",
+	}, {
+		name: "rfc850",
+		s:    "Sunday, 06-Nov-94 08:49:37 GMT",
+	}, {
+		name: "asctime",
+		s:    "Sun Nov  6 08:49:37 1994",
+	}, {
+		name:    "iso",
+		s:       "1994-11-06T08:49:37Z",
+		wantErr: true,
+	}, {
+		name:    "empty",
+		s:       "",
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHTTP(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseHTTP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !got.Equal(want) || got.Location() != time.UTC {
+				t.Errorf("ParseHTTP() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFormatFlexible(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	date := time.Date(2023, 3, 5, 9, 7, 3, 45000000, loc)
+
+	tests := []struct {
+		name   string
+		layout string
+		want   string
+	}{{
+		name:   "datetime",
+		layout: "yyyy-MM-dd HH:mm:ss",
+		want:   "2023-03-05 09:07:03",
+	}, {
+		name:   "short fields",
+		layout: "yy/M/d H:m:s",
+		want:   "23/3/5 9:7:3",
+	}, {
+		name:   "milliseconds and offset",
+		layout: "HH:mm:ss.SSSZ",
+		want:   "09:07:03.045+08:00",
+	}, {
+		name:   "twelve hour clock",
+		layout: "hh:mm a",
+		want:   "09:07 AM",
+	}, {
+		name:   "quoted literal",
+		layout: "yyyy-MM-dd'T'HH:mm",
+		want:   "2023-03-05T09:07",
+	}, {
+		name:   "escaped quote and digits kept",
+		layout: "'at' 2006 ''yy",
+		want:   "at 2006 '23",
+	}, {
+		name:   "chinese",
+		layout: "yyyy年M月d日",
+		want:   "2023年3月5日",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatFlexible(date, tt.layout); got != tt.want {
+				t.Errorf("FormatFlexible(%q) = %q, want %q", tt.layout, got, tt.want)
+			}
+		})
+	}
+
+	if got := FormatFlexible(time.Date(2023, 1, 1, 0, 30, 0, 0, time.UTC), "h:mm a"); got != "12:30 AM" {
+		t.Errorf("FormatFlexible(midnight) = %q, want %q", got, "12:30 AM")
+	}
+}
+
+func TestToMap(t *testing.T) {
+	got := ToMap(time.Date(2023, 10, 5, 15, 30, 45, 123, time.UTC))
+	want := map[string]int{
+		"year":       2023,
+		"month":      10,
+		"day":        5,
+		"hour":       15,
+		"minute":     30,
+		"second":     45,
+		"nanosecond": 123,
+		"weekday":    4,
+		"yearDay":    278,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ToMap() = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("ToMap()[%q] = %d, want %d", k, got[k], v)
+		}
+	}
+}
+
+func TestTimeAgoAt(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		ago  time.Duration
+		zh   string
+		en   string
+	}{
+		{"zero", 0, "刚刚", "just now"},
+		{"future", -time.Hour, "刚刚", "just now"},
+		{"59s", 59 * time.Second, "刚刚", "just now"},
+		{"60s", 60 * time.Second, "1分钟前", "1 minute ago"},
+		{"59m59s", time.Hour - time.Second, "59分钟前", "59 minutes ago"},
+		{"60m", time.Hour, "1小时前", "1 hour ago"},
+		{"23h", 23 * time.Hour, "23小时前", "23 hours ago"},
+		{"24h", 24 * time.Hour, "昨天", "yesterday"},
+		{"25h", 25 * time.Hour, "昨天", "yesterday"},
+		{"48h", 48 * time.Hour, "2天前", "2 days ago"},
+		{"6d", 6 * 24 * time.Hour, "6天前", "6 days ago"},
+		{"6d23h", 7*24*time.Hour - time.Hour, "6天前", "6 days ago"},
+		{"7d", 7 * 24 * time.Hour, "2024年3月8日", "Mar 8, 2024"},
+		{"8d", 8 * 24 * time.Hour, "2024年3月7日", "Mar 7, 2024"},
+		{"last year", 400 * 24 * time.Hour, "2023年2月9日", "Feb 9, 2023"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at := now.Add(-tt.ago)
+			if got := TimeAgoAt(at, now, LangChinese); got != tt.zh {
+				t.Errorf("TimeAgoAt(%v, zh) = %q, want %q", at, got, tt.zh)
+			}
+			if got := TimeAgoAt(at, now, LangEnglish); got != tt.en {
+				t.Errorf("TimeAgoAt(%v, en) = %q, want %q", at, got, tt.en)
+			}
+		})
+	}
+
+	if got := TimeAgoAt(now, now, "fr"); got != "" {
+		t.Errorf("TimeAgoAt(fr) = %q, want empty", got)
+	}
+	if got := TimeAgo(time.Now().Add(-2*time.Minute), LangEnglish); got != "2 minutes ago" {
+		t.Errorf("TimeAgo(-2m) = %q, want %q", got, "2 minutes ago")
+	}
+}
@@ -0,0 +1,85 @@
+package dateutil
+
+import "time"
+
+// Interval 时间区间，由起止时间表示
+// Start: 起始时间
+// End: 结束时间
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration 返回区间的时长
+func (i Interval) Duration() time.Duration {
+	return i.End.Sub(i.Start)
+}
+
+// SplitRange 将[start, end]按固定长度切分为首尾相接的子区间，便于分批处理，如"按月处理一整年的数据"
+// 每个子区间跨越size个unit，最后一个区间可能较短并以end结束；相邻区间中前一个的End等于后一个的Start
+// 各区间的起点均从start按偏移量计算，月、季度、年不会因月末日期调整而逐段漂移，
+// 如从1月31日按月切分，各区间起点依次为1月31日、2月29日（或28日）、3月31日……
+// start: 起始时间
+// end: 结束时间
+// unit: 单位，支持Nanosecond、Millisecond、SecondUnit、MinuteUnit、HourUnit、DayUnit、WeekUnit、MonthUnit、QuarterUnit、YearUnit
+// size: 每个区间包含的单位数，必须大于0
+// 返回值: 按时间先后排列的子区间；end不晚于start、size不大于0或单位不支持时返回空切片
+func SplitRange(start, end time.Time, unit TimeUnit, size int) []Interval {
+	result := []Interval{}
+	if size <= 0 || !end.After(start) {
+		return result
+	}
+	if _, ok := offsetUnits(start, unit, 0); !ok {
+		return result
+	}
+
+	chunkStart := start
+	for i := 1; chunkStart.Before(end); i++ {
+		chunkEnd, _ := offsetUnits(start, unit, i*size)
+		if chunkEnd.After(end) {
+			chunkEnd = end
+		}
+		result = append(result, Interval{Start: chunkStart, End: chunkEnd})
+		chunkStart = chunkEnd
+	}
+	return result
+}
+
+// offsetUnits 返回t偏移n个unit后的时间
+// 月、季度、年按日历偏移，目标月份没有对应的日期时取该月最后一天，如1月31日加1个月为2月的最后一天
+func offsetUnits(t time.Time, unit TimeUnit, n int) (time.Time, bool) {
+	switch unit {
+	case Nanosecond:
+		return t.Add(time.Duration(n)), true
+	case Millisecond:
+		return OffsetMillisecond(t, n), true
+	case SecondUnit:
+		return OffsetSecond(t, n), true
+	case MinuteUnit:
+		return OffsetMinute(t, n), true
+	case HourUnit:
+		return OffsetHour(t, n), true
+	case DayUnit:
+		return OffsetDay(t, n), true
+	case WeekUnit:
+		return OffsetWeek(t, n), true
+	case MonthUnit:
+		return addMonthsClamped(t, n), true
+	case QuarterUnit:
+		return addMonthsClamped(t, 3*n), true
+	case YearUnit:
+		return addMonthsClamped(t, 12*n), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// addMonthsClamped 为时间添加指定月数，目标月份天数不足时取该月最后一天
+func addMonthsClamped(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	lastDay := time.Date(year, month+time.Month(months)+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(year, month+time.Month(months), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
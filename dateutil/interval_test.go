@@ -0,0 +1,123 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSplitRange(t *testing.T) {
+	tests := []struct {
+		name  string
+		start time.Time
+		end   time.Time
+		unit  TimeUnit
+		size  int
+		want  []Interval
+	}{{
+		name:  "day into 6-hour chunks",
+		start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		end:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		unit:  HourUnit,
+		size:  6,
+		want: []Interval{
+			{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC)},
+			{time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)},
+			{time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC)},
+			{time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		},
+	}, {
+		name:  "last chunk shorter",
+		start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		end:   time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+		unit:  WeekUnit,
+		size:  1,
+		want: []Interval{
+			{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)},
+			{time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)},
+		},
+	}, {
+		name:  "month end does not drift",
+		start: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		end:   time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC),
+		unit:  MonthUnit,
+		size:  1,
+		want: []Interval{
+			{time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+			{time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)},
+			{time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)},
+		},
+	}, {
+		name:  "quarters",
+		start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		end:   time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+		unit:  QuarterUnit,
+		size:  1,
+		want: []Interval{
+			{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+			{time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}, {
+		name:  "end before start",
+		start: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		end:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		unit:  DayUnit,
+		size:  1,
+		want:  []Interval{},
+	}, {
+		name:  "zero size",
+		start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		end:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		unit:  DayUnit,
+		size:  0,
+		want:  []Interval{},
+	}, {
+		name:  "unsupported unit",
+		start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		end:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		unit:  TimeUnit(-1),
+		size:  1,
+		want:  []Interval{},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitRange(tt.start, tt.end, tt.unit, tt.size)
+			if got == nil || len(got) != len(tt.want) {
+				t.Fatalf("SplitRange() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Start.Equal(tt.want[i].Start) || !got[i].End.Equal(tt.want[i].End) {
+					t.Errorf("SplitRange()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSplitRange_YearIntoMonths(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, end := range []time.Time{
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndOfYear(start),
+	} {
+		got := SplitRange(start, end, MonthUnit, 1)
+		if len(got) != 12 {
+			t.Fatalf("SplitRange(year, month) to %v returned %d intervals, want 12", end, len(got))
+		}
+		for i, interval := range got {
+			wantStart := time.Date(2024, time.Month(i+1), 1, 0, 0, 0, 0, time.UTC)
+			if !interval.Start.Equal(wantStart) {
+				t.Errorf("interval %d starts at %v, want %v", i, interval.Start, wantStart)
+			}
+			if i > 0 && !got[i-1].End.Equal(interval.Start) {
+				t.Errorf("interval %d does not start where interval %d ends", i, i-1)
+			}
+		}
+		if last := got[len(got)-1]; !last.End.Equal(end) {
+			t.Errorf("last interval ends at %v, want %v", last.End, end)
+		}
+		if d := got[1].Duration(); d != 29*24*time.Hour {
+			t.Errorf("February interval Duration() = %v, want %v", d, 29*24*time.Hour)
+		}
+	}
+}
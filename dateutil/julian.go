@@ -0,0 +1,83 @@
+package dateutil
+
+import (
+	"math"
+	"time"
+)
+
+// secondsPerDay 一天的秒数
+const secondsPerDay = 24 * 60 * 60
+
+// ToJulianDay 将时间转换为儒略日（Julian Day），按UTC计算
+// 使用Meeus《天文算法》中的标准算法，日期按公历（含1582年之前的外推公历）解释
+// 儒略日以正午为起点，如2000-01-01 12:00 UTC为2451545.0
+// t: 时间
+// 返回值: 儒略日，小数部分表示一天中的时刻
+func ToJulianDay(t time.Time) float64 {
+	u := t.UTC()
+	year, month := u.Year(), int(u.Month())
+	if month <= 2 {
+		year--
+		month += 12
+	}
+	a := floorDiv(year, 100)
+	b := 2 - a + floorDiv(a, 4)
+
+	elapsed := u.Sub(time.Date(u.Year(), u.Month(), u.Day(), 0, 0, 0, 0, time.UTC))
+	day := float64(u.Day()) + elapsed.Seconds()/secondsPerDay
+
+	return math.Floor(365.25*float64(year+4716)) + math.Floor(30.6001*float64(month+1)) + day + float64(b) - 1524.5
+}
+
+// FromJulianDay 将儒略日转换为UTC时间，是ToJulianDay的逆运算
+// 受float64精度限制，结果四舍五入到毫秒
+// jd: 儒略日
+// 返回值: 对应的UTC时间
+func FromJulianDay(jd float64) time.Time {
+	z := math.Floor(jd + 0.5)
+	f := jd + 0.5 - z
+
+	alpha := math.Floor((z - 1867216.25) / 36524.25)
+	a := z + 1 + alpha - math.Floor(alpha/4)
+	b := a + 1524
+	c := math.Floor((b - 122.1) / 365.25)
+	d := math.Floor(365.25 * c)
+	e := math.Floor((b - d) / 30.6001)
+
+	day := int(b - d - math.Floor(30.6001*e))
+	month := int(e - 1)
+	if e >= 14 {
+		month = int(e - 13)
+	}
+	year := int(c - 4716)
+	if month <= 2 {
+		year = int(c - 4715)
+	}
+
+	elapsed := time.Duration(math.Round(f*secondsPerDay*1000)) * time.Millisecond
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC).Add(elapsed)
+}
+
+// EpochDay 返回t所在日期距1970-01-01的天数，日期按t自身的时区确定
+// t: 时间
+// 返回值: 纪元日，1970-01-01为0，之前的日期为负数
+func EpochDay(t time.Time) int64 {
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return date.Unix() / secondsPerDay
+}
+
+// FromEpochDay 返回距1970-01-01指定天数的日期
+// d: 纪元日，1970-01-01为0
+// 返回值: 对应日期UTC零点的时间
+func FromEpochDay(d int64) time.Time {
+	return time.Unix(d*secondsPerDay, 0).UTC()
+}
+
+// floorDiv 向下取整的整数除法，对负数同样向负无穷取整
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
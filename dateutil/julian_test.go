@@ -0,0 +1,83 @@
+package dateutil
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestToJulianDay(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		want float64
+	}{
+		{"J2000 epoch", time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), 2451545.0},
+		{"unix epoch", time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), 2440587.5},
+		{"Gregorian reform", time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC), 2299160.5},
+		{"Sputnik launch", time.Date(1957, 10, 4, 19, 26, 24, 0, time.UTC), 2436116.31},
+		{"leap day", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), 2460369.5},
+		{"non-UTC location", time.Date(2000, 1, 1, 20, 0, 0, 0, time.FixedZone("CST", 8*3600)), 2451545.0},
+		{"before common era", time.Date(-4712, 1, 1, 12, 0, 0, 0, time.UTC), 38.0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToJulianDay(tt.t); math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("ToJulianDay() = %f, want %f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromJulianDay(t *testing.T) {
+	tests := []struct {
+		name string
+		jd   float64
+		want time.Time
+	}{
+		{"J2000 epoch", 2451545.0, time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{"unix epoch", 2440587.5, time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"Sputnik launch", 2436116.31, time.Date(1957, 10, 4, 19, 26, 24, 0, time.UTC)},
+		{"end of February", 2460370.25, time.Date(2024, 2, 29, 18, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromJulianDay(tt.jd); !got.Equal(tt.want) {
+				t.Errorf("FromJulianDay(%f) = %v, want %v", tt.jd, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJulianDayRoundTrip(t *testing.T) {
+	start := time.Date(1600, 1, 1, 0, 0, 0, 0, time.UTC)
+	for d := start; d.Year() < 2400; d = d.Add(97*24*time.Hour + 3*time.Hour + 17*time.Minute + 123*time.Millisecond) {
+		if got := FromJulianDay(ToJulianDay(d)); !got.Equal(d) {
+			t.Fatalf("FromJulianDay(ToJulianDay(%v)) = %v", d, got)
+		}
+	}
+}
+
+func TestEpochDay(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		want int64
+	}{
+		{"epoch", time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), 0},
+		{"end of epoch day", time.Date(1970, 1, 1, 23, 59, 59, 0, time.UTC), 0},
+		{"day before epoch", time.Date(1969, 12, 31, 12, 0, 0, 0, time.UTC), -1},
+		{"J2000", time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), 10957},
+		{"local date", time.Date(2000, 1, 1, 1, 0, 0, 0, time.FixedZone("CST", 8*3600)), 10957},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EpochDay(tt.t); got != tt.want {
+				t.Errorf("EpochDay() = %d, want %d", got, tt.want)
+			}
+			if got := FromEpochDay(tt.want); EpochDay(got) != tt.want || !got.Equal(BeginOfDay(got)) {
+				t.Errorf("FromEpochDay(%d) = %v", tt.want, got)
+			}
+		})
+	}
+}
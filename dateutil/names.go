@@ -0,0 +1,94 @@
+package dateutil
+
+import (
+	"strings"
+	"time"
+)
+
+// 支持的语言
+const (
+	LangEnglish = "en" // 英文
+	LangChinese = "zh" // 中文
+)
+
+// monthNames 各语言的月份全称，下标0对应一月
+var monthNames = map[string][12]string{
+	LangEnglish: {"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December"},
+	LangChinese: {"一月", "二月", "三月", "四月", "五月", "六月",
+		"七月", "八月", "九月", "十月", "十一月", "十二月"},
+}
+
+// weekdayNames 各语言的星期全称，下标0对应周日
+var weekdayNames = map[string][7]string{
+	LangEnglish: {"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	LangChinese: {"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+}
+
+// MonthName 返回月份在指定语言中的名称
+// m: 月份
+// lang: 语言，LangEnglish或LangChinese
+// 返回值: 月份名称，如"October"、"十月"；月份非法或语言不支持时返回空字符串
+func MonthName(m time.Month, lang string) string {
+	names, ok := monthNames[lang]
+	if !ok || m < time.January || m > time.December {
+		return ""
+	}
+	return names[m-1]
+}
+
+// WeekdayName 返回星期在指定语言中的名称
+// d: 星期
+// lang: 语言，LangEnglish或LangChinese
+// 返回值: 星期名称，如"Thursday"、"星期四"；星期非法或语言不支持时返回空字符串
+func WeekdayName(d time.Weekday, lang string) string {
+	names, ok := weekdayNames[lang]
+	if !ok || d < time.Sunday || d > time.Saturday {
+		return ""
+	}
+	return names[d]
+}
+
+// lookupMonth 按名称反查月份，英文不区分大小写并支持三个字母的缩写（及"Sept"）
+func lookupMonth(name, lang string) (time.Month, bool) {
+	names, ok := monthNames[lang]
+	if !ok {
+		return 0, false
+	}
+	for i, full := range names {
+		if matchName(name, full, lang) || lang == LangEnglish && strings.EqualFold(name, "Sept") && i == 8 {
+			return time.Month(i + 1), true
+		}
+	}
+	return 0, false
+}
+
+// lookupWeekday 按名称反查星期，英文不区分大小写并支持三个字母的缩写，中文同时支持"周X"和"星期天"
+func lookupWeekday(name, lang string) (time.Weekday, bool) {
+	names, ok := weekdayNames[lang]
+	if !ok {
+		return 0, false
+	}
+	if lang == LangChinese {
+		switch {
+		case name == "星期天" || name == "周日" || name == "周天":
+			return time.Sunday, true
+		case strings.HasPrefix(name, "周"):
+			name = "星期" + strings.TrimPrefix(name, "周")
+		}
+	}
+	for i, full := range names {
+		if matchName(name, full, lang) {
+			return time.Weekday(i), true
+		}
+	}
+	return 0, false
+}
+
+// matchName 判断名称是否与全称匹配，英文额外接受三个字母的缩写
+func matchName(name, full, lang string) bool {
+	if lang != LangEnglish {
+		return name == full
+	}
+	return strings.EqualFold(name, full) || strings.EqualFold(name, full[:3])
+}
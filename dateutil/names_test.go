@@ -0,0 +1,50 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMonthName(t *testing.T) {
+	tests := []struct {
+		name  string
+		month time.Month
+		lang  string
+		want  string
+	}{
+		{name: "english", month: time.October, lang: LangEnglish, want: "October"},
+		{name: "chinese", month: time.December, lang: LangChinese, want: "十二月"},
+		{name: "invalid month", month: 13, lang: LangEnglish, want: ""},
+		{name: "unsupported language", month: time.October, lang: "fr", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MonthName(tt.month, tt.lang); got != tt.want {
+				t.Errorf("MonthName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeekdayName(t *testing.T) {
+	tests := []struct {
+		name    string
+		weekday time.Weekday
+		lang    string
+		want    string
+	}{
+		{name: "english", weekday: time.Thursday, lang: LangEnglish, want: "Thursday"},
+		{name: "chinese", weekday: time.Sunday, lang: LangChinese, want: "星期日"},
+		{name: "invalid weekday", weekday: 7, lang: LangChinese, want: ""},
+		{name: "unsupported language", weekday: time.Monday, lang: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WeekdayName(tt.weekday, tt.lang); got != tt.want {
+				t.Errorf("WeekdayName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
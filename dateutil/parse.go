@@ -0,0 +1,340 @@
+package dateutil
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// smartParseLayouts SmartParse依次尝试的候选格式，按常见程度排列
+var smartParseLayouts = []string{
+	"2006-01-02 15:04:05",
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"2006/01/02",
+	"2006年1月2日 15:04:05",
+	"2006年1月2日",
+	"20060102150405",
+	"20060102",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.ANSIC,
+}
+
+// SmartParse 自动识别常见格式并解析时间字符串
+// 依次尝试"yyyy-MM-dd HH:mm:ss"、RFC3339、"yyyy/MM/dd"、"yyyy年M月d日"、"yyyyMMdd"等格式，
+// 返回第一个解析成功的结果；不含时区信息的字符串按UTC解析
+// s: 待解析的字符串，首尾空白会被忽略
+// 返回值: 解析后的时间和可能的错误（空输入或无法识别的格式）
+func SmartParse(s string) (time.Time, error) {
+	t, _, err := SmartParseWithLayout(s)
+	return t, err
+}
+
+// SmartParseWithLayout 与SmartParse相同，同时返回解析成功的格式，便于排查格式混杂的输入
+// s: 待解析的字符串，首尾空白会被忽略
+// 返回值: 解析后的时间、匹配的Go时间格式（如"2006-01-02"、time.RFC3339Nano）和可能的错误；
+// 出错时格式为空字符串
+func SmartParseWithLayout(s string) (time.Time, string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, "", errors.New("empty input string")
+	}
+	for _, layout := range smartParseLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, layout, nil
+		}
+	}
+	return time.Time{}, "", fmt.Errorf("unrecognized time format %q", s)
+}
+
+// ParseRelative 解析相对时间表达式，常用于命令行参数
+// 支持关键字now（base本身）、today（base当天零点）、tomorrow（次日零点）、yesterday（前一日零点），不区分大小写；
+// 支持带正负号的偏移表达式，如"+3d"、"-90m"、"+1w"，单位见ParseExtendedDuration；
+// 其它字符串交给SmartParse按绝对时间解析
+// s: 待解析的字符串
+// base: 相对表达式的参考时间
+// 返回值: 解析后的时间和可能的错误
+func ParseRelative(s string, base time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case "now":
+		return base, nil
+	case "today":
+		return BeginOfDay(base), nil
+	case "tomorrow":
+		return BeginOfDay(AddDaysWallClock(base, 1)), nil
+	case "yesterday":
+		return BeginOfDay(AddDaysWallClock(base, -1)), nil
+	}
+
+	if strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-") {
+		d, err := ParseExtendedDuration(s)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return base.Add(d), nil
+	}
+	return SmartParse(s)
+}
+
+// ParseVerbose 解析包含月份名称和星期名称的日期，如"Thursday, October 5, 2023"、"5 十月 2023"
+// 日、月、年的顺序不限：月份以名称给出，四位数字视为年份，其余不超过31的数字视为日；
+// 数字可带序数后缀（5th）或"日"、"号"；英文名称不区分大小写且支持三个字母的缩写；
+// 星期名称可选，给出时必须与日期实际的星期一致
+// s: 待解析的字符串
+// lang: 语言，LangEnglish或LangChinese
+// 返回值: 解析后的日期（UTC零点）和可能的错误
+func ParseVerbose(s string, lang string) (time.Time, error) {
+	if _, ok := monthNames[lang]; !ok {
+		return time.Time{}, fmt.Errorf("unsupported language %q", lang)
+	}
+	tokens := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '\n' || r == ',' || r == '，'
+	})
+	if len(tokens) == 0 {
+		return time.Time{}, errors.New("empty input string")
+	}
+
+	var (
+		year, day           int
+		month               time.Month
+		weekday             time.Weekday
+		hasWeekday, hasYear bool
+	)
+	for _, token := range tokens {
+		token = strings.TrimSuffix(token, ".")
+		if token[0] >= '0' && token[0] <= '9' {
+			digits := trimNumberSuffix(token)
+			n, err := strconv.Atoi(digits)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("invalid verbose date %q: %w", s, err)
+			}
+			switch {
+			case len(digits) == 4 || n > 31:
+				if hasYear {
+					return time.Time{}, fmt.Errorf("invalid verbose date %q: duplicate year", s)
+				}
+				year, hasYear = n, true
+			case day == 0:
+				day = n
+			default:
+				return time.Time{}, fmt.Errorf("invalid verbose date %q: unexpected number %q", s, token)
+			}
+			continue
+		}
+		if m, ok := lookupMonth(token, lang); ok && month == 0 {
+			month = m
+			continue
+		}
+		if d, ok := lookupWeekday(token, lang); ok && !hasWeekday {
+			weekday, hasWeekday = d, true
+			continue
+		}
+		return time.Time{}, fmt.Errorf("invalid verbose date %q: unrecognized token %q", s, token)
+	}
+
+	if !hasYear || month == 0 || day == 0 {
+		return time.Time{}, fmt.Errorf("invalid verbose date %q: year, month and day are required", s)
+	}
+	t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if t.Day() != day {
+		return time.Time{}, fmt.Errorf("invalid verbose date %q: day out of range", s)
+	}
+	if hasWeekday && t.Weekday() != weekday {
+		return time.Time{}, fmt.Errorf("invalid verbose date %q: %s is a %s", s, t.Format("2006-01-02"), t.Weekday())
+	}
+	return t, nil
+}
+
+// trimNumberSuffix 去除数字的序数后缀（st、nd、rd、th）或"日"、"号"后缀
+func trimNumberSuffix(token string) string {
+	for _, suffix := range []string{"st", "nd", "rd", "th", "日", "号"} {
+		if strings.HasSuffix(token, suffix) {
+			return strings.TrimSuffix(token, suffix)
+		}
+	}
+	return token
+}
+
+// layoutField Parser预处理得到的布局片段：固定宽度的数字字段或字面量
+type layoutField struct {
+	kind    layoutFieldKind // 字段类型
+	literal string          // 字面量内容，仅kind为fieldLiteral时有效
+}
+
+// layoutFieldKind 布局片段的类型
+type layoutFieldKind int
+
+const (
+	fieldLiteral layoutFieldKind = iota // 字面量
+	fieldYear                           // 四位年份"2006"
+	fieldMonth                          // 两位月份"01"
+	fieldDay                            // 两位日期"02"
+	fieldHour                           // 两位24小时制小时"15"
+	fieldMinute                         // 两位分钟"04"
+	fieldSecond                         // 两位秒"05"
+)
+
+// layoutFieldTokens Parser快速路径支持的数字字段及其宽度
+var layoutFieldTokens = []struct {
+	token string
+	kind  layoutFieldKind
+}{
+	{"2006", fieldYear},
+	{"01", fieldMonth},
+	{"02", fieldDay},
+	{"15", fieldHour},
+	{"04", fieldMinute},
+	{"05", fieldSecond},
+}
+
+// Parser 可复用的时间解析器，在创建时预处理布局，适用于以同一布局大量解析的热点路径
+// 布局仅由"2006"、"01"、"02"、"15"、"04"、"05"和分隔符（含"T"及中文等非ASCII字符）组成时，
+// 直接按固定位置读取数字，避免time.Parse每次重新分析布局；其它布局，
+// 以及快速路径无法处理的输入（如带小数秒或非法日期）都回退到time.Parse，结果和错误与time.Parse一致
+// 不含时区信息的字符串按UTC解析；Parser创建后只读，可并发使用
+type Parser struct {
+	layout string        // 原始布局
+	fields []layoutField // 预处理得到的布局片段，为nil表示不支持快速路径
+	length int           // 快速路径要求的输入长度
+}
+
+// NewParser 根据布局创建解析器
+// layout: 与time.Parse相同的布局，如"2006-01-02 15:04:05"
+// 返回值: 解析器实例
+func NewParser(layout string) *Parser {
+	return &Parser{
+		layout: layout,
+		fields: compileLayout(layout),
+		length: len(layout),
+	}
+}
+
+// Parse 按解析器的布局解析字符串，等效于time.Parse(layout, s)
+// s: 待解析的字符串
+// 返回值: 解析后的时间和可能的错误（空输入或格式错误）
+func (p *Parser) Parse(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, errors.New("empty input string")
+	}
+	if t, ok := p.parseFast(s); ok {
+		return t, nil
+	}
+	return time.Parse(p.layout, s)
+}
+
+// parseFast 按预处理的布局直接读取各字段，无法处理时ok为false
+func (p *Parser) parseFast(s string) (t time.Time, ok bool) {
+	if p.fields == nil || len(s) != p.length {
+		return time.Time{}, false
+	}
+
+	year, month, day, hour, minute, second := 0, 1, 1, 0, 0, 0
+	pos := 0
+	for _, f := range p.fields {
+		if f.kind == fieldLiteral {
+			if s[pos:pos+len(f.literal)] != f.literal {
+				return time.Time{}, false
+			}
+			pos += len(f.literal)
+			continue
+		}
+
+		width := 2
+		if f.kind == fieldYear {
+			width = 4
+		}
+		n, valid := atoiFixed(s[pos : pos+width])
+		if !valid {
+			return time.Time{}, false
+		}
+		pos += width
+
+		switch f.kind {
+		case fieldYear:
+			year = n
+		case fieldMonth:
+			month = n
+		case fieldDay:
+			day = n
+		case fieldHour:
+			hour = n
+		case fieldMinute:
+			minute = n
+		case fieldSecond:
+			second = n
+		}
+	}
+
+	if month < 1 || month > 12 || day < 1 || day > daysIn(time.Month(month), year) ||
+		hour > 23 || minute > 59 || second > 59 {
+		return time.Time{}, false
+	}
+	return time.Date(year, time.Month(month), day, hour, minute, second, 0, time.UTC), true
+}
+
+// compileLayout 将布局拆分为数字字段和字面量，布局包含快速路径不支持的元素时返回nil
+func compileLayout(layout string) []layoutField {
+	var fields []layoutField
+	for i := 0; i < len(layout); {
+		matched := false
+		for _, tok := range layoutFieldTokens {
+			if strings.HasPrefix(layout[i:], tok.token) {
+				fields = append(fields, layoutField{kind: tok.kind})
+				i += len(tok.token)
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		c := layout[i]
+		rest := layout[i+1:]
+		afterSecond := len(fields) > 0 && fields[len(fields)-1].kind == fieldSecond
+		switch {
+		case c >= '0' && c <= '9', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z' && c != 'T':
+			return nil // 其它数字和字母可能构成"Jan"、"MST"、"PM"等元素
+		case c == '-' && strings.HasPrefix(rest, "07"),
+			(c == '.' || c == ',') && (strings.HasPrefix(rest, "0") || strings.HasPrefix(rest, "9")),
+			// time.Parse会把秒后面的"."或","加数字当作小数秒读取，如布局"05.2006"
+			(c == '.' || c == ',') && afterSecond && len(rest) > 0 && rest[0] >= '0' && rest[0] <= '9',
+			c == '_' && strings.HasPrefix(rest, "2"):
+			return nil // 时区偏移、小数秒和空格填充的日期
+		}
+
+		if n := len(fields); n > 0 && fields[n-1].kind == fieldLiteral {
+			fields[n-1].literal += layout[i : i+1]
+		} else {
+			fields = append(fields, layoutField{kind: fieldLiteral, literal: layout[i : i+1]})
+		}
+		i++
+	}
+	return fields
+}
+
+// atoiFixed 解析只含ASCII数字的定长字符串
+func atoiFixed(s string) (int, bool) {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, false
+		}
+		n = n*10 + int(s[i]-'0')
+	}
+	return n, true
+}
+
+// daysIn 返回指定年月的天数
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
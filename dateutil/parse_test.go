@@ -0,0 +1,345 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSmartParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    time.Time
+		wantErr bool
+	}{{
+		name: "datetime",
+		s:    "2023-10-05 15:30:45",
+		want: time.Date(2023, 10, 5, 15, 30, 45, 0, time.UTC),
+	}, {
+		name: "date",
+		s:    "2023-10-05",
+		want: time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "rfc3339",
+		s:    "2023-10-05T15:30:45+08:00",
+		want: time.Date(2023, 10, 5, 7, 30, 45, 0, time.UTC),
+	}, {
+		name: "slash date",
+		s:    "2023/10/05",
+		want: time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "chinese date",
+		s:    "2023年10月5日",
+		want: time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "compact date",
+		s:    "20231005",
+		want: time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "surrounding whitespace",
+		s:    "  2023-10-05  ",
+		want: time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:    "empty",
+		s:       "",
+		wantErr: true,
+	}, {
+		name:    "unrecognized",
+		s:       "next tuesday",
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SmartParse(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SmartParse(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("SmartParse(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSmartParseWithLayout(t *testing.T) {
+	tests := []struct {
+		name       string
+		s          string
+		want       time.Time
+		wantLayout string
+		wantErr    bool
+	}{{
+		name:       "date",
+		s:          "2023-10-05",
+		want:       time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC),
+		wantLayout: "2006-01-02",
+	}, {
+		name:       "datetime",
+		s:          "2023-10-05 15:30:45",
+		want:       time.Date(2023, 10, 5, 15, 30, 45, 0, time.UTC),
+		wantLayout: "2006-01-02 15:04:05",
+	}, {
+		name:       "rfc3339",
+		s:          "2023-10-05T15:30:45+08:00",
+		want:       time.Date(2023, 10, 5, 7, 30, 45, 0, time.UTC),
+		wantLayout: time.RFC3339Nano,
+	}, {
+		name:       "chinese date",
+		s:          " 2023年10月5日 ",
+		want:       time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC),
+		wantLayout: "2006年1月2日",
+	}, {
+		name:       "compact datetime",
+		s:          "20231005153045",
+		want:       time.Date(2023, 10, 5, 15, 30, 45, 0, time.UTC),
+		wantLayout: "20060102150405",
+	}, {
+		name:    "empty",
+		s:       "",
+		wantErr: true,
+	}, {
+		name:    "unrecognized",
+		s:       "next tuesday",
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, layout, err := SmartParseWithLayout(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SmartParseWithLayout(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) || layout != tt.wantLayout {
+				t.Errorf("SmartParseWithLayout(%q) = %v, %q, want %v, %q", tt.s, got, layout, tt.want, tt.wantLayout)
+			}
+		})
+	}
+}
+
+func TestParseRelative(t *testing.T) {
+	base := time.Date(2023, 10, 5, 15, 30, 45, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		s       string
+		want    time.Time
+		wantErr bool
+	}{{
+		name: "now",
+		s:    "now",
+		want: base,
+	}, {
+		name: "today",
+		s:    "today",
+		want: time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "tomorrow",
+		s:    "Tomorrow",
+		want: time.Date(2023, 10, 6, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "yesterday",
+		s:    "YESTERDAY",
+		want: time.Date(2023, 10, 4, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "plus one week",
+		s:    "+1w",
+		want: time.Date(2023, 10, 12, 15, 30, 45, 0, time.UTC),
+	}, {
+		name: "minus thirty days",
+		s:    "-30d",
+		want: time.Date(2023, 9, 5, 15, 30, 45, 0, time.UTC),
+	}, {
+		name: "minus ninety minutes",
+		s:    "-90m",
+		want: time.Date(2023, 10, 5, 14, 0, 45, 0, time.UTC),
+	}, {
+		name: "absolute fallback",
+		s:    "2023-01-02",
+		want: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:    "invalid offset",
+		s:       "+3x",
+		wantErr: true,
+	}, {
+		name:    "unrecognized",
+		s:       "someday",
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRelative(tt.s, base)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRelative(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseRelative(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseVerbose(t *testing.T) {
+	want := time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		s       string
+		lang    string
+		want    time.Time
+		wantErr bool
+	}{{
+		name: "english with weekday",
+		s:    "Thursday, October 5, 2023",
+		lang: LangEnglish,
+		want: want,
+	}, {
+		name: "english day first abbreviated",
+		s:    "5th Oct. 2023",
+		lang: LangEnglish,
+		want: want,
+	}, {
+		name: "english lowercase",
+		s:    "thu october 05 2023",
+		lang: LangEnglish,
+		want: want,
+	}, {
+		name: "chinese",
+		s:    "5 十月 2023",
+		lang: LangChinese,
+		want: want,
+	}, {
+		name: "chinese with weekday",
+		s:    "2023 十月 5日 星期四",
+		lang: LangChinese,
+		want: want,
+	}, {
+		name: "chinese short weekday",
+		s:    "周四，5 十月 2023",
+		lang: LangChinese,
+		want: want,
+	}, {
+		name:    "weekday mismatch",
+		s:       "Friday, October 5, 2023",
+		lang:    LangEnglish,
+		wantErr: true,
+	}, {
+		name:    "wrong language",
+		s:       "5 十月 2023",
+		lang:    LangEnglish,
+		wantErr: true,
+	}, {
+		name:    "day out of range",
+		s:       "February 30, 2023",
+		lang:    LangEnglish,
+		wantErr: true,
+	}, {
+		name:    "missing year",
+		s:       "October 5",
+		lang:    LangEnglish,
+		wantErr: true,
+	}, {
+		name:    "unsupported language",
+		s:       "5 octobre 2023",
+		lang:    "fr",
+		wantErr: true,
+	}, {
+		name:    "empty",
+		s:       "  ",
+		lang:    LangEnglish,
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseVerbose(tt.s, tt.lang)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseVerbose() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("ParseVerbose() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParser(t *testing.T) {
+	layouts := []string{
+		"2006-01-02 15:04:05",
+		"2006-01-02",
+		"2006-01-02T15:04:05",
+		"20060102150405",
+		"2006/01/02 15:04",
+		"2006年01月02日",
+		time.RFC3339, // 不支持快速路径，回退到time.Parse
+		"Jan 2, 2006",
+		"15:04:05.2006", // 秒后的"."加数字会被time.Parse当作小数秒
+		"15:04:05,01",
+	}
+	inputs := []string{
+		"2023-10-05 14:30:45",
+		"2023-10-05 14:30:45.123",
+		"2023-10-05",
+		"2024-02-29",
+		"2023-02-29",
+		"2023-13-01",
+		"2023-10-05T14:30:45",
+		"2023-10-05T14:30:45+08:00",
+		"20231005143045",
+		"2023/10/05 24:00",
+		"2023/10/05 23:60",
+		"2023年10月05日",
+		"Oct 5, 2023",
+		"2023-1O-05",
+		"0000-01-01",
+		"abc",
+		"14:30:45.2023",
+		"14:30:45,10",
+	}
+
+	for _, layout := range layouts {
+		p := NewParser(layout)
+		for _, s := range inputs {
+			want, wantErr := time.Parse(layout, s)
+			got, err := p.Parse(s)
+			if (err != nil) != (wantErr != nil) || !got.Equal(want) || got.Location() != want.Location() {
+				t.Errorf("NewParser(%q).Parse(%q) = %v, %v; time.Parse = %v, %v", layout, s, got, err, want, wantErr)
+			}
+		}
+	}
+
+	if _, err := NewParser("2006-01-02").Parse(""); err == nil {
+		t.Error("Parse(\"\") should fail")
+	}
+	for layout, wantFast := range map[string]bool{
+		"2006-01-02 15:04:05":     true,
+		"2006年01月02日":             true,
+		"2006-01-02T15:04:05Z07":  false,
+		"2006-01-02 15:04:05.000": false,
+		"2006-1-2":                false,
+		"Jan _2 2006":             false,
+	} {
+		if fast := NewParser(layout).fields != nil; fast != wantFast {
+			t.Errorf("NewParser(%q) fast path = %v, want %v", layout, fast, wantFast)
+		}
+	}
+}
+
+func BenchmarkTimeParse(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		time.Parse("2006-01-02 15:04:05", "2023-10-05 14:30:45")
+	}
+}
+
+func BenchmarkParseDateTime(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ParseDateTime("2023-10-05 14:30:45")
+	}
+}
+
+func BenchmarkParser_Parse(b *testing.B) {
+	p := NewParser("2006-01-02 15:04:05")
+	for i := 0; i < b.N; i++ {
+		p.Parse("2023-10-05 14:30:45")
+	}
+}
@@ -0,0 +1,72 @@
+package dateutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeOfDay 不含日期的时钟时间，如营业时间中的"09:30"
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+	Second int
+}
+
+// ParseTimeOfDay 解析"HH:MM"或"HH:MM:SS"格式的时钟时间
+// s: 待解析的字符串，小时范围0-23，分钟和秒范围0-59
+// 返回值: 解析得到的时钟时间和可能的错误
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 && len(parts) != 3 {
+		return TimeOfDay{}, fmt.Errorf("invalid time of day %q: expected HH:MM or HH:MM:SS", s)
+	}
+
+	limits := []int{23, 59, 59}
+	values := make([]int, 3)
+	for i, part := range parts {
+		if len(part) != 2 {
+			return TimeOfDay{}, fmt.Errorf("invalid time of day %q: expected HH:MM or HH:MM:SS", s)
+		}
+		v, err := strconv.Atoi(part)
+		if err != nil || v < 0 || v > limits[i] {
+			return TimeOfDay{}, fmt.Errorf("invalid time of day %q: component %q out of range", s, part)
+		}
+		values[i] = v
+	}
+	return TimeOfDay{Hour: values[0], Minute: values[1], Second: values[2]}, nil
+}
+
+// String 返回"HH:MM:SS"格式的字符串
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+}
+
+// Before 判断t是否早于u
+func (t TimeOfDay) Before(u TimeOfDay) bool {
+	return t.seconds() < u.seconds()
+}
+
+// After 判断t是否晚于u
+func (t TimeOfDay) After(u TimeOfDay) bool {
+	return t.seconds() > u.seconds()
+}
+
+// Equal 判断t与u是否相同
+func (t TimeOfDay) Equal(u TimeOfDay) bool {
+	return t.seconds() == u.seconds()
+}
+
+// At 将时钟时间应用到指定日期，使用该日期的时区
+// date: 提供年月日和时区的日期，其时分秒会被忽略
+// 返回值: 该日期对应时钟时间的时间点
+func (t TimeOfDay) At(date time.Time) time.Time {
+	year, month, day := date.Date()
+	return time.Date(year, month, day, t.Hour, t.Minute, t.Second, 0, date.Location())
+}
+
+// seconds 返回距零点的秒数
+func (t TimeOfDay) seconds() int {
+	return t.Hour*3600 + t.Minute*60 + t.Second
+}
@@ -0,0 +1,87 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    TimeOfDay
+		wantErr bool
+	}{{
+		name: "hour and minute",
+		s:    "09:30",
+		want: TimeOfDay{Hour: 9, Minute: 30},
+	}, {
+		name: "with seconds",
+		s:    "23:59:59",
+		want: TimeOfDay{Hour: 23, Minute: 59, Second: 59},
+	}, {
+		name: "midnight",
+		s:    "00:00",
+		want: TimeOfDay{},
+	}, {
+		name:    "hour out of range",
+		s:       "24:00",
+		wantErr: true,
+	}, {
+		name:    "minute out of range",
+		s:       "12:60",
+		wantErr: true,
+	}, {
+		name:    "missing padding",
+		s:       "9:30",
+		wantErr: true,
+	}, {
+		name:    "too many parts",
+		s:       "09:30:00:00",
+		wantErr: true,
+	}, {
+		name:    "not a number",
+		s:       "ab:cd",
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTimeOfDay(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTimeOfDay(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTimeOfDay(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeOfDay_Compare(t *testing.T) {
+	morning := TimeOfDay{Hour: 9, Minute: 30}
+	evening := TimeOfDay{Hour: 18}
+
+	if !morning.Before(evening) || morning.After(evening) {
+		t.Errorf("%v should be before %v", morning, evening)
+	}
+	if !evening.After(morning) || evening.Before(morning) {
+		t.Errorf("%v should be after %v", evening, morning)
+	}
+	if !morning.Equal(TimeOfDay{Hour: 9, Minute: 30}) || morning.Equal(evening) {
+		t.Errorf("Equal() mismatch for %v", morning)
+	}
+	if got := morning.String(); got != "09:30:00" {
+		t.Errorf("String() = %q, want %q", got, "09:30:00")
+	}
+}
+
+func TestTimeOfDay_At(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	date := time.Date(2023, 10, 3, 22, 15, 0, 0, loc)
+	got := TimeOfDay{Hour: 9, Minute: 30, Second: 15}.At(date)
+	want := time.Date(2023, 10, 3, 9, 30, 15, 0, loc)
+	if !got.Equal(want) || got.Location() != loc {
+		t.Errorf("At() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,74 @@
+package dateutil
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// locationCache 已加载的时区，避免重复读取时区数据库
+var locationCache sync.Map // map[string]*time.Location
+
+// ConvertZone 将时间转换到指定时区，表示同一时刻
+// 夏令时由时区规则自动处理，t.Equal(ConvertZone(t, loc))始终为true
+// t: 时间
+// loc: 目标时区，不能为nil
+// 返回值: 目标时区下的时间
+func ConvertZone(t time.Time, loc *time.Location) time.Time {
+	return t.In(loc)
+}
+
+// ConvertZoneName 将时间转换到以IANA名称指定的时区，如"America/New_York"、"Asia/Shanghai"
+// tzName为"UTC"或空字符串时转换为UTC，为"Local"时转换为本地时区
+// t: 时间
+// tzName: 时区名称
+// 返回值: 目标时区下的时间和可能的错误（时区不存在或时区数据库不可用）
+func ConvertZoneName(t time.Time, tzName string) (time.Time, error) {
+	loc, err := loadLocation(tzName)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.In(loc), nil
+}
+
+// ZoneOffset 返回指定时区在时刻t相对UTC的偏移量，已计入夏令时
+// t: 时刻
+// tzName: 时区名称，规则同ConvertZoneName
+// 返回值: 偏移量（东区为正，如北京时间为8小时）和可能的错误
+func ZoneOffset(t time.Time, tzName string) (time.Duration, error) {
+	loc, err := loadLocation(tzName)
+	if err != nil {
+		return 0, err
+	}
+	_, offset := t.In(loc).Zone()
+	return time.Duration(offset) * time.Second, nil
+}
+
+// WorldClock 将同一时刻转换到多个时区并格式化为 yyyy-MM-dd HH:mm:ss，用于同时显示各地的当地时间
+// t: 时刻
+// zones: 时区名称列表，规则同ConvertZoneName，重复的名称只出现一次
+// 返回值: 时区名称到当地时间字符串的映射，以及可能的错误；任一时区无效时返回nil和指明第一个无效时区的错误
+func WorldClock(t time.Time, zones []string) (map[string]string, error) {
+	result := make(map[string]string, len(zones))
+	for _, zone := range zones {
+		loc, err := loadLocation(zone)
+		if err != nil {
+			return nil, err
+		}
+		result[zone] = FormatDateTime(t.In(loc))
+	}
+	return result, nil
+}
+
+// loadLocation 按名称加载时区，加载成功的结果会被缓存
+func loadLocation(name string) (*time.Location, error) {
+	if loc, ok := locationCache.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("load time zone %q: %w", name, err)
+	}
+	locationCache.Store(name, loc)
+	return loc, nil
+}
@@ -0,0 +1,123 @@
+package dateutil
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConvertZoneName(t *testing.T) {
+	if _, err := time.LoadLocation("America/New_York"); err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		t          time.Time
+		tzName     string
+		wantHour   int
+		wantOffset time.Duration
+	}{{
+		name:       "New York during DST",
+		t:          time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC),
+		tzName:     "America/New_York",
+		wantHour:   8,
+		wantOffset: -4 * time.Hour,
+	}, {
+		name:       "New York outside DST",
+		t:          time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC),
+		tzName:     "America/New_York",
+		wantHour:   7,
+		wantOffset: -5 * time.Hour,
+	}, {
+		name:       "Shanghai has no DST",
+		t:          time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC),
+		tzName:     "Asia/Shanghai",
+		wantHour:   20,
+		wantOffset: 8 * time.Hour,
+	}, {
+		name:       "UTC",
+		t:          time.Date(2024, 7, 1, 12, 0, 0, 0, time.FixedZone("CST", 8*3600)),
+		tzName:     "UTC",
+		wantHour:   4,
+		wantOffset: 0,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertZoneName(tt.t, tt.tzName)
+			if err != nil {
+				t.Fatalf("ConvertZoneName() error = %v", err)
+			}
+			if !got.Equal(tt.t) {
+				t.Errorf("ConvertZoneName() = %v, not the same instant as %v", got, tt.t)
+			}
+			if got.Hour() != tt.wantHour {
+				t.Errorf("ConvertZoneName() hour = %d, want %d", got.Hour(), tt.wantHour)
+			}
+			offset, err := ZoneOffset(tt.t, tt.tzName)
+			if err != nil {
+				t.Fatalf("ZoneOffset() error = %v", err)
+			}
+			if offset != tt.wantOffset {
+				t.Errorf("ZoneOffset() = %v, want %v", offset, tt.wantOffset)
+			}
+		})
+	}
+
+	if _, err := ConvertZoneName(time.Now(), "Mars/Olympus_Mons"); err == nil {
+		t.Error("ConvertZoneName() with unknown zone should fail")
+	}
+	if _, err := ZoneOffset(time.Now(), "Mars/Olympus_Mons"); err == nil {
+		t.Error("ZoneOffset() with unknown zone should fail")
+	}
+}
+
+func TestConvertZone(t *testing.T) {
+	utc := time.Date(2024, 3, 10, 6, 59, 0, 0, time.UTC)
+	loc := time.FixedZone("UTC-5", -5*3600)
+	got := ConvertZone(utc, loc)
+	if !got.Equal(utc) || got.Hour() != 1 || got.Location() != loc {
+		t.Errorf("ConvertZone() = %v, want 01:59 in %v", got, loc)
+	}
+}
+
+func TestWorldClock(t *testing.T) {
+	if _, err := time.LoadLocation("America/New_York"); err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	instant := time.Date(2024, 7, 1, 12, 30, 0, 0, time.UTC)
+	zones := []string{"UTC", "Asia/Shanghai", "America/New_York", "Asia/Kolkata", "Pacific/Auckland"}
+	got, err := WorldClock(instant, zones)
+	if err != nil {
+		t.Fatalf("WorldClock() error = %v", err)
+	}
+	want := map[string]string{
+		"UTC":              "2024-07-01 12:30:00",
+		"Asia/Shanghai":    "2024-07-01 20:30:00",
+		"America/New_York": "2024-07-01 08:30:00",
+		"Asia/Kolkata":     "2024-07-01 18:00:00",
+		"Pacific/Auckland": "2024-07-02 00:30:00",
+	}
+	if len(got) != len(want) {
+		t.Errorf("WorldClock() returned %d zones, want %d", len(got), len(want))
+	}
+	for zone, w := range want {
+		if got[zone] != w {
+			t.Errorf("WorldClock()[%q] = %q, want %q", zone, got[zone], w)
+		}
+	}
+
+	got, err = WorldClock(instant, []string{"Asia/Shanghai", "Mars/Olympus", "Nowhere/City"})
+	if err == nil || !strings.Contains(err.Error(), "Mars/Olympus") {
+		t.Errorf("WorldClock() error = %v, want error naming Mars/Olympus", err)
+	}
+	if got != nil {
+		t.Errorf("WorldClock() = %v, want nil on error", got)
+	}
+
+	if got, err := WorldClock(instant, nil); err != nil || len(got) != 0 {
+		t.Errorf("WorldClock(nil) = %v, %v; want empty map", got, err)
+	}
+}
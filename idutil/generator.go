@@ -0,0 +1,123 @@
+package idutil
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Generator ID生成器接口，用于在不同的ID策略之间切换
+// 例如生产环境使用Snowflake，测试环境使用可预测的序列
+type Generator interface {
+	// Next 生成下一个ID
+	Next() (string, error)
+}
+
+// GeneratorFunc 将普通函数适配为Generator
+type GeneratorFunc func() (string, error)
+
+// Next 调用f生成下一个ID
+func (f GeneratorFunc) Next() (string, error) {
+	return f()
+}
+
+// NewUUIDGenerator 返回生成UUID v4的Generator
+func NewUUIDGenerator() Generator {
+	return GeneratorFunc(UUID)
+}
+
+// NewObjectIDGenerator 返回生成ObjectID的Generator
+func NewObjectIDGenerator() Generator {
+	return GeneratorFunc(func() (string, error) {
+		return ObjectID(), nil
+	})
+}
+
+// NewNanoIDGenerator 返回生成NanoID的Generator
+// length与alphabet的含义与NanoID相同，传零值时使用默认值
+func NewNanoIDGenerator(length int, alphabet string) Generator {
+	return GeneratorFunc(func() (string, error) {
+		return NanoID(length, alphabet)
+	})
+}
+
+// Next 生成下一个ULID，实现Generator接口
+func (u *ULIDGenerator) Next() (string, error) {
+	return u.ULID()
+}
+
+// Next 生成下一个KSUID，实现Generator接口
+func (k *KSUIDGenerator) Next() (string, error) {
+	return k.KSUID()
+}
+
+// Next 生成下一个雪花ID并格式化为十进制字符串，实现Generator接口
+func (g *SnowflakeGenerator) Next() (string, error) {
+	id, err := g.NextID()
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(id, 10), nil
+}
+
+// Registry 按名称注册和查找Generator，便于通过配置字符串选择ID策略
+// Registry是并发安全的，零值不可用，请使用NewRegistry创建
+type Registry struct {
+	mu         sync.RWMutex
+	generators map[string]Generator
+}
+
+// NewRegistry 创建一个空的生成器注册表
+func NewRegistry() *Registry {
+	return &Registry{
+		generators: make(map[string]Generator),
+	}
+}
+
+// Register 以指定名称注册生成器
+// 名称为空、生成器为nil或名称已被注册时返回错误
+func (r *Registry) Register(name string, g Generator) error {
+	if name == "" {
+		return errors.New("生成器名称不能为空")
+	}
+	if g == nil {
+		return errors.New("生成器不能为nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.generators[name]; exists {
+		return fmt.Errorf("生成器%q已注册", name)
+	}
+	r.generators[name] = g
+	return nil
+}
+
+// Lookup 按名称查找生成器
+// 名称未注册时返回错误
+func (r *Registry) Lookup(name string) (Generator, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	g, exists := r.generators[name]
+	if !exists {
+		return nil, fmt.Errorf("未注册的生成器%q", name)
+	}
+	return g, nil
+}
+
+// Names 返回所有已注册的生成器名称，按字典序排序
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.generators))
+	for name := range r.generators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,102 @@
+package idutil
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+// TestGeneratorAdapters 测试各生成器适配器的输出格式
+func TestGeneratorAdapters(t *testing.T) {
+	snowflake, err := NewSnowflakeGenerator(1, 1)
+	if err != nil {
+		t.Fatalf("NewSnowflakeGenerator failed: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		generator Generator
+		pattern   string
+	}{
+		{"uuid", NewUUIDGenerator(), `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`},
+		{"ulid", NewULIDGenerator(), `^[0-9A-HJKMNP-TV-Z]{26}$`},
+		{"ksuid", NewKSUIDGenerator(), `^[0-9A-Za-z]{27}$`},
+		{"nanoid", NewNanoIDGenerator(10, "abc"), `^[abc]{10}$`},
+		{"objectid", NewObjectIDGenerator(), `^[0-9a-f]{24}$`},
+		{"snowflake", snowflake, `^[1-9][0-9]*$`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re := regexp.MustCompile(tt.pattern)
+			seen := make(map[string]bool)
+			for i := 0; i < 100; i++ {
+				id, err := tt.generator.Next()
+				if err != nil {
+					t.Fatalf("Next() failed: %v", err)
+				}
+				if !re.MatchString(id) {
+					t.Fatalf("Next() = %q, does not match %s", id, tt.pattern)
+				}
+				if seen[id] {
+					t.Fatalf("Next() generated duplicate ID %q", id)
+				}
+				seen[id] = true
+			}
+		})
+	}
+
+	id, err := snowflake.Next()
+	if err != nil {
+		t.Fatalf("Next() failed: %v", err)
+	}
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		t.Errorf("snowflake Next() = %q, not a valid int64: %v", id, err)
+	}
+}
+
+// TestRegistry 测试生成器注册表
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+
+	seq := 0
+	sequence := GeneratorFunc(func() (string, error) {
+		seq++
+		return strconv.Itoa(seq), nil
+	})
+	uuid := NewUUIDGenerator()
+
+	if err := registry.Register("sequence", sequence); err != nil {
+		t.Fatalf("Register(sequence) failed: %v", err)
+	}
+	if err := registry.Register("uuid", uuid); err != nil {
+		t.Fatalf("Register(uuid) failed: %v", err)
+	}
+
+	g, err := registry.Lookup("sequence")
+	if err != nil {
+		t.Fatalf("Lookup(sequence) failed: %v", err)
+	}
+	for _, want := range []string{"1", "2"} {
+		if id, _ := g.Next(); id != want {
+			t.Errorf("sequence Next() = %q, want %q", id, want)
+		}
+	}
+
+	if names := registry.Names(); len(names) != 2 || names[0] != "sequence" || names[1] != "uuid" {
+		t.Errorf("Names() = %v, want [sequence uuid]", names)
+	}
+
+	if _, err := registry.Lookup("missing"); err == nil {
+		t.Error("Lookup(missing) should return error")
+	}
+	if err := registry.Register("uuid", uuid); err == nil {
+		t.Error("Register duplicate name should return error")
+	}
+	if err := registry.Register("", uuid); err == nil {
+		t.Error("Register empty name should return error")
+	}
+	if err := registry.Register("nil", nil); err == nil {
+		t.Error("Register nil generator should return error")
+	}
+}
@@ -2,12 +2,15 @@ package idutil
 
 import (
 	"crypto/rand"
+	"crypto/sha1"
 	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
 	"net"
 	"os"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -15,7 +18,7 @@ import (
 
 // 相关变量与初始化
 var (
-	objectIDCounter uint32
+	objectIDCounter uint32 // ObjectID计数器，启动时以随机值初始化，只使用低24位
 	machineID       [3]byte
 	processID       [2]byte
 )
@@ -39,6 +42,138 @@ func UUID() (string, error) {
 		uuid[:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:]), nil
 }
 
+// RFC 4122 预定义的命名空间UUID，用于基于名称的UUID(v3/v5)生成
+const (
+	NamespaceDNS  = "6ba7b810-9dad-11d1-80b4-00c04fd430c8" // 名称为完全限定域名
+	NamespaceURL  = "6ba7b811-9dad-11d1-80b4-00c04fd430c8" // 名称为URL
+	NamespaceOID  = "6ba7b812-9dad-11d1-80b4-00c04fd430c8" // 名称为ISO OID
+	NamespaceX500 = "6ba7b814-9dad-11d1-80b4-00c04fd430c8" // 名称为X.500 DN
+)
+
+// UUIDv5 生成基于名称的UUID v5 (RFC 4122)
+// 对命名空间UUID的16字节与名称拼接后做SHA-1摘要，取前16字节并设置版本和变体
+// 相同的命名空间和名称总是得到相同的UUID，适合从URL等稳定输入派生ID
+// namespace: 命名空间UUID字符串，如NamespaceDNS、NamespaceURL
+// name: 名称
+func UUIDv5(namespace string, name string) (string, error) {
+	ns, err := parseUUID(namespace)
+	if err != nil {
+		return "", fmt.Errorf("命名空间无效: %w", err)
+	}
+
+	h := sha1.New()
+	h.Write(ns[:])
+	h.Write([]byte(name))
+	sum := h.Sum(nil)
+
+	var uuid [16]byte
+	copy(uuid[:], sum[:16])
+	uuid[6] = (uuid[6] & 0x0F) | 0x50 // 版本5 (SHA-1)
+	uuid[8] = (uuid[8] & 0x3F) | 0x80 // RFC 4122变体
+
+	return formatUUID(uuid[:]), nil
+}
+
+// base62编码表，按ASCII顺序排列以保证编码结果的字典序与数值大小一致
+const base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// compactUUIDLength 128位数值以base62编码所需的固定长度(62^22 > 2^128)
+const compactUUIDLength = 22
+
+// UUIDCompact 生成紧凑格式的UUID v4
+// 将16字节的UUID以base62编码为22个字符，适合放在URL中
+// 可通过CompactToUUID还原为标准36字符格式
+func UUIDCompact() (string, error) {
+	var uuid [16]byte
+	if _, err := rand.Read(uuid[:]); err != nil {
+		return "", fmt.Errorf("UUID生成失败: %w", err)
+	}
+	uuid[6] = (uuid[6] & 0x0F) | 0x40 // 版本4 (随机)
+	uuid[8] = (uuid[8] & 0x3F) | 0x80 // RFC 4122变体
+
+	return encodeBase62(uuid[:], compactUUIDLength), nil
+}
+
+// CompactToUUID 将紧凑格式的UUID还原为标准36字符格式
+func CompactToUUID(s string) (string, error) {
+	if len(s) != compactUUIDLength {
+		return "", fmt.Errorf("紧凑UUID长度必须为%d", compactUUIDLength)
+	}
+	b, err := decodeBase62(s, 16)
+	if err != nil {
+		return "", err
+	}
+	return formatUUID(b), nil
+}
+
+// UUIDToCompact 将标准36字符格式的UUID转换为紧凑格式
+func UUIDToCompact(uuid string) (string, error) {
+	b, err := parseUUID(uuid)
+	if err != nil {
+		return "", err
+	}
+	return encodeBase62(b[:], compactUUIDLength), nil
+}
+
+// formatUUID 将16字节格式化为8-4-4-4-12的UUID字符串
+func formatUUID(b []byte) string {
+	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x", b[:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// parseUUID 解析8-4-4-4-12格式的UUID字符串为16字节
+func parseUUID(s string) ([16]byte, error) {
+	var b [16]byte
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return b, errors.New("UUID格式无效")
+	}
+	hexStr := s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	if _, err := hex.Decode(b[:], []byte(hexStr)); err != nil {
+		return b, fmt.Errorf("UUID格式无效: %w", err)
+	}
+	return b, nil
+}
+
+// encodeBase62 将字节数组(大端序整数)编码为定长base62字符串，不足位数时左侧补'0'
+func encodeBase62(data []byte, width int) string {
+	num := make([]byte, len(data))
+	copy(num, data)
+
+	result := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		// 对大整数做一次除以62的长除法，余数即为当前位
+		var rem uint32
+		for j := range num {
+			acc := rem<<8 | uint32(num[j])
+			num[j] = byte(acc / 62)
+			rem = acc % 62
+		}
+		result[i] = base62Alphabet[rem]
+	}
+	return string(result)
+}
+
+// decodeBase62 将base62字符串解码为指定字节数的大端序整数
+func decodeBase62(s string, size int) ([]byte, error) {
+	num := make([]byte, size)
+	for i := 0; i < len(s); i++ {
+		idx := strings.IndexByte(base62Alphabet, s[i])
+		if idx < 0 {
+			return nil, fmt.Errorf("非法的base62字符: %q", s[i])
+		}
+		// num = num*62 + idx
+		carry := uint32(idx)
+		for j := size - 1; j >= 0; j-- {
+			acc := uint32(num[j])*62 + carry
+			num[j] = byte(acc)
+			carry = acc >> 8
+		}
+		if carry != 0 {
+			return nil, errors.New("base62数值溢出")
+		}
+	}
+	return num, nil
+}
+
 func init() {
 	// 初始化机器ID(优先使用MAC地址)
 	ifaces, err := net.Interfaces()
@@ -59,10 +194,27 @@ func init() {
 	pid := os.Getpid()
 	processID[0] = byte(pid >> 8)
 	processID[1] = byte(pid)
+
+	seedObjectIDCounter()
+}
+
+// seedObjectIDCounter 按MongoDB规范以随机值初始化ObjectID计数器
+// 避免同时启动的两个进程（机器ID和进程ID也可能相同，如容器中的PID 1）从相同的计数开始
+func seedObjectIDCounter() {
+	var b [4]byte
+	if _, err := rand.Read(b[:3]); err != nil {
+		// 随机源不可用时退化为使用纳秒时间
+		atomic.StoreUint32(&objectIDCounter, uint32(time.Now().UnixNano()))
+		return
+	}
+	atomic.StoreUint32(&objectIDCounter, binary.BigEndian.Uint32(b[:])>>8)
 }
 
 // GenerateObjectID 生成MongoDB风格的ObjectId(24字符十六进制字符串)
 // 结构: 4字节时间戳 + 3字节机器ID + 2字节进程ID + 3字节计数器
+// 计数器在进程启动时随机初始化，每次生成递增1并在2^24处回绕
+// 注意: 同一进程每秒最多生成2^24(约1677万)个互不相同的ID，超过后计数器回绕，
+// 与同一秒内先前生成的ID可能重复
 func ObjectID() string {
 	b := make([]byte, 12)
 
@@ -226,6 +378,89 @@ func ULID() (string, error) {
 	return defaultULIDGenerator.ULID()
 }
 
+// KSUID相关常量
+const (
+	ksuidEpoch      = 1400000000 // KSUID纪元: 2014-05-13 16:53:20 UTC
+	ksuidLength     = 27         // base62编码后的字符数
+	ksuidByteLength = 20         // 4字节时间戳 + 16字节随机负载
+)
+
+// KSUIDGenerator KSUID生成器
+// KSUID (K-Sortable Unique IDentifier) 是一种按时间排序的唯一标识符
+// 格式: 160位 (20字节)，其中32位为自KSUID纪元起的秒数，128位为随机负载
+// 编码后为27个字符的base62字符串，字典序与生成时间一致
+// 同一秒内生成的KSUID通过递增随机负载保证单调递增
+type KSUIDGenerator struct {
+	mu       sync.Mutex
+	lastTime uint32
+	payload  [16]byte // 128位随机负载
+}
+
+var defaultKSUIDGenerator = &KSUIDGenerator{}
+
+// NewKSUIDGenerator 创建新的KSUID生成器
+func NewKSUIDGenerator() *KSUIDGenerator {
+	return &KSUIDGenerator{}
+}
+
+// KSUID 生成一个新的KSUID字符串
+func (k *KSUIDGenerator) KSUID() (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	now := uint32(time.Now().Unix() - ksuidEpoch)
+
+	// 如果当前秒与上次相同，递增随机负载
+	if now == k.lastTime {
+		for i := 15; i >= 0; i-- {
+			k.payload[i]++
+			if k.payload[i] != 0 {
+				break
+			}
+			// 如果所有字节都溢出，则等待下一秒并重新生成负载
+			if i == 0 {
+				time.Sleep(time.Second - time.Duration(time.Now().UnixNano()%1e9))
+				now = uint32(time.Now().Unix() - ksuidEpoch)
+				if _, err := rand.Read(k.payload[:]); err != nil {
+					return "", fmt.Errorf("生成随机数失败: %w", err)
+				}
+			}
+		}
+	} else {
+		if _, err := rand.Read(k.payload[:]); err != nil {
+			return "", fmt.Errorf("生成随机数失败: %w", err)
+		}
+	}
+
+	k.lastTime = now
+
+	// 组合KSUID字节: 32位时间戳 + 128位随机负载
+	var ksuidBytes [ksuidByteLength]byte
+	binary.BigEndian.PutUint32(ksuidBytes[:4], now)
+	copy(ksuidBytes[4:], k.payload[:])
+
+	return encodeBase62(ksuidBytes[:], ksuidLength), nil
+}
+
+// KSUID 生成一个新的KSUID字符串(使用默认生成器)
+func KSUID() (string, error) {
+	return defaultKSUIDGenerator.KSUID()
+}
+
+// ParseKSUIDTime 解析KSUID中的时间戳
+// 返回KSUID生成时的时间(秒级精度)和可能的错误
+func ParseKSUIDTime(s string) (time.Time, error) {
+	if len(s) != ksuidLength {
+		return time.Time{}, fmt.Errorf("KSUID长度必须为%d，实际为%d", ksuidLength, len(s))
+	}
+	b, err := decodeBase62(s, ksuidByteLength)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("解析KSUID失败: %w", err)
+	}
+	ts := binary.BigEndian.Uint32(b[:4])
+	return time.Unix(int64(ts)+ksuidEpoch, 0), nil
+}
+
 // base32编码表 (Crockford Base32)
 const base32Alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
 
@@ -255,6 +490,9 @@ func encodeBase32(data []byte) string {
 	return string(result)
 }
 
+// defaultNanoIDAlphabet NanoID的默认字符集
+const defaultNanoIDAlphabet = "_-.0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
 // NanoID 生成一个安全、紧凑、URL友好的唯一标识符
 // length: ID长度，建议范围6-22，默认21
 // alphabet: 自定义字符集，默认为"_-.0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
@@ -263,7 +501,7 @@ func NanoID(length int, alphabet string) (string, error) {
 		length = 21
 	}
 	if alphabet == "" {
-		alphabet = "_-.0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+		alphabet = defaultNanoIDAlphabet
 	}
 
 	alphabetLen := len(alphabet)
@@ -302,3 +540,61 @@ func NanoID(length int, alphabet string) (string, error) {
 func DefaultNanoID() (string, error) {
 	return NanoID(21, "")
 }
+
+// NanoIDWithChecksum 生成末尾带有校验字符的NanoID，适用于需要人工输入的短ID
+// 校验字符使用Luhn mod N算法基于ID主体计算，可检出所有单字符错误和大部分相邻字符互换
+// length: ID主体长度，不含校验字符，默认21
+// alphabet: 自定义字符集，默认与NanoID相同，不能包含重复字符
+// 返回值: 长度为length+1的ID和可能的错误
+func NanoIDWithChecksum(length int, alphabet string) (string, error) {
+	if alphabet == "" {
+		alphabet = defaultNanoIDAlphabet
+	}
+	for i := 0; i < len(alphabet); i++ {
+		if strings.IndexByte(alphabet[i+1:], alphabet[i]) >= 0 {
+			return "", errors.New("字符集不能包含重复字符")
+		}
+	}
+
+	body, err := NanoID(length, alphabet)
+	if err != nil {
+		return "", err
+	}
+	return body + string(alphabet[luhnModN(body, alphabet, 2)]), nil
+}
+
+// VerifyChecksum 校验NanoIDWithChecksum生成的ID
+// id: 待校验的ID，最后一个字符为校验字符
+// alphabet: 生成ID时使用的字符集，为空时使用默认字符集
+// 返回值: ID的所有字符都在字符集中且校验通过时返回true
+func VerifyChecksum(id, alphabet string) bool {
+	if alphabet == "" {
+		alphabet = defaultNanoIDAlphabet
+	}
+	if len(id) < 2 {
+		return false
+	}
+	return luhnModN(id, alphabet, 1) == 0
+}
+
+// luhnModN 按Luhn mod N算法从右向左计算加权和，firstFactor为最右侧字符的权重
+// firstFactor为2时返回应追加的校验字符下标；为1时对含校验字符的ID返回0表示校验通过
+// 存在不在字符集中的字符时返回-1
+func luhnModN(s, alphabet string, firstFactor int) int {
+	n := len(alphabet)
+	factor := firstFactor
+	sum := 0
+	for i := len(s) - 1; i >= 0; i-- {
+		codePoint := strings.IndexByte(alphabet, s[i])
+		if codePoint < 0 {
+			return -1
+		}
+		addend := factor * codePoint
+		sum += addend/n + addend%n
+		factor = 3 - factor // 权重在2和1之间交替
+	}
+	if firstFactor == 1 {
+		return sum % n
+	}
+	return (n - sum%n) % n
+}
@@ -69,6 +69,115 @@ func TestUUID(t *testing.T) {
 	}
 }
 
+// TestUUIDCompact 测试紧凑UUID的生成与还原
+func TestUUIDCompact(t *testing.T) {
+	compact, err := UUIDCompact()
+	if err != nil {
+		t.Fatalf("UUIDCompact() failed: %v", err)
+	}
+	if len(compact) != 22 {
+		t.Errorf("compact UUID length should be 22, got %d", len(compact))
+	}
+	for _, c := range compact {
+		if !strings.ContainsRune(base62Alphabet, c) {
+			t.Errorf("compact UUID contains invalid character: %c", c)
+		}
+	}
+
+	// 紧凑格式 -> 标准格式 -> 紧凑格式
+	canonical, err := CompactToUUID(compact)
+	if err != nil {
+		t.Fatalf("CompactToUUID(%s) failed: %v", compact, err)
+	}
+	if len(canonical) != 36 || canonical[14] != '4' {
+		t.Errorf("canonical UUID format incorrect: %s", canonical)
+	}
+	back, err := UUIDToCompact(canonical)
+	if err != nil {
+		t.Fatalf("UUIDToCompact(%s) failed: %v", canonical, err)
+	}
+	if back != compact {
+		t.Errorf("round trip mismatch: %s != %s", back, compact)
+	}
+
+	// 边界值
+	tests := []struct {
+		uuid    string
+		compact string
+	}{
+		{"00000000-0000-0000-0000-000000000000", "0000000000000000000000"},
+		{"ffffffff-ffff-ffff-ffff-ffffffffffff", "7n42DGM5Tflk9n8mt7Fhc7"},
+	}
+	for _, tt := range tests {
+		got, err := UUIDToCompact(tt.uuid)
+		if err != nil || got != tt.compact {
+			t.Errorf("UUIDToCompact(%s) = %s, %v; want %s", tt.uuid, got, err, tt.compact)
+		}
+		got, err = CompactToUUID(tt.compact)
+		if err != nil || got != tt.uuid {
+			t.Errorf("CompactToUUID(%s) = %s, %v; want %s", tt.compact, got, err, tt.uuid)
+		}
+	}
+
+	// 非法输入
+	invalid := []string{"", "abc", "000000000000000000000!", "zzzzzzzzzzzzzzzzzzzzzz"}
+	for _, s := range invalid {
+		if _, err := CompactToUUID(s); err == nil {
+			t.Errorf("CompactToUUID(%q) should fail", s)
+		}
+	}
+	if _, err := UUIDToCompact("not-a-uuid"); err == nil {
+		t.Error("UUIDToCompact(not-a-uuid) should fail")
+	}
+}
+
+// TestUUIDv5 测试基于名称的UUID v5生成功能
+func TestUUIDv5(t *testing.T) {
+	// 已知向量(RFC 9562 附录A.4 及Python uuid模块)
+	tests := []struct {
+		namespace string
+		name      string
+		want      string
+	}{
+		{NamespaceDNS, "www.example.com", "2ed6657d-e927-568b-95e1-2665a8aea6a2"},
+		{NamespaceDNS, "python.org", "886313e1-3b8a-5372-9b90-0c9aee199e5d"},
+		{NamespaceURL, "https://example.com/", "dd2c1780-811a-5296-81c5-178a0ef488bc"},
+	}
+	for _, tt := range tests {
+		got, err := UUIDv5(tt.namespace, tt.name)
+		if err != nil {
+			t.Fatalf("UUIDv5(%s, %s) failed: %v", tt.namespace, tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("UUIDv5(%s, %s) = %s, want %s", tt.namespace, tt.name, got, tt.want)
+		}
+	}
+
+	// 相同输入多次调用结果一致，且版本号为5
+	first, _ := UUIDv5(NamespaceURL, "https://github.com/luckxgo/go-utils")
+	second, _ := UUIDv5(NamespaceURL, "https://github.com/luckxgo/go-utils")
+	if first != second {
+		t.Errorf("UUIDv5 should be deterministic: %s != %s", first, second)
+	}
+	if first[14] != '5' {
+		t.Errorf("UUIDv5 version nibble should be 5, got %c", first[14])
+	}
+	if v := first[19]; v != '8' && v != '9' && v != 'a' && v != 'b' {
+		t.Errorf("UUIDv5 variant incorrect: %s", first)
+	}
+
+	// 不同名称得到不同结果
+	other, _ := UUIDv5(NamespaceURL, "https://github.com/luckxgo")
+	if other == first {
+		t.Error("different names should produce different UUIDs")
+	}
+
+	// 非法命名空间
+	if _, err := UUIDv5("invalid", "name"); err == nil {
+		t.Error("UUIDv5 with invalid namespace should fail")
+	}
+}
+
 // TestObjectID 测试ObjectID生成功能
 func TestObjectID(t *testing.T) {
 	// 测试基本生成功能
@@ -118,6 +227,43 @@ func TestObjectID(t *testing.T) {
 	}
 }
 
+// TestObjectIDCounterSeed 测试计数器在启动时随机初始化，两个"新进程"的计数不会从同一个值开始
+func TestObjectIDCounterSeed(t *testing.T) {
+	defer seedObjectIDCounter()
+
+	// 模拟两个同时启动的进程各自生成一批ID，记录其计数器部分
+	const perProcess = 100
+	counters := func() map[string]bool {
+		seedObjectIDCounter()
+		result := make(map[string]bool, perProcess)
+		for i := 0; i < perProcess; i++ {
+			result[ObjectID()[18:]] = true
+		}
+		return result
+	}
+	first, second := counters(), counters()
+
+	overlap := 0
+	for c := range second {
+		if first[c] {
+			overlap++
+		}
+	}
+	if overlap > 0 {
+		t.Errorf("Two freshly seeded processes produced %d overlapping counters", overlap)
+	}
+
+	// 计数器不应固定从1开始
+	seeds := make(map[uint32]bool)
+	for i := 0; i < 5; i++ {
+		seedObjectIDCounter()
+		seeds[objectIDCounter] = true
+	}
+	if len(seeds) < 2 {
+		t.Errorf("seedObjectIDCounter produced the same seed %v every time", seeds)
+	}
+}
+
 // TestSnowflakeGenerator 测试雪花算法生成器
 func TestSnowflakeGenerator(t *testing.T) {
 	// 测试创建生成器
@@ -323,6 +469,57 @@ func TestULID_Concurrency(t *testing.T) {
 	}
 }
 
+// TestKSUID 测试KSUID生成、排序性和时间戳解析
+func TestKSUID(t *testing.T) {
+	before := time.Now()
+	ksuid, err := KSUID()
+	if err != nil {
+		t.Fatalf("KSUID生成失败: %v", err)
+	}
+	if len(ksuid) != 27 {
+		t.Errorf("KSUID长度应为27，实际为%d", len(ksuid))
+	}
+	// 验证字符集
+	for _, c := range ksuid {
+		if !strings.ContainsRune(base62Alphabet, c) {
+			t.Errorf("KSUID包含无效字符: %c", c)
+		}
+	}
+
+	// 测试时间戳解析
+	ts, err := ParseKSUIDTime(ksuid)
+	if err != nil {
+		t.Fatalf("解析KSUID时间失败: %v", err)
+	}
+	if diff := ts.Sub(before); diff < -2*time.Second || diff > 2*time.Second {
+		t.Errorf("KSUID时间戳偏差过大: 生成于%v, 解析为%v", before, ts)
+	}
+
+	// 测试排序性（同一秒内也应单调递增）
+	var prevKSUID string
+	for i := 0; i < 1000; i++ {
+		currentKSUID, err := KSUID()
+		if err != nil {
+			t.Fatalf("KSUID生成失败: %v", err)
+		}
+		if i > 0 && currentKSUID <= prevKSUID {
+			t.Errorf("KSUID未按预期排序: 前一个=%s, 当前=%s", prevKSUID, currentKSUID)
+		}
+		prevKSUID = currentKSUID
+	}
+
+	// 测试非法输入
+	if _, err := ParseKSUIDTime("short"); err == nil {
+		t.Error("解析长度错误的KSUID应返回错误")
+	}
+	if _, err := ParseKSUIDTime(strings.Repeat("-", 27)); err == nil {
+		t.Error("解析包含非法字符的KSUID应返回错误")
+	}
+	if _, err := ParseKSUIDTime(strings.Repeat("z", 27)); err == nil {
+		t.Error("解析溢出的KSUID应返回错误")
+	}
+}
+
 // TestNanoID 测试NanoID生成功能
 func TestNanoID(t *testing.T) {
 	// 测试默认参数
@@ -423,3 +620,58 @@ func TestNanoID_Concurrency(t *testing.T) {
 		t.Errorf("生成的NanoID数量不正确: 预期=%d, 实际=%d", concurrency*idsPerGoroutine, count)
 	}
 }
+
+// TestNanoIDWithChecksum 测试带校验字符的NanoID
+func TestNanoIDWithChecksum(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		id, err := NanoIDWithChecksum(10, "")
+		if err != nil {
+			t.Fatalf("带校验字符的NanoID生成失败: %v", err)
+		}
+		if len(id) != 11 {
+			t.Fatalf("带校验字符的NanoID长度应为11，实际为%d", len(id))
+		}
+		if !VerifyChecksum(id, "") {
+			t.Fatalf("生成的ID %s 校验失败", id)
+		}
+
+		// 修改任意一个字符都应校验失败
+		for pos := 0; pos < len(id); pos++ {
+			flipped := []byte(id)
+			idx := strings.IndexByte(defaultNanoIDAlphabet, flipped[pos])
+			flipped[pos] = defaultNanoIDAlphabet[(idx+1+i%10)%len(defaultNanoIDAlphabet)]
+			if VerifyChecksum(string(flipped), "") {
+				t.Errorf("修改第%d个字符后的ID %s 不应通过校验（原ID %s）", pos, flipped, id)
+			}
+		}
+	}
+
+	// 相邻字符互换应校验失败
+	alphabet := "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	id := "7K3QX9P2"
+	id += string(alphabet[luhnModN(id, alphabet, 2)])
+	if !VerifyChecksum(id, alphabet) {
+		t.Fatalf("ID %s 校验失败", id)
+	}
+	for pos := 0; pos+1 < len(id); pos++ {
+		swapped := []byte(id)
+		swapped[pos], swapped[pos+1] = swapped[pos+1], swapped[pos]
+		if VerifyChecksum(string(swapped), alphabet) {
+			t.Errorf("互换第%d、%d个字符后的ID %s 不应通过校验", pos, pos+1, swapped)
+		}
+	}
+
+	// 无效输入
+	if VerifyChecksum("7K3Q-X", alphabet) {
+		t.Error("包含字符集外字符的ID不应通过校验")
+	}
+	if VerifyChecksum("0", alphabet) {
+		t.Error("长度不足的ID不应通过校验")
+	}
+	if _, err := NanoIDWithChecksum(10, "abca"); err == nil {
+		t.Error("预期字符集包含重复字符错误，但未收到")
+	}
+	if _, err := NanoIDWithChecksum(10, "a"); err == nil {
+		t.Error("预期字符集长度不足错误，但未收到")
+	}
+}
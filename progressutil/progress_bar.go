@@ -8,6 +8,19 @@ import (
 	"sync"
 )
 
+// RenderMode controls how the progress is rendered.
+type RenderMode int
+
+const (
+	// BarMode renders a bar with a percentage, overwriting the current line. This is the default.
+	BarMode RenderMode = iota
+	// PercentMode renders only the percentage, e.g. "42%", one line per render.
+	PercentMode
+	// CountMode renders current/total, e.g. "42/100", one line per render.
+	// With WithBytes(true) the humanized byte counts follow, e.g. "42/100 (1.2MB/1.0GB)".
+	CountMode
+)
+
 // ProgressBar represents a progress bar that can be rendered to an output stream.
 type ProgressBar struct {
 	total   int
@@ -16,14 +29,36 @@ type ProgressBar struct {
 	fill    string
 	empty   string
 	output  io.Writer
+	mode    RenderMode
+	bytes   bool
 	mu      sync.Mutex
 }
 
+// Option configures optional behavior of a ProgressBar.
+type Option func(*ProgressBar)
+
+// WithMode sets the render mode. The default is BarMode.
+// PercentMode and CountMode write each render on its own line, which suits
+// CI logs that do not handle carriage returns well.
+func WithMode(mode RenderMode) Option {
+	return func(p *ProgressBar) {
+		p.mode = mode
+	}
+}
+
+// WithBytes treats current and total as byte counts and humanizes them in CountMode.
+func WithBytes(enabled bool) Option {
+	return func(p *ProgressBar) {
+		p.bytes = enabled
+	}
+}
+
 // NewProgressBar creates a new progress bar with the specified total value, width, fill and empty characters, and output writer.
 // If fill is empty, it defaults to "=".
 // If empty is empty, it defaults to " ".
 // If output is nil, it defaults to os.Stdout.
-func NewProgressBar(total int, width int, fill, empty string, output io.Writer) *ProgressBar {
+// Options such as WithMode and WithBytes can be used to change how the progress is rendered.
+func NewProgressBar(total int, width int, fill, empty string, output io.Writer, options ...Option) *ProgressBar {
 	if fill == "" {
 		fill = "="
 	}
@@ -33,13 +68,17 @@ func NewProgressBar(total int, width int, fill, empty string, output io.Writer)
 	if output == nil {
 		output = os.Stdout
 	}
-	return &ProgressBar{
+	p := &ProgressBar{
 		total:  total,
 		width:  width,
 		fill:   fill,
 		empty:  empty,
 		output: output,
 	}
+	for _, option := range options {
+		option(p)
+	}
+	return p
 }
 
 // SetProgress sets the current progress to the specified value.
@@ -73,13 +112,33 @@ func (p *ProgressBar) Increment() error {
 }
 
 // Render writes the progress bar to the output stream.
-// The progress bar is rendered as a single line, overwriting the current line.
+// In BarMode the progress bar is rendered as a single line, overwriting the current line.
 // When progress is complete (current == total), a newline is added.
+// In PercentMode and CountMode each render is written as its own line.
 func (p *ProgressBar) Render() error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	percent := float64(p.current) / float64(p.total) * 100
+	switch p.mode {
+	case PercentMode:
+		percent := 100
+		if p.total > 0 {
+			percent = p.current * 100 / p.total
+		}
+		return p.renderLine(fmt.Sprintf("%d%%", percent))
+	case CountMode:
+		text := fmt.Sprintf("%d/%d", p.current, p.total)
+		if p.bytes {
+			text += " (" + humanizeBytes(p.current) + "/" + humanizeBytes(p.total) + ")"
+		}
+		return p.renderLine(text)
+	}
+
+	// A non-positive total has nothing left to do and is rendered as complete.
+	percent := 100.0
+	if p.total > 0 {
+		percent = float64(p.current) / float64(p.total) * 100
+	}
 	filled := int(percent / 100 * float64(p.width))
 	bar := strings.Repeat(p.fill, filled) + strings.Repeat(p.empty, p.width-filled)
 
@@ -94,6 +153,31 @@ func (p *ProgressBar) Render() error {
 	return err
 }
 
+// renderLine writes text as a standalone line, marking completion with " done!".
+func (p *ProgressBar) renderLine(text string) error {
+	if p.current == p.total {
+		text += " done!"
+	}
+	_, err := fmt.Fprintln(p.output, text)
+	return err
+}
+
+// humanizeBytes formats a byte count using binary units, e.g. 1536 -> "1.5KB".
+func humanizeBytes(n int) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	value := float64(n)
+	units := []string{"KB", "MB", "GB", "TB", "PB"}
+	i := -1
+	for value >= unit && i < len(units)-1 {
+		value /= unit
+		i++
+	}
+	return fmt.Sprintf("%.1f%s", value, units[i])
+}
+
 // Show sets the current progress and immediately renders the progress bar to the output stream.
 // It combines the functionality of SetProgress and Render in a single method call.
 // Returns any error encountered while setting progress or rendering.
@@ -171,6 +171,93 @@ func TestShow(t *testing.T) {
 	}
 }
 
+func TestRenderModes(t *testing.T) {
+	tests := []struct {
+		name       string
+		total      int
+		current    int
+		options    []Option
+		wantOutput string
+	}{{
+		name:       "bar mode",
+		total:      100,
+		current:    50,
+		options:    []Option{WithMode(BarMode)},
+		wantOutput: "\r[=====     ] 50.00%",
+	}, {
+		name:       "percent mode",
+		total:      100,
+		current:    50,
+		options:    []Option{WithMode(PercentMode)},
+		wantOutput: "50%\n",
+	}, {
+		name:       "count mode",
+		total:      100,
+		current:    50,
+		options:    []Option{WithMode(CountMode)},
+		wantOutput: "50/100\n",
+	}, {
+		name:       "count mode with bytes",
+		total:      2 << 30,
+		current:    1 << 30,
+		options:    []Option{WithMode(CountMode), WithBytes(true)},
+		wantOutput: "1073741824/2147483648 (1.0GB/2.0GB)\n",
+	}, {
+		name:       "percent mode complete",
+		total:      100,
+		current:    100,
+		options:    []Option{WithMode(PercentMode)},
+		wantOutput: "100% done!\n",
+	}, {
+		name:       "percent mode zero total",
+		total:      0,
+		current:    0,
+		options:    []Option{WithMode(PercentMode)},
+		wantOutput: "100% done!\n",
+	}, {
+		name:       "bar mode zero total",
+		total:      0,
+		current:    0,
+		options:    []Option{WithMode(BarMode)},
+		wantOutput: "\r[==========] 100.00% done!\n",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			pb := NewProgressBar(tt.total, 10, "=", " ", buf, tt.options...)
+			if err := pb.Show(tt.current); err != nil {
+				t.Fatalf("Show() error = %v", err)
+			}
+			if got := buf.String(); got != tt.wantOutput {
+				t.Errorf("output = %q, want %q", got, tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestHumanizeBytes(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "0B"},
+		{1023, "1023B"},
+		{1024, "1.0KB"},
+		{1536, "1.5KB"},
+		{1258291, "1.2MB"},
+		{1 << 30, "1.0GB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := humanizeBytes(tt.n); got != tt.want {
+				t.Errorf("humanizeBytes(%d) = %q, want %q", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestShow2(t *testing.T) {
 	// 写个模拟下载的进度条
 	pb := NewProgressBar(100, 10, "=", " ", nil)
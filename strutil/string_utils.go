@@ -1,13 +1,25 @@
 package strutil
 
 import (
+	"bufio"
+	"bytes"
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/url"
+	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 // IsEmpty 判断字符串是否为空（长度为0）
@@ -135,6 +147,76 @@ func CharCount(s string, caseSensitive bool) map[rune]int {
 	return counts
 }
 
+// WordFreq 单词及其出现次数
+type WordFreq struct {
+	Word  string
+	Count int
+}
+
+// WordFrequency 统计单词出现频率，不区分大小写
+// 参数:
+//
+//	s - 待统计的字符串
+//	separators - 可变参数，自定义分隔符集合，未提供时与WordCount一致默认使用空格
+//
+// 返回值:
+//
+//	小写单词到出现次数的映射
+//
+// 示例:
+//
+//	WordFrequency("Go go gopher") → map[string]int{"go":2, "gopher":1}
+//	WordFrequency("a,b,A", ',') → map[string]int{"a":2, "b":1}
+func WordFrequency(s string, separators ...rune) map[string]int {
+	if len(separators) == 0 {
+		separators = []rune{' '}
+	}
+
+	counts := make(map[string]int)
+	for _, word := range Split(s, separators...) {
+		counts[strings.ToLower(word)]++
+	}
+	return counts
+}
+
+// TopWords 返回出现次数最多的n个单词，不区分大小写
+// 结果按出现次数降序排列，次数相同时按单词字典序升序排列，保证结果稳定
+// 参数:
+//
+//	s - 待统计的字符串
+//	n - 返回的单词数量，不足n个时返回全部，n <= 0时返回空切片
+//	separators - 可变参数，自定义分隔符集合，未提供时默认使用空格
+//
+// 返回值:
+//
+//	按频率排序的单词列表
+//
+// 示例:
+//
+//	TopWords("the cat and the hat", 2) → [{the 2} {and 1}]
+func TopWords(s string, n int, separators ...rune) []WordFreq {
+	if n <= 0 {
+		return []WordFreq{}
+	}
+
+	counts := WordFrequency(s, separators...)
+	result := make([]WordFreq, 0, len(counts))
+	for word, count := range counts {
+		result = append(result, WordFreq{Word: word, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Word < result[j].Word
+	})
+
+	if len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
 // IsPalindrome 判断字符串是否为回文（正读反读一致）
 // 参数:
 //
@@ -149,6 +231,16 @@ func CharCount(s string, caseSensitive bool) map[rune]int {
 //	IsPalindrome("abba") → true
 //	IsPalindrome("abc") → false
 func IsPalindrome(s string) bool {
+	// 纯ASCII字符串按字节比较，避免分配[]rune
+	if isASCII(s) {
+		for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+			if s[i] != s[j] {
+				return false
+			}
+		}
+		return true
+	}
+
 	runes := []rune(s)
 	n := len(runes)
 	for i := 0; i < n/2; i++ {
@@ -280,6 +372,42 @@ func TrimAll(s string) string {
 	return builder.String()
 }
 
+// NormalizeWhitespace 规范化字符串中的空白字符，便于忽略排版差异进行比较
+// 去除两端空白，并将内部连续的空白字符（包括\n、\r\n、\r等换行符）合并为单个空格
+// 与TrimAll不同，单词之间保留一个分隔空格
+// 参数:
+//
+//	s - 待处理的字符串
+//
+// 返回值:
+//
+//	规范化后的新字符串
+//
+// 示例:
+//
+//	NormalizeWhitespace("a\t\tb\n c") → "a b c"
+//	NormalizeWhitespace("  key =\r\n  value ") → "key = value"
+func NormalizeWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// EqualsIgnoreWhitespace 判断两个字符串在规范化空白后是否相等
+// 参数:
+//
+//	a, b - 待比较的字符串
+//
+// 返回值:
+//
+//	NormalizeWhitespace(a) == NormalizeWhitespace(b)时返回true
+//
+// 示例:
+//
+//	EqualsIgnoreWhitespace("a  b", "a\tb\n") → true
+//	EqualsIgnoreWhitespace("ab", "a b") → false
+func EqualsIgnoreWhitespace(a, b string) bool {
+	return NormalizeWhitespace(a) == NormalizeWhitespace(b)
+}
+
 // Substring 安全地截取子字符串，支持负索引（从末尾开始计数）
 // start 起始索引（包含），end 结束索引（不包含），如果为负数则从末尾开始计算
 func Substring(s string, start, end int) (string, error) {
@@ -355,6 +483,77 @@ func Split(s string, separators ...rune) []string {
 	return result
 }
 
+// SplitRespectingQuotes 按空白分割字符串，引号内的空白不作为分隔符，规则与POSIX shell相似
+// 双引号内的反斜杠可转义任意字符（如\"和\\），单引号内的内容按字面处理；
+// 引号外的反斜杠同样转义下一个字符；相邻的引号部分与普通文本拼接为同一个词，
+// 如a"b c"d → "ab cd"；空引号""产生一个空字符串
+// 参数:
+//
+//	s - 待分割的字符串
+//
+// 返回值:
+//
+//	分割后的字符串切片；引号未闭合或末尾有未转义字符的反斜杠时返回错误
+//
+// 示例:
+//
+//	SplitRespectingQuotes(`say "hello world" now`) → ["say", "hello world", "now"], nil
+//	SplitRespectingQuotes(`echo "a \"quoted\" word"`) → ["echo", `a "quoted" word`], nil
+//	SplitRespectingQuotes(`say 'oops`) → nil, error
+func SplitRespectingQuotes(s string) ([]string, error) {
+	result := []string{}
+	var builder strings.Builder
+	inToken := false // 当前是否处于一个词中，用于保留空引号产生的空字符串
+	var quote rune   // 当前所在的引号，0表示不在引号内
+	escaped := false
+
+	for _, c := range s {
+		switch {
+		case escaped:
+			builder.WriteRune(c)
+			escaped = false
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+			} else {
+				builder.WriteRune(c)
+			}
+		case c == '\\':
+			escaped = true
+			inToken = true
+		case quote == '"':
+			if c == '"' {
+				quote = 0
+			} else {
+				builder.WriteRune(c)
+			}
+		case c == '"' || c == '\'':
+			quote = c
+			inToken = true
+		case unicode.IsSpace(c):
+			if inToken {
+				result = append(result, builder.String())
+				builder.Reset()
+				inToken = false
+			}
+		default:
+			builder.WriteRune(c)
+			inToken = true
+		}
+	}
+
+	if escaped {
+		return nil, errors.New("trailing backslash")
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inToken {
+		result = append(result, builder.String())
+	}
+	return result, nil
+}
+
 // Join 连接字符串数组，使用指定的分隔符
 // 参数:
 //
@@ -378,6 +577,72 @@ func Equals(a, b string) bool {
 	return a == b
 }
 
+// SecureEquals 以常量时间比较两个字符串，用于比较密钥、令牌等敏感数据
+// 与 Equals(==) 不同，比较耗时不依赖于内容在哪个位置首次不同，可防御计时攻击
+// 注意: 与 subtle.ConstantTimeCompare 一样，长度不同时会立即返回，仍会泄露长度是否相同；
+// 如需隐藏长度信息，可先对两端做哈希（如SHA-256）再比较固定长度的摘要
+// 示例:
+//
+//	SecureEquals("token", "token") → true
+//	SecureEquals("token", "tokem") → false
+func SecureEquals(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// Normalize 将字符串转换为指定的Unicode规范化形式
+// 视觉上相同的字符可能有不同的编码，如"é"既可以是单个码点U+00E9，
+// 也可以是"e"加组合重音符U+0301，规范化后才能正确比较
+// 参数:
+//
+//	s - 待规范化的字符串
+//	form - 规范化形式，支持"NFC"、"NFD"、"NFKC"、"NFKD"（不区分大小写）
+//
+// 返回值:
+//
+//	规范化后的字符串，form不受支持时原样返回s
+//
+// 示例:
+//
+//	Normalize("e\u0301", "NFC") → "\u00e9"
+//	Normalize("\u00e9", "NFD") → "e\u0301"
+//	Normalize("\ufb01", "NFKC") → "fi"
+func Normalize(s string, form string) string {
+	var f norm.Form
+	switch strings.ToUpper(form) {
+	case "NFC":
+		f = norm.NFC
+	case "NFD":
+		f = norm.NFD
+	case "NFKC":
+		f = norm.NFKC
+	case "NFKD":
+		f = norm.NFKD
+	default:
+		return s
+	}
+	return f.String(s)
+}
+
+// EqualsNormalized 判断两个字符串在NFC规范化后是否相等
+// 可用于比较组合方式不同但视觉上相同的用户输入
+// 注意: NFC只统一规范等价的字符，"\ufb01"与"fi"这类兼容等价字符仍视为不同，
+// 如需将其视为相同，可使用Normalize(s, "NFKC")后再比较
+// 参数:
+//
+//	a, b - 待比较的字符串
+//
+// 返回值:
+//
+//	Normalize(a, "NFC") == Normalize(b, "NFC")时返回true
+//
+// 示例:
+//
+//	EqualsNormalized("\u00e9", "e\u0301") → true
+//	EqualsNormalized("e", "\u00e9") → false
+func EqualsNormalized(a, b string) bool {
+	return norm.NFC.String(a) == norm.NFC.String(b)
+}
+
 // DefaultIfEmpty 如果字符串为空则返回默认值
 func DefaultIfEmpty(s, def string) string {
 	if IsEmpty(s) {
@@ -388,6 +653,15 @@ func DefaultIfEmpty(s, def string) string {
 
 // Reverse 反转字符串
 func Reverse(s string) string {
+	// 纯ASCII字符串按字节反转，避免分配[]rune
+	if isASCII(s) {
+		b := []byte(s)
+		for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+			b[i], b[j] = b[j], b[i]
+		}
+		return string(b)
+	}
+
 	runes := []rune(s)
 	n := len(runes)
 	for i := 0; i < n/2; i++ {
@@ -397,6 +671,63 @@ func Reverse(s string) string {
 	return string(runes)
 }
 
+// isASCII 判断字符串是否只包含ASCII字符
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// SortRunes 返回按rune升序排列字符后的字符串，可作为变位词（anagram）分组的规范签名
+// 与Reverse一样按rune处理，支持中文等多字节字符；纯ASCII字符串按字节排序以避免分配[]rune
+// 参数:
+//
+//	s - 待排序的字符串
+//
+// 返回值:
+//
+//	字符按码点升序排列后的字符串
+//
+// 示例:
+//
+//	SortRunes("listen") → "eilnst"
+//	SortRunes("你好你") → "你你好"
+func SortRunes(s string) string {
+	if isASCII(s) {
+		b := []byte(s)
+		sort.Slice(b, func(i, j int) bool { return b[i] < b[j] })
+		return string(b)
+	}
+
+	runes := []rune(s)
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+	return string(runes)
+}
+
+// AreAnagrams 判断两个字符串是否互为变位词，即包含相同的字符且每个字符出现次数相同
+// 比较不区分大小写；空白和标点同样参与比较，如需忽略可先用TrimAll等函数处理
+// 参数:
+//
+//	a, b - 待比较的字符串
+//
+// 返回值:
+//
+//	互为变位词时返回true
+//
+// 示例:
+//
+//	AreAnagrams("Listen", "Silent") → true
+//	AreAnagrams("abc", "abd") → false
+func AreAnagrams(a, b string) bool {
+	if utf8.RuneCountInString(a) != utf8.RuneCountInString(b) {
+		return false
+	}
+	return SortRunes(strings.ToLower(a)) == SortRunes(strings.ToLower(b))
+}
+
 // IsNotBlank 判断字符串是否非空白（包含非空白字符）
 func IsNotBlank(s string) bool {
 	return !IsBlank(s)
@@ -422,6 +753,88 @@ func Capitalize(s string) string {
 	return string(runes)
 }
 
+// titleSmallWords TitleCase中除首尾外保持小写的虚词
+var titleSmallWords = map[string]bool{
+	"a": true, "an": true, "the": true, "of": true, "and": true, "to": true, "in": true,
+}
+
+// TitleCase 将每个单词的首字母大写，但虚词（a、an、the、of、and、to、in）在非首尾位置保持小写
+// 单词以空白分隔，原有空白保持不变；除首字母和虚词外，其余字母的大小写保持不变
+// 参数:
+//
+//	s - 待转换的字符串
+//
+// 返回值:
+//
+//	标题格式的字符串
+//
+// 示例:
+//
+//	TitleCase("the lord of the rings") → "The Lord of the Rings"
+//	TitleCase("what to look in") → "What to Look In"
+func TitleCase(s string) string {
+	return titleCase(s, true)
+}
+
+// TitleCaseAll 将每个单词的首字母大写，不对虚词做特殊处理
+// 参数:
+//
+//	s - 待转换的字符串
+//
+// 返回值:
+//
+//	每个单词首字母大写的字符串
+//
+// 示例:
+//
+//	TitleCaseAll("the lord of the rings") → "The Lord Of The Rings"
+func TitleCaseAll(s string) string {
+	return titleCase(s, false)
+}
+
+// titleCase TitleCase和TitleCaseAll的内部实现
+func titleCase(s string, keepSmallWords bool) string {
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		return s
+	}
+
+	var builder strings.Builder
+	builder.Grow(len(s))
+	index := 0
+	rest := s
+	for len(rest) > 0 {
+		// 原样保留单词之间的空白
+		trimmed := strings.TrimLeftFunc(rest, unicode.IsSpace)
+		builder.WriteString(rest[:len(rest)-len(trimmed)])
+		rest = trimmed
+		if rest == "" {
+			break
+		}
+
+		word := words[index]
+		rest = rest[len(word):]
+		bare := strings.ToLower(strings.TrimFunc(word, func(r rune) bool { return !unicode.IsLetter(r) }))
+		if keepSmallWords && index > 0 && index < len(words)-1 && titleSmallWords[bare] {
+			builder.WriteString(strings.ToLower(word))
+		} else {
+			builder.WriteString(upperFirstLetter(word))
+		}
+		index++
+	}
+	return builder.String()
+}
+
+// upperFirstLetter 将单词中的第一个字母转换为大写，跳过开头的标点等非字母字符
+func upperFirstLetter(word string) string {
+	for i, r := range word {
+		if unicode.IsLetter(r) {
+			return word[:i] + string(unicode.ToUpper(r)) + word[i+utf8.RuneLen(r):]
+		}
+	}
+	return word
+}
+
 // Uncapitalize 首字母小写，其余字母不变
 func Uncapitalize(s string) string {
 	if IsEmpty(s) {
@@ -564,6 +977,81 @@ func ToSnakeCase(s string) string {
 	return string(result)
 }
 
+// SplitIdentifier 将程序标识符拆分为单词，是ToCamelCase、ToSnakeCase的逆向操作
+// 支持camelCase、PascalCase、snake_case、kebab-case及其混合形式：
+// 字母和数字以外的字符均视为分隔符；非大写的字母或数字后紧跟大写字母时拆分；
+// 连续的大写字母视为一个缩写词，其最后一个字母后紧跟小写字母时归入下一个单词；
+// 数字跟随前面的单词，单词保持原有大小写
+// 参数:
+//
+//	s - 标识符
+//
+// 返回值:
+//
+//	拆分后的单词，没有单词时返回空切片
+//
+// 示例:
+//
+//	SplitIdentifier("getHTTPResponseCode") → ["get", "HTTP", "Response", "Code"]
+//	SplitIdentifier("user_id") → ["user", "id"]
+//	SplitIdentifier("HTTP2Server") → ["HTTP2", "Server"]
+func SplitIdentifier(s string) []string {
+	runes := []rune(s)
+	words := []string{}
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+
+		prev := runes[i-1]
+		switch {
+		case unicode.IsUpper(r) && !unicode.IsUpper(prev):
+			// "getHTTP"、"v2Api"、"用户Name"：在大写字母前拆分
+			words = append(words, string(runes[start:i]))
+			start = i
+		case unicode.IsLower(r) && unicode.IsUpper(prev) && i-1 > start && unicode.IsUpper(runes[i-2]):
+			// "HTTPResponse"：缩写词的最后一个大写字母属于下一个单词
+			words = append(words, string(runes[start:i-1]))
+			start = i - 1
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// HumanizeIdentifier 将程序标识符转换为便于阅读的标签，单词以空格连接并将首字母大写
+// 单词的拆分规则见SplitIdentifier，首字母以外的字母保持原样，因此缩写词不受影响
+// 参数:
+//
+//	s - 标识符
+//
+// 返回值:
+//
+//	转换后的标签，没有单词时返回空字符串
+//
+// 示例:
+//
+//	HumanizeIdentifier("getHTTPResponseCode") → "Get HTTP Response Code"
+//	HumanizeIdentifier("created_at") → "Created At"
+func HumanizeIdentifier(s string) string {
+	words := SplitIdentifier(s)
+	for i, word := range words {
+		words[i] = upperFirstLetter(word)
+	}
+	return strings.Join(words, " ")
+}
+
 // IsNumeric 检查字符串是否只包含ASCII数字字符(0-9)
 // 注意: 此函数仅支持ASCII数字，不支持 Unicode 数字字符（如 ½、③等）
 // 参数:
@@ -592,64 +1080,169 @@ func IsNumeric(s string) bool {
 	return true
 }
 
-// IsAlpha 检查字符串是否只包含ASCII字母字符(a-z,A-Z)
-// 注意: 此函数仅支持ASCII字母，不支持 Unicode 字母字符（如 é、ñ、ü等）
+// ParseInt 解析用户输入的整数，允许千位分隔符、货币符号和首尾空白
+// 解析前会去除首尾的空白和货币符号（如$、¥、€），并去除整数部分中的逗号分隔符
+// 逗号必须按千位分组，如"1,234"，"1,5"和"12,34"无效；不接受小数、指数和十六进制等其它写法
 // 参数:
 //
-//	s - 待检查的字符串
+//	s - 待解析的字符串
 //
 // 返回值:
 //
-//	如果字符串非空且所有字符都是字母则返回true，否则返回false
+//	解析得到的整数；输入无效或超出int64范围时返回错误
 //
 // 示例:
 //
-//	IsAlpha("abcXYZ") → true
-//	IsAlpha("abc123") → false (包含数字)
-//	IsAlpha("") → false (空字符串)
-func IsAlpha(s string) bool {
-	if IsEmpty(s) {
-		return false
+//	ParseInt("1,234") → 1234, nil
+//	ParseInt(" -$42 ") → -42, nil
+//	ParseInt("12.3") → 0, error
+func ParseInt(s string) (int64, error) {
+	cleaned, ok := cleanNumber(s)
+	if !ok || strings.Contains(cleaned, ".") {
+		return 0, fmt.Errorf("invalid integer %q", s)
 	}
-	for _, r := range s {
-		if !((r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')) {
-			return false
-		}
+	n, err := strconv.ParseInt(cleaned, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid integer %q: %w", s, err)
 	}
-	return true
+	return n, nil
 }
 
-// IsAlphanumeric 检查字符串是否只包含ASCII字母和数字字符
-// 注意: 此函数仅支持ASCII字符，不支持 Unicode 字符
+// ParseFloat 解析用户输入的小数，允许千位分隔符、货币符号和首尾空白
+// 清理规则与ParseInt相同，小数点最多出现一次；不接受指数、NaN、Inf等写法
 // 参数:
 //
-//	s - 待检查的字符串
+//	s - 待解析的字符串
 //
 // 返回值:
 //
-//	如果字符串非空且所有字符都是字母或数字则返回true，否则返回false
+//	解析得到的浮点数；输入无效时返回错误
 //
 // 示例:
 //
-//	IsAlphanumeric("abc123") → true
-//	IsAlphanumeric("abc_123") → false (包含下划线)
-//	IsAlphanumeric("") → false (空字符串)
-func IsAlphanumeric(s string) bool {
-	if IsEmpty(s) {
-		return false
+//	ParseFloat("$1,234.56") → 1234.56, nil
+//	ParseFloat("-0.5") → -0.5, nil
+//	ParseFloat("12.3.4") → 0, error
+func ParseFloat(s string) (float64, error) {
+	cleaned, ok := cleanNumber(s)
+	if !ok {
+		return 0, fmt.Errorf("invalid number %q", s)
 	}
-	for _, r := range s {
-		if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
-			return false
-		}
+	f, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q: %w", s, err)
 	}
-	return true
+	return f, nil
 }
 
-// Base64Encode 将字符串编码为base64
-func Base64Encode(s string) string {
-	return base64.StdEncoding.EncodeToString([]byte(s))
-}
+// cleanNumber 去除数字字符串首尾的空白、货币符号以及整数部分的逗号分隔符
+// 返回只含可选符号、数字和至多一个小数点的字符串；格式无效时ok为false
+func cleanNumber(s string) (cleaned string, ok bool) {
+	isAffix := func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.Is(unicode.Sc, r)
+	}
+	s = strings.TrimFunc(s, isAffix)
+	sign := ""
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		sign, s = s[:1], strings.TrimLeftFunc(s[1:], isAffix)
+	}
+
+	intPart, fracPart, hasDot := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" {
+		return "", false
+	}
+	groups := strings.Split(intPart, ",")
+	for i, group := range groups {
+		if !isDigits(group) {
+			return "", false
+		}
+		if len(groups) == 1 {
+			break
+		}
+		// 有分隔符时按千位分组：第一组1到3位，其后每组恰好3位
+		if (i == 0 && (group == "" || len(group) > 3)) || (i > 0 && len(group) != 3) {
+			return "", false
+		}
+	}
+	if !isDigits(fracPart) {
+		return "", false
+	}
+
+	cleaned = sign + strings.Join(groups, "")
+	if hasDot {
+		cleaned += "." + fracPart
+	}
+	return cleaned, true
+}
+
+// isDigits 判断字符串是否只包含ASCII数字，空字符串返回true
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// IsAlpha 检查字符串是否只包含ASCII字母字符(a-z,A-Z)
+// 注意: 此函数仅支持ASCII字母，不支持 Unicode 字母字符（如 é、ñ、ü等）
+// 参数:
+//
+//	s - 待检查的字符串
+//
+// 返回值:
+//
+//	如果字符串非空且所有字符都是字母则返回true，否则返回false
+//
+// 示例:
+//
+//	IsAlpha("abcXYZ") → true
+//	IsAlpha("abc123") → false (包含数字)
+//	IsAlpha("") → false (空字符串)
+func IsAlpha(s string) bool {
+	if IsEmpty(s) {
+		return false
+	}
+	for _, r := range s {
+		if !((r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsAlphanumeric 检查字符串是否只包含ASCII字母和数字字符
+// 注意: 此函数仅支持ASCII字符，不支持 Unicode 字符
+// 参数:
+//
+//	s - 待检查的字符串
+//
+// 返回值:
+//
+//	如果字符串非空且所有字符都是字母或数字则返回true，否则返回false
+//
+// 示例:
+//
+//	IsAlphanumeric("abc123") → true
+//	IsAlphanumeric("abc_123") → false (包含下划线)
+//	IsAlphanumeric("") → false (空字符串)
+func IsAlphanumeric(s string) bool {
+	if IsEmpty(s) {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Base64Encode 将字符串编码为base64
+func Base64Encode(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
 
 // Base64Decode 解码base64字符串
 func Base64Decode(s string) (string, error) {
@@ -660,6 +1253,70 @@ func Base64Decode(s string) (string, error) {
 	return string(data), nil
 }
 
+// IsJSON 检查字符串是否为合法的JSON
+// 参数:
+//
+//	s - 待检查的字符串
+//
+// 返回值:
+//
+//	如果字符串是合法的JSON（对象、数组或标量）则返回true，否则返回false
+//
+// 示例:
+//
+//	IsJSON(`{"a":1}`) → true
+//	IsJSON("{bad}") → false
+func IsJSON(s string) bool {
+	return json.Valid([]byte(s))
+}
+
+// IsBase64 检查字符串是否为合法的标准base64编码（含填充）
+// 参数:
+//
+//	s - 待检查的字符串
+//
+// 返回值:
+//
+//	如果字符串非空、长度为4的倍数、不含换行且可被正确解码则返回true，否则返回false
+//
+// 示例:
+//
+//	IsBase64("SGVsbG8=") → true
+//	IsBase64("SGVsbG8") → false (缺少填充)
+func IsBase64(s string) bool {
+	if IsEmpty(s) || len(s)%4 != 0 || strings.ContainsAny(s, "\r\n") {
+		return false
+	}
+	_, err := base64.StdEncoding.DecodeString(s)
+	return err == nil
+}
+
+// IsHex 检查字符串是否只包含十六进制字符（0-9、a-f、A-F）
+// 注意: 不接受"0x"前缀
+// 参数:
+//
+//	s - 待检查的字符串
+//
+// 返回值:
+//
+//	如果字符串非空且所有字符都是十六进制字符则返回true，否则返回false
+//
+// 示例:
+//
+//	IsHex("deadBEEF") → true
+//	IsHex("0x1f") → false
+func IsHex(s string) bool {
+	if IsEmpty(s) {
+		return false
+	}
+	for _, r := range s {
+		if !((r >= '0' && r <= '9') || (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F')) {
+			return false
+		}
+	}
+	return true
+}
+
 // Mask 对字符串中的敏感信息进行掩码处理
 // 示例: Mask("13812345678", 3, 4, '*') → "138****5678"
 func Mask(s string, leftUnmaskLen, rightUnmaskLen int, maskChar rune) string {
@@ -677,6 +1334,49 @@ func Mask(s string, leftUnmaskLen, rightUnmaskLen int, maskChar rune) string {
 	return masked
 }
 
+// RedactedToken RedactFully返回的固定占位符
+const RedactedToken = "[REDACTED]"
+
+// Redact 对字符串进行保留结构的脱敏，用于日志输出
+// 与Mask保留首尾窗口不同，Redact将所有字母和数字替换为'x'，保留分隔符和长度
+// 参数:
+//
+//	s - 待脱敏的字符串
+//
+// 返回值:
+//
+//	脱敏后的字符串，字符数与原字符串相同
+//
+// 示例:
+//
+//	Redact("user@example.com") → "xxxx@xxxxxxx.xxx"
+//	Redact("138-1234-5678") → "xxx-xxxx-xxxx"
+func Redact(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return 'x'
+		}
+		return r
+	}, s)
+}
+
+// RedactFully 将字符串完全脱敏为固定的占位符，不泄露长度和结构
+// 参数:
+//
+//	s - 待脱敏的字符串
+//
+// 返回值:
+//
+//	总是返回RedactedToken，空字符串也不例外
+//
+// 示例:
+//
+//	RedactFully("secret") → "[REDACTED]"
+//	RedactFully("") → "[REDACTED]"
+func RedactFully(s string) string {
+	return RedactedToken
+}
+
 // RandomUUID 生成随机UUID (Version 4) 字符串
 // 采用RFC 4122标准，格式为8-4-4-4-12的十六进制字符
 // 返回值:
@@ -716,30 +1416,1665 @@ func RandomUUID() string {
 //	Format("Hello, {}!", "World") => "Hello, World!"
 //	Format("Name: {}, Age: {}", "Alice") => "Name: Alice, Age: {}"
 func Format(template string, params ...string) string {
-	var result strings.Builder
-	paramIndex := 0
+	return CompileFormat(template).Render(params...)
+}
+
+// CompiledTemplate 预先解析过占位符位置的格式化模板
+// 适用于同一模板需要反复格式化的热点路径（如日志），避免每次调用Format都重新扫描模板
+// 编译后的模板只读，可以在多个goroutine间共享
+type CompiledTemplate struct {
+	literals     []string // 占位符之间的字面文本，长度比placeholders多1
+	placeholders []string // 占位符原文（如"{}"），参数不足时原样输出
+}
+
+// CompileFormat 编译格式化模板，预先解析占位符{}的位置
+// 参数说明:
+//
+//	template - 包含占位符{}的模板字符串，语法与Format一致
+//
+// 返回值:
+//
+//	编译后的模板
+//
+// 示例:
+//
+//	tpl := CompileFormat("Hello, {}!")
+//	tpl.Render("World") => "Hello, World!"
+func CompileFormat(template string) *CompiledTemplate {
+	tpl := &CompiledTemplate{}
+	var literal strings.Builder
 	placeholderStart := -1
 
 	for i, c := range template {
 		if c == '{' && placeholderStart == -1 {
 			placeholderStart = i
 		} else if c == '}' && placeholderStart != -1 {
-			if paramIndex < len(params) {
-				result.WriteString(params[paramIndex])
-				paramIndex++
-			} else {
-				result.WriteString(template[placeholderStart : i+1])
-			}
+			tpl.literals = append(tpl.literals, literal.String())
+			tpl.placeholders = append(tpl.placeholders, template[placeholderStart:i+1])
+			literal.Reset()
 			placeholderStart = -1
 		} else if placeholderStart == -1 {
-			result.WriteRune(c)
+			literal.WriteRune(c)
 		}
 	}
 
 	// Handle unclosed placeholder at end of string
 	if placeholderStart != -1 {
-		result.WriteString(template[placeholderStart:])
+		literal.WriteString(template[placeholderStart:])
+	}
+	tpl.literals = append(tpl.literals, literal.String())
+
+	return tpl
+}
+
+// Render 用参数依次替换模板中的占位符，结果与对同一模板调用Format一致
+// 参数说明:
+//
+//	params - 可变参数，多余的参数被忽略，不足时未替换的占位符原样保留
+//
+// 返回值:
+//
+//	格式化后的字符串
+func (t *CompiledTemplate) Render(params ...string) string {
+	size := 0
+	for _, literal := range t.literals {
+		size += len(literal)
+	}
+	for i, placeholder := range t.placeholders {
+		if i < len(params) {
+			size += len(params[i])
+		} else {
+			size += len(placeholder)
+		}
+	}
+
+	var result strings.Builder
+	result.Grow(size)
+	for i, placeholder := range t.placeholders {
+		result.WriteString(t.literals[i])
+		if i < len(params) {
+			result.WriteString(params[i])
+		} else {
+			result.WriteString(placeholder)
+		}
 	}
+	result.WriteString(t.literals[len(t.literals)-1])
 
 	return result.String()
 }
+
+// RenderTemplate 渲染带条件段落的简单模板，比text/template更轻量，适合通知文案等场景
+// 支持的语法:
+//
+//	{key}           替换为data[key]（使用fmt.Sprint格式化，nil输出为空）
+//	{if key}...{end} 仅当data[key]为真值时输出中间的内容，可以嵌套
+//	{{ 和 }}        输出字面的{和}
+//
+// 真值: true、非空字符串、非零数字、非空的切片/map/数组，以及其它非nil、非零值；
+// 键不存在时视为假
+// 参数:
+//
+//	tmpl - 模板字符串
+//	data - 模板数据
+//
+// 返回值:
+//
+//	渲染后的字符串；模板中存在未闭合的标签、多余的{end}、未闭合的{if}、空标签、缺少条件的{if}，
+//	或需要输出的{key}在data中不存在时返回错误（被条件跳过的段落中的键不检查）
+//
+// 示例:
+//
+//	RenderTemplate("Hi {name}{if premium}, thanks for subscribing{end}!",
+//		map[string]interface{}{"name": "Ann", "premium": true}) → "Hi Ann, thanks for subscribing!"
+func RenderTemplate(tmpl string, data map[string]interface{}) (string, error) {
+	var builder strings.Builder
+	var outer []bool // 每层{if}外部的输出状态
+	active := true   // 当前是否输出
+
+	for i := 0; i < len(tmpl); {
+		c := tmpl[i]
+		if c == '}' && i+1 < len(tmpl) && tmpl[i+1] == '}' {
+			if active {
+				builder.WriteByte('}')
+			}
+			i += 2
+			continue
+		}
+		if c != '{' {
+			if active {
+				builder.WriteByte(c)
+			}
+			i++
+			continue
+		}
+		if i+1 < len(tmpl) && tmpl[i+1] == '{' {
+			if active {
+				builder.WriteByte('{')
+			}
+			i += 2
+			continue
+		}
+
+		end := strings.IndexByte(tmpl[i+1:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unclosed tag at position %d", i)
+		}
+		tag := strings.TrimSpace(tmpl[i+1 : i+1+end])
+		pos := i
+		i += end + 2
+
+		switch {
+		case tag == "":
+			return "", fmt.Errorf("empty tag at position %d", pos)
+		case tag == "end":
+			if len(outer) == 0 {
+				return "", fmt.Errorf("unexpected {end} at position %d", pos)
+			}
+			active = outer[len(outer)-1]
+			outer = outer[:len(outer)-1]
+		case tag == "if":
+			return "", fmt.Errorf("missing condition key in {if} at position %d", pos)
+		case strings.HasPrefix(tag, "if ") || strings.HasPrefix(tag, "if\t"):
+			key := strings.TrimSpace(tag[3:])
+			outer = append(outer, active)
+			active = active && isTruthy(data[key])
+		case active:
+			value, ok := data[tag]
+			if !ok {
+				return "", fmt.Errorf("missing value for key %q at position %d", tag, pos)
+			}
+			if value != nil {
+				builder.WriteString(fmt.Sprint(value))
+			}
+		}
+	}
+
+	if len(outer) > 0 {
+		return "", fmt.Errorf("%d unclosed {if} section(s)", len(outer))
+	}
+	return builder.String(), nil
+}
+
+// isTruthy 判断模板条件的值是否为真
+func isTruthy(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.Chan, reflect.String:
+		return v.Len() > 0
+	default:
+		return !v.IsZero()
+	}
+}
+
+// DisplayWidth 计算字符串在等宽终端中的显示宽度
+// 中日韩文字、全角符号等宽字符计为2，组合附加符号计为0，其余字符计为1
+// 参数:
+//
+//	s - 待计算的字符串
+//
+// 返回值:
+//
+//	字符串的显示宽度
+//
+// 示例:
+//
+//	DisplayWidth("hello") → 5
+//	DisplayWidth("你好") → 4
+func DisplayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+// runeWidth 返回单个字符的显示宽度
+func runeWidth(r rune) int {
+	switch {
+	case unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) || r == '\u200b':
+		return 0
+	case unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hangul, r) ||
+		unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r):
+		return 2
+	case r >= 0x3000 && r <= 0x303f, // CJK符号和标点
+		r >= 0xff01 && r <= 0xff60, // 全角ASCII及标点
+		r >= 0xffe0 && r <= 0xffe6: // 全角货币符号等
+		return 2
+	default:
+		return 1
+	}
+}
+
+// FormatTable 将二维字符串数据渲染为等宽对齐的文本表格
+// 每列宽度取该列所有单元格（含表头）的最大显示宽度，按DisplayWidth计算，支持中日韩字符对齐
+// 列之间以" | "分隔，表头下方输出"-"与"+"组成的分隔线；headers为空时不输出表头
+// 行的单元格数不足时缺失的单元格视为空字符串；每行以换行符结尾
+// 参数:
+//
+//	rows - 表格数据行
+//	headers - 表头，可为nil
+//
+// 返回值:
+//
+//	渲染后的表格字符串
+//
+// 示例:
+//
+//	FormatTable([][]string{{"Alice", "30"}}, []string{"Name", "Age"}) →
+//	Name  | Age
+//	------+----
+//	Alice | 30
+func FormatTable(rows [][]string, headers []string) string {
+	columns := len(headers)
+	for _, row := range rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+	if columns == 0 {
+		return ""
+	}
+
+	// 计算每列宽度
+	widths := make([]int, columns)
+	measure := func(cells []string) {
+		for i, cell := range cells {
+			if w := DisplayWidth(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	measure(headers)
+	for _, row := range rows {
+		measure(row)
+	}
+
+	var builder strings.Builder
+	writeRow := func(cells []string) {
+		for i := 0; i < columns; i++ {
+			var cell string
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			if i > 0 {
+				builder.WriteString(" | ")
+			}
+			if i == columns-1 {
+				// 最后一列不补齐，避免行尾多余空格
+				builder.WriteString(cell)
+				continue
+			}
+			// PadRight按字符数填充，这里补偿宽字符多占用的显示宽度
+			padLen := widths[i] - DisplayWidth(cell) + len([]rune(cell))
+			builder.WriteString(PadRight(cell, padLen, ' '))
+		}
+		builder.WriteByte('\n')
+	}
+
+	if len(headers) > 0 {
+		writeRow(headers)
+		for i, w := range widths {
+			if i > 0 {
+				builder.WriteString("-+-")
+			}
+			builder.WriteString(strings.Repeat("-", w))
+		}
+		builder.WriteByte('\n')
+	}
+	for _, row := range rows {
+		writeRow(row)
+	}
+
+	return builder.String()
+}
+
+// typographyReplacer 将排版字符替换为对应ASCII字符的替换器
+var typographyReplacer = strings.NewReplacer(
+	// 单引号、撇号
+	"‘", "'", "’", "'", "‚", "'", "‛", "'", "′", "'",
+	// 双引号
+	"“", "\"", "”", "\"", "„", "\"", "‟", "\"", "″", "\"",
+	// 各类连字符与破折号
+	"‐", "-", "‑", "-", "‒", "-", "–", "-", "—", "-", "―", "-", "−", "-",
+	// 省略号
+	"…", "...",
+	// 不换行空格及各类宽度的空格
+	"\u00a0", " ", "\u2002", " ", "\u2003", " ", "\u2004", " ", "\u2005", " ", "\u2006", " ",
+	"\u2007", " ", "\u2008", " ", "\u2009", " ", "\u200a", " ", "\u202f", " ", "\u205f", " ",
+	// 零宽字符直接移除
+	"\u200b", "", "\u200c", "", "\u200d", "", "\u2060", "", "\ufeff", "",
+)
+
+// NormalizeTypography 将排版用的特殊字符规范化为ASCII等价字符
+// 弯引号转换为直引号，各类破折号转换为"-"，省略号转换为"..."，
+// 不换行空格等特殊空格转换为普通空格，零宽字符被移除
+// 参数:
+//
+//	s - 待处理的字符串
+//
+// 返回值:
+//
+//	规范化后的字符串
+//
+// 示例:
+//
+//	NormalizeTypography("“Hello” — it’s") → "\"Hello\" - it's"
+func NormalizeTypography(s string) string {
+	return typographyReplacer.Replace(s)
+}
+
+// Smartify 将ASCII引号和标点转换为排版用的弯引号等字符，是NormalizeTypography的近似逆操作
+// 引号根据前一个字符判断开闭：位于开头、空白或左括号之后为左引号，否则为右引号；
+// 单词内部的单引号视为撇号(’)；"--"转换为破折号"—"，"..."转换为省略号"…"
+// 参数:
+//
+//	s - 待处理的字符串
+//
+// 返回值:
+//
+//	转换后的字符串
+//
+// 示例:
+//
+//	Smartify("\"Hello\" -- it's...") → "“Hello” — it’s…"
+func Smartify(s string) string {
+	s = strings.ReplaceAll(s, "...", "…")
+	s = strings.ReplaceAll(s, "--", "—")
+
+	var builder strings.Builder
+	builder.Grow(len(s))
+	prev := rune(-1) // -1 表示字符串开头
+	for _, c := range s {
+		opening := prev == -1 || unicode.IsSpace(prev) || strings.ContainsRune("([{—", prev)
+		switch c {
+		case '"':
+			if opening {
+				builder.WriteRune('“')
+			} else {
+				builder.WriteRune('”')
+			}
+		case '\'':
+			if opening {
+				builder.WriteRune('‘')
+			} else {
+				builder.WriteRune('’')
+			}
+		default:
+			builder.WriteRune(c)
+		}
+		prev = c
+	}
+	return builder.String()
+}
+
+// FuzzyMatch 判断pattern中的字符是否按顺序（不要求连续）出现在s中，忽略大小写
+// 常用于命令面板等场景的模糊匹配，空pattern始终匹配
+// 参数:
+//
+//	pattern - 匹配模式
+//	s - 待匹配的字符串
+//
+// 返回值:
+//
+//	匹配成功返回true，否则返回false
+//
+// 示例:
+//
+//	FuzzyMatch("gco", "git checkout") → true
+//	FuzzyMatch("gcx", "git checkout") → false
+func FuzzyMatch(pattern, s string) bool {
+	_, ok := fuzzyScore(pattern, s)
+	return ok
+}
+
+// FuzzyScore 计算pattern与s的模糊匹配得分，用于对候选项排序，得分越高越相关
+// 每个匹配字符得1分；与上一个匹配字符相邻额外得5分；位于单词开头额外得3分；
+// 首个匹配字符位置越靠前额外得分越高（最多10分）。不匹配时返回0
+// 参数:
+//
+//	pattern - 匹配模式
+//	s - 待匹配的字符串
+//
+// 返回值:
+//
+//	匹配得分，不匹配时为0
+//
+// 示例:
+//
+//	FuzzyScore("check", "git checkout") > FuzzyScore("check", "cache hack")
+func FuzzyScore(pattern, s string) int {
+	score, _ := fuzzyScore(pattern, s)
+	return score
+}
+
+// fuzzyScore 模糊匹配的内部实现，返回得分和是否匹配
+func fuzzyScore(pattern, s string) (int, bool) {
+	patternRunes := []rune(strings.ToLower(pattern))
+	if len(patternRunes) == 0 {
+		return 0, true
+	}
+	runes := []rune(s)
+
+	score := 0
+	pi := 0
+	prevIdx := -2
+	for i, c := range runes {
+		if pi == len(patternRunes) {
+			break
+		}
+		if unicode.ToLower(c) != patternRunes[pi] {
+			continue
+		}
+
+		score++
+		if i == prevIdx+1 {
+			score += 5 // 连续匹配
+		}
+		if i == 0 || isFuzzyBoundary(runes[i-1]) {
+			score += 3 // 单词开头
+		}
+		if pi == 0 && i < 10 {
+			score += 10 - i // 首个匹配字符越靠前越好
+		}
+		prevIdx = i
+		pi++
+	}
+
+	if pi < len(patternRunes) {
+		return 0, false
+	}
+	return score, true
+}
+
+// isFuzzyBoundary 判断字符是否为单词分隔符
+func isFuzzyBoundary(r rune) bool {
+	return unicode.IsSpace(r) || r == '_' || r == '-' || r == '/' || r == '.'
+}
+
+// Chunk 按字符（rune）数将字符串切分为固定大小的分组，最后一组可能不足size个字符
+// 参数:
+//
+//	s - 待切分的字符串
+//	size - 每组的字符数，必须大于0
+//
+// 返回值:
+//
+//	切分后的分组，s为空时返回空切片；size不合法时返回错误
+//
+// 示例:
+//
+//	Chunk("abcdefg", 3) → ["abc", "def", "g"]
+//	Chunk("你好世界", 2) → ["你好", "世界"]
+func Chunk(s string, size int) ([]string, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("chunk size must be positive, got %d", size)
+	}
+
+	runes := []rune(s)
+	chunks := make([]string, 0, (len(runes)+size-1)/size)
+	for start := 0; start < len(runes); start += size {
+		end := start + size
+		if end > len(runes) {
+			end = len(runes)
+		}
+		chunks = append(chunks, string(runes[start:end]))
+	}
+	return chunks, nil
+}
+
+// ChunkJoin 按字符数切分字符串后使用分隔符连接，常用于格式化卡号等分组显示
+// 参数:
+//
+//	s - 待切分的字符串
+//	size - 每组的字符数，必须大于0
+//	sep - 分组之间的分隔符
+//
+// 返回值:
+//
+//	连接后的字符串；size不合法时返回错误
+//
+// 示例:
+//
+//	ChunkJoin("1234567890123456", 4, " ") → "1234 5678 9012 3456"
+func ChunkJoin(s string, size int, sep string) (string, error) {
+	chunks, err := Chunk(s, size)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(chunks, sep), nil
+}
+
+var (
+	// fingerprintUUIDPattern 匹配UUID形式的标识符
+	fingerprintUUIDPattern = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	// fingerprintDigitPattern 匹配连续数字
+	fingerprintDigitPattern = regexp.MustCompile(`\d+`)
+)
+
+// Fingerprint 生成忽略数字和UUID的归一化指纹，用于对仅ID、时间戳等不同的日志行去重分组
+// UUID形式的标识符替换为"<uuid>"，连续数字替换为"#"，再去除首尾空白并将连续空白合并为单个空格
+// 参数:
+//
+//	s - 待处理的字符串
+//
+// 返回值:
+//
+//	归一化后的指纹
+//
+// 示例:
+//
+//	Fingerprint("user 42 logged in at 10:03") → "user # logged in at #:#"
+//	Fingerprint("req 550e8400-e29b-41d4-a716-446655440000 done") → "req <uuid> done"
+func Fingerprint(s string) string {
+	s = fingerprintUUIDPattern.ReplaceAllString(s, "<uuid>")
+	s = fingerprintDigitPattern.ReplaceAllString(s, "#")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+var (
+	// smallNumberWords 0-19的英文单词
+	smallNumberWords = []string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+	}
+	// tensNumberWords 整十数的英文单词，下标为十位数字
+	tensNumberWords = []string{
+		"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety",
+	}
+	// scaleNumberWords 千进位的英文数量级单词
+	scaleNumberWords = []string{
+		"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion",
+	}
+	// irregularOrdinalWords 不规则的序数词
+	irregularOrdinalWords = map[string]string{
+		"one":    "first",
+		"two":    "second",
+		"three":  "third",
+		"five":   "fifth",
+		"eight":  "eighth",
+		"nine":   "ninth",
+		"twelve": "twelfth",
+	}
+)
+
+// Ordinal 返回带英文序数后缀的数字
+// 11、12、13结尾的数字使用"th"，其余按个位数字使用"st"、"nd"、"rd"或"th"
+// 参数:
+//
+//	n - 数字，负数保留负号
+//
+// 返回值:
+//
+//	带序数后缀的字符串
+//
+// 示例:
+//
+//	Ordinal(1) → "1st"
+//	Ordinal(12) → "12th"
+//	Ordinal(23) → "23rd"
+func Ordinal(n int) string {
+	abs := n % 100
+	if abs < 0 {
+		abs = -abs
+	}
+
+	suffix := "th"
+	if abs < 11 || abs > 13 {
+		switch abs % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+// NumberToWords 将整数转换为英文单词（美式写法，不含"and"）
+// 参数:
+//
+//	n - 待转换的整数，负数以"minus"开头
+//
+// 返回值:
+//
+//	英文单词表示
+//
+// 示例:
+//
+//	NumberToWords(0) → "zero"
+//	NumberToWords(123) → "one hundred twenty-three"
+//	NumberToWords(-1005) → "minus one thousand five"
+func NumberToWords(n int) string {
+	if n == 0 {
+		return smallNumberWords[0]
+	}
+
+	// 使用uint64避免对最小负数取反时溢出
+	abs := uint64(n)
+	if n < 0 {
+		abs = -abs
+	}
+
+	var groups []string
+	for scale := 0; abs > 0; scale++ {
+		if group := abs % 1000; group > 0 {
+			words := threeDigitWords(int(group))
+			if scaleNumberWords[scale] != "" {
+				words += " " + scaleNumberWords[scale]
+			}
+			groups = append([]string{words}, groups...)
+		}
+		abs /= 1000
+	}
+
+	result := strings.Join(groups, " ")
+	if n < 0 {
+		return "minus " + result
+	}
+	return result
+}
+
+// threeDigitWords 将1-999的整数转换为英文单词
+func threeDigitWords(n int) string {
+	var parts []string
+	if n >= 100 {
+		parts = append(parts, smallNumberWords[n/100]+" hundred")
+		n %= 100
+	}
+	switch {
+	case n == 0:
+	case n < 20:
+		parts = append(parts, smallNumberWords[n])
+	case n%10 == 0:
+		parts = append(parts, tensNumberWords[n/10])
+	default:
+		parts = append(parts, tensNumberWords[n/10]+"-"+smallNumberWords[n%10])
+	}
+	return strings.Join(parts, " ")
+}
+
+// OrdinalWord 将整数转换为英文序数词
+// 参数:
+//
+//	n - 待转换的整数
+//
+// 返回值:
+//
+//	英文序数词表示
+//
+// 示例:
+//
+//	OrdinalWord(1) → "first"
+//	OrdinalWord(12) → "twelfth"
+//	OrdinalWord(21) → "twenty-first"
+//	OrdinalWord(40) → "fortieth"
+func OrdinalWord(n int) string {
+	words := NumberToWords(n)
+
+	// 只需转换最后一个单词（或连字符后的部分）
+	cut := strings.LastIndexAny(words, " -") + 1
+	prefix, last := words[:cut], words[cut:]
+
+	if ordinal, ok := irregularOrdinalWords[last]; ok {
+		return prefix + ordinal
+	}
+	if strings.HasSuffix(last, "y") {
+		return prefix + strings.TrimSuffix(last, "y") + "ieth"
+	}
+	return prefix + last + "th"
+}
+
+// shellUnsafePattern 匹配需要在shell中加引号的字符
+var shellUnsafePattern = regexp.MustCompile(`[^\w@%+=:,./-]`)
+
+// ShellQuote 将字符串转义为可安全用作POSIX shell单个参数的形式
+// 只包含安全字符（字母、数字及@%+=:,./-_）的字符串原样返回，
+// 其余情况使用单引号包裹，内部的单引号转写为'"'"'
+// 参数:
+//
+//	s - 待转义的字符串
+//
+// 返回值:
+//
+//	转义后的字符串
+//
+// 示例:
+//
+//	ShellQuote("file.txt") → "file.txt"
+//	ShellQuote("it's a file") → `'it'"'"'s a file'`
+//	ShellQuote("") → "''"
+func ShellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	if !shellUnsafePattern.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
+
+// CSVField 按RFC 4180转义CSV字段
+// 字段包含逗号、双引号或换行符时使用双引号包裹，内部的双引号转写为两个双引号，否则原样返回
+// 参数:
+//
+//	s - 待转义的字段值
+//
+// 返回值:
+//
+//	转义后的字段
+//
+// 示例:
+//
+//	CSVField("plain") → "plain"
+//	CSVField(`a,"b"`) → `"a,""b"""`
+func CSVField(s string) string {
+	if !strings.ContainsAny(s, ",\"\r\n") {
+		return s
+	}
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// Censor 屏蔽字符串中的敏感词，按整词匹配且不区分大小写
+// 每个匹配到的词被替换为等长（按字符数计）的maskChar，其余文本保持不变
+// 整词匹配要求敏感词前后不是字母、数字或下划线，因此"ass"不会屏蔽"class"中的子串
+// 参数:
+//
+//	s - 待处理的字符串
+//	words - 敏感词列表，空字符串会被忽略
+//	maskChar - 用于替换的字符
+//
+// 返回值:
+//
+//	屏蔽后的字符串
+//
+// 示例:
+//
+//	Censor("You are a Fool!", []string{"fool"}, '*') → "You are a ****!"
+//	Censor("foolish", []string{"fool"}, '*') → "foolish"
+func Censor(s string, words []string, maskChar rune) string {
+	return censor(s, words, maskChar, true)
+}
+
+// CensorContains 屏蔽字符串中的敏感词，按子串匹配且不区分大小写
+// 与Censor不同，敏感词出现在其它单词内部时也会被屏蔽
+// 参数:
+//
+//	s - 待处理的字符串
+//	words - 敏感词列表，空字符串会被忽略
+//	maskChar - 用于替换的字符
+//
+// 返回值:
+//
+//	屏蔽后的字符串
+//
+// 示例:
+//
+//	CensorContains("foolish", []string{"fool"}, '*') → "****ish"
+func CensorContains(s string, words []string, maskChar rune) string {
+	return censor(s, words, maskChar, false)
+}
+
+// censor Censor和CensorContains的内部实现
+func censor(s string, words []string, maskChar rune, wholeWord bool) string {
+	runes := []rune(s)
+	// 逐字符转小写，保证与原字符串下标一一对应
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	masked := make([]bool, len(runes))
+	found := false
+	for _, word := range words {
+		target := []rune(strings.ToLower(word))
+		if len(target) == 0 {
+			continue
+		}
+		for i := 0; i+len(target) <= len(lower); i++ {
+			if string(lower[i:i+len(target)]) != string(target) {
+				continue
+			}
+			end := i + len(target)
+			if wholeWord && (i > 0 && isWordRune(lower[i-1]) || end < len(lower) && isWordRune(lower[end])) {
+				continue
+			}
+			for j := i; j < end; j++ {
+				masked[j] = true
+			}
+			found = true
+		}
+	}
+	if !found {
+		return s
+	}
+
+	var builder strings.Builder
+	builder.Grow(len(s))
+	for i, r := range runes {
+		if masked[i] {
+			builder.WriteRune(maskChar)
+		} else {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// isWordRune 判断字符是否属于单词的一部分（字母、数字或下划线）
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// TruncateWords 按单词截断字符串，最多保留maxWords个单词
+// 单词以空格、制表符和换行符分隔，截断后单词之间以单个空格连接
+// 参数:
+//
+//	s - 待截断的字符串
+//	maxWords - 最多保留的单词数，小于等于0时不保留任何单词
+//	ellipsis - 发生截断时追加的后缀，如"..."
+//
+// 返回值:
+//
+//	截断后的字符串；未发生截断时原样返回
+//
+// 示例:
+//
+//	TruncateWords("the quick brown fox jumps", 3, "...") → "the quick brown..."
+func TruncateWords(s string, maxWords int, ellipsis string) string {
+	words := Split(s, ' ', '\t', '\n', '\r')
+	if len(words) <= maxWords {
+		return s
+	}
+	if maxWords <= 0 {
+		return ellipsis
+	}
+	return Join(words[:maxWords], " ") + ellipsis
+}
+
+// TruncateAtWord 按字符数截断字符串，并回退到maxChars以内最后一个完整单词的末尾
+// 字符数按rune计算，不包含ellipsis；若第一个单词就超过maxChars，则直接在maxChars处截断
+// 参数:
+//
+//	s - 待截断的字符串
+//	maxChars - 保留的最大字符数，小于等于0时不保留任何字符
+//	ellipsis - 发生截断时追加的后缀，如"..."
+//
+// 返回值:
+//
+//	截断后的字符串；未发生截断时原样返回
+//
+// 示例:
+//
+//	TruncateAtWord("hello wonderful world", 12, "...") → "hello..."
+func TruncateAtWord(s string, maxChars int, ellipsis string) string {
+	runes := []rune(s)
+	if len(runes) <= maxChars {
+		return s
+	}
+	if maxChars <= 0 {
+		return ellipsis
+	}
+
+	cut := maxChars
+	// 截断点不在单词边界时，回退到上一个空白字符
+	if !unicode.IsSpace(runes[cut]) {
+		for i := cut - 1; i > 0; i-- {
+			if unicode.IsSpace(runes[i]) {
+				cut = i
+				break
+			}
+		}
+	}
+	return strings.TrimRightFunc(string(runes[:cut]), unicode.IsSpace) + ellipsis
+}
+
+// accentReplacer 将带变音符号的拉丁字母替换为对应的ASCII字母
+// 覆盖Latin-1补充和Latin扩展-A中的常用字母
+var accentReplacer = strings.NewReplacer(
+	"À", "A", "Á", "A", "Â", "A", "Ã", "A", "Ä", "A", "Å", "A", "Ā", "A", "Ă", "A", "Ą", "A",
+	"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a", "å", "a", "ā", "a", "ă", "a", "ą", "a",
+	"Æ", "AE", "æ", "ae", "Ç", "C", "Ć", "C", "Ĉ", "C", "Ċ", "C", "Č", "C",
+	"ç", "c", "ć", "c", "ĉ", "c", "ċ", "c", "č", "c", "Ď", "D", "Đ", "D", "Ð", "D", "ď", "d", "đ", "d", "ð", "d",
+	"È", "E", "É", "E", "Ê", "E", "Ë", "E", "Ē", "E", "Ĕ", "E", "Ė", "E", "Ę", "E", "Ě", "E",
+	"è", "e", "é", "e", "ê", "e", "ë", "e", "ē", "e", "ĕ", "e", "ė", "e", "ę", "e", "ě", "e",
+	"Ĝ", "G", "Ğ", "G", "Ġ", "G", "Ģ", "G", "ĝ", "g", "ğ", "g", "ġ", "g", "ģ", "g",
+	"Ĥ", "H", "Ħ", "H", "ĥ", "h", "ħ", "h",
+	"Ì", "I", "Í", "I", "Î", "I", "Ï", "I", "Ĩ", "I", "Ī", "I", "Ĭ", "I", "Į", "I", "İ", "I",
+	"ì", "i", "í", "i", "î", "i", "ï", "i", "ĩ", "i", "ī", "i", "ĭ", "i", "į", "i", "ı", "i",
+	"Ĳ", "IJ", "ĳ", "ij", "Ĵ", "J", "ĵ", "j", "Ķ", "K", "ķ", "k", "ĸ", "k",
+	"Ĺ", "L", "Ļ", "L", "Ľ", "L", "Ŀ", "L", "Ł", "L", "ĺ", "l", "ļ", "l", "ľ", "l", "ŀ", "l", "ł", "l",
+	"Ñ", "N", "Ń", "N", "Ņ", "N", "Ň", "N", "Ŋ", "N", "ñ", "n", "ń", "n", "ņ", "n", "ň", "n", "ŉ", "n", "ŋ", "n",
+	"Ò", "O", "Ó", "O", "Ô", "O", "Õ", "O", "Ö", "O", "Ø", "O", "Ō", "O", "Ŏ", "O", "Ő", "O",
+	"ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o", "ø", "o", "ō", "o", "ŏ", "o", "ő", "o",
+	"Œ", "OE", "œ", "oe", "Ŕ", "R", "Ŗ", "R", "Ř", "R", "ŕ", "r", "ŗ", "r", "ř", "r",
+	"Ś", "S", "Ŝ", "S", "Ş", "S", "Š", "S", "ś", "s", "ŝ", "s", "ş", "s", "š", "s", "ß", "ss", "ſ", "s",
+	"Ţ", "T", "Ť", "T", "Ŧ", "T", "ţ", "t", "ť", "t", "ŧ", "t", "Þ", "TH", "þ", "th",
+	"Ù", "U", "Ú", "U", "Û", "U", "Ü", "U", "Ũ", "U", "Ū", "U", "Ŭ", "U", "Ů", "U", "Ű", "U", "Ų", "U",
+	"ù", "u", "ú", "u", "û", "u", "ü", "u", "ũ", "u", "ū", "u", "ŭ", "u", "ů", "u", "ű", "u", "ų", "u",
+	"Ŵ", "W", "ŵ", "w", "Ý", "Y", "Ÿ", "Y", "Ŷ", "Y", "ý", "y", "ÿ", "y", "ŷ", "y",
+	"Ź", "Z", "Ż", "Z", "Ž", "Z", "ź", "z", "ż", "z", "ž", "z",
+)
+
+// RemoveAccents 去除拉丁字母上的变音符号，将其折叠为ASCII字母
+// 注意: 仅处理Latin-1补充和Latin扩展-A中的字母，其它字符保持不变
+// 参数:
+//
+//	s - 待处理的字符串
+//
+// 返回值:
+//
+//	去除变音符号后的字符串
+//
+// 示例:
+//
+//	RemoveAccents("Crème Brûlée") → "Creme Brulee"
+//	RemoveAccents("Straße") → "Strasse"
+func RemoveAccents(s string) string {
+	return accentReplacer.Replace(s)
+}
+
+// defaultFilenameMaxLen SafeFilename默认的最大文件名长度（字节），与常见文件系统的限制一致
+const defaultFilenameMaxLen = 255
+
+// SafeFilename 将任意字符串（如标题）转换为可在常见文件系统上使用的文件名
+// 最大长度为255字节，等效于SafeFilenameWithMaxLen(s, 255)
+// 参数:
+//
+//	s - 待转换的字符串
+//
+// 返回值:
+//
+//	安全的文件名，无可用字符时返回"untitled"
+//
+// 示例:
+//
+//	SafeFilename("My: Report/2023?.pdf") → "My_Report_2023.pdf"
+func SafeFilename(s string) string {
+	return SafeFilenameWithMaxLen(s, defaultFilenameMaxLen)
+}
+
+// SafeFilenameWithMaxLen 将任意字符串转换为安全的文件名，并限制最大长度
+// 处理步骤: 去除变音符号；路径分隔符/和\视为空白；删除: * ? " < > |和控制字符；
+// 连续空白折叠为单个下划线；去除开头的点以及末尾的点和下划线；超长时按字节截断（不拆分多字节字符），
+// 并尽量保留扩展名
+// 参数:
+//
+//	s - 待转换的字符串
+//	maxLen - 最大字节数，小于等于0时使用默认值255
+//
+// 返回值:
+//
+//	安全的文件名，无可用字符时返回"untitled"
+func SafeFilenameWithMaxLen(s string, maxLen int) string {
+	if maxLen <= 0 {
+		maxLen = defaultFilenameMaxLen
+	}
+
+	var builder strings.Builder
+	for _, r := range RemoveAccents(s) {
+		switch {
+		case r == '/' || r == '\\':
+			builder.WriteRune(' ')
+		case strings.ContainsRune(`:*?"<>|`, r) || unicode.IsControl(r) && !unicode.IsSpace(r):
+			// 非法字符直接删除
+		default:
+			builder.WriteRune(r)
+		}
+	}
+
+	name := strings.Join(strings.Fields(builder.String()), "_")
+	name = strings.TrimLeft(name, ".")
+	name = strings.TrimRight(name, "._")
+	if name == "" {
+		return "untitled"
+	}
+	if len(name) <= maxLen {
+		return name
+	}
+
+	// 超长时尽量保留扩展名
+	ext := ""
+	if i := strings.LastIndexByte(name, '.'); i > 0 && len(name)-i < maxLen/2 {
+		name, ext = name[:i], name[i:]
+	}
+	name = truncateBytes(name, maxLen-len(ext))
+	return strings.TrimRight(name, "._") + ext
+}
+
+// truncateBytes 将字符串截断到不超过n字节，不会拆分多字节字符
+func truncateBytes(s string, n int) string {
+	end := 0
+	for end < len(s) {
+		_, size := utf8.DecodeRuneInString(s[end:])
+		if end+size > n {
+			break
+		}
+		end += size
+	}
+	return s[:end]
+}
+
+// Lines 将字符串按行拆分，同时支持\n、\r\n和\r换行符
+// 末尾的换行符不会产生额外的空行
+// 参数:
+//
+//	s - 待拆分的字符串
+//
+// 返回值:
+//
+//	不含换行符的行列表，空字符串返回空切片
+//
+// 示例:
+//
+//	Lines("a\r\nb\n\nc\n") → []string{"a", "b", "", "c"}
+func Lines(s string) []string {
+	if IsEmpty(s) {
+		return []string{}
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// LineCount 返回字符串的行数，规则与Lines一致
+// 参数:
+//
+//	s - 待统计的字符串
+//
+// 返回值:
+//
+//	行数，空字符串返回0
+//
+// 示例:
+//
+//	LineCount("a\nb\n") → 2
+func LineCount(s string) int {
+	return len(Lines(s))
+}
+
+// CountLinesReader 从io.Reader中流式统计行数，规则与LineCount一致，适用于无法整体读入内存的大文件
+// 使用bufio.Scanner逐行读取，单行长度不能超过bufio.MaxScanTokenSize（64KB）
+// 参数:
+//
+//	r - 数据来源
+//
+// 返回值:
+//
+//	行数；读取失败或单行过长时返回已统计的行数和错误
+//
+// 示例:
+//
+//	CountLinesReader(strings.NewReader("a\r\nb")) → 2, nil
+func CountLinesReader(r io.Reader) (int, error) {
+	count := 0
+	err := ForEachLine(r, func(string) bool {
+		count++
+		return true
+	})
+	return count, err
+}
+
+// ForEachLine 从io.Reader中逐行读取并调用fn，换行规则与Lines一致
+// fn返回false时立即停止读取，剩余数据不会被消费
+// 单行长度不能超过bufio.MaxScanTokenSize（64KB）
+// 参数:
+//
+//	r - 数据来源
+//	fn - 处理每一行的函数，参数不含换行符，返回false表示停止
+//
+// 返回值:
+//
+//	读取失败或单行过长时返回错误，正常读完或提前停止时返回nil
+//
+// 示例:
+//
+//	ForEachLine(file, func(line string) bool {
+//		fmt.Println(line)
+//		return line != "END"
+//	})
+func ForEachLine(r io.Reader, fn func(line string) bool) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(scanLines)
+	for scanner.Scan() {
+		if !fn(scanner.Text()) {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+// scanLines bufio.SplitFunc，与bufio.ScanLines类似，但额外把单独的\r视为换行符
+func scanLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		if data[i] == '\n' {
+			return i + 1, data[:i], nil
+		}
+		if i+1 < len(data) {
+			if data[i+1] == '\n' {
+				return i + 2, data[:i], nil
+			}
+			return i + 1, data[:i], nil
+		}
+		if atEOF {
+			return i + 1, data[:i], nil
+		}
+		// \r位于缓冲区末尾，需要更多数据判断是否为\r\n
+		return 0, nil, nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// Quote 为每一行（包括空行）添加"> "前缀，用于渲染引用回复
+// 参数:
+//
+//	s - 待引用的文本
+//
+// 返回值:
+//
+//	引用后的文本，行之间以\n连接；空字符串返回空字符串
+//
+// 示例:
+//
+//	Quote("hello\n\nworld") → "> hello\n> \n> world"
+func Quote(s string) string {
+	lines := Lines(s)
+	for i, line := range lines {
+		lines[i] = "> " + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CodeBlock 使用三个反引号包裹代码，生成带语言标记的Markdown代码块
+// 换行符统一为\n；若代码中包含连续的反引号，围栏会自动加长以避免提前闭合
+// 参数:
+//
+//	s - 代码内容
+//	lang - 语言标记，如"go"，可为空
+//
+// 返回值:
+//
+//	Markdown代码块
+//
+// 示例:
+//
+//	CodeBlock("fmt.Println(1)", "go") → "```go\nfmt.Println(1)\n```"
+func CodeBlock(s, lang string) string {
+	// 围栏长度需超过内容中最长的连续反引号
+	longest, run := 0, 0
+	for _, r := range s {
+		if r == '`' {
+			run++
+			if run > longest {
+				longest = run
+			}
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", max(3, longest+1))
+
+	var builder strings.Builder
+	builder.WriteString(fence + lang + "\n")
+	for _, line := range Lines(s) {
+		builder.WriteString(line + "\n")
+	}
+	builder.WriteString(fence)
+	return builder.String()
+}
+
+// RunLengthEncode 对字符串进行游程编码，每段连续相同的字符输出为"字符+次数"
+// 按rune处理，支持中文等多字节字符；为避免与次数混淆，字符本身是数字或反斜杠时前面加反斜杠转义
+// 参数:
+//
+//	s - 待编码的字符串
+//
+// 返回值:
+//
+//	编码后的字符串，空字符串返回空字符串
+//
+// 示例:
+//
+//	RunLengthEncode("aaabbc") → "a3b2c1"
+//	RunLengthEncode("好好好") → "好3"
+//	RunLengthEncode("a11") → "a1\\12"
+func RunLengthEncode(s string) string {
+	var builder strings.Builder
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		j := i + 1
+		for j < len(runes) && runes[j] == runes[i] {
+			j++
+		}
+		if unicode.IsDigit(runes[i]) || runes[i] == '\\' {
+			builder.WriteByte('\\')
+		}
+		builder.WriteRune(runes[i])
+		builder.WriteString(strconv.Itoa(j - i))
+		i = j
+	}
+	return builder.String()
+}
+
+// MaxRunLengthDecodedLen RunLengthDecode解码结果的最大字符数，防止很小的恶意输入（如"a9999999999"）耗尽内存
+const MaxRunLengthDecodedLen = 1 << 24
+
+// RunLengthDecode 解码RunLengthEncode生成的游程编码字符串
+// 解码结果的字符数超过MaxRunLengthDecodedLen时返回错误
+// 参数:
+//
+//	s - 待解码的字符串
+//
+// 返回值:
+//
+//	解码后的字符串；缺少字符的次数、缺少次数的字符、次数为0或过大、末尾孤立的反斜杠等格式错误，
+//	或解码结果过长时返回错误
+//
+// 示例:
+//
+//	RunLengthDecode("a3b2c1") → "aaabbc", nil
+//	RunLengthDecode("3a") → "", error
+func RunLengthDecode(s string) (string, error) {
+	var builder strings.Builder
+	runes := []rune(s)
+	total := 0
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		if r == '\\' {
+			i++
+			if i == len(runes) {
+				return "", errors.New("trailing backslash")
+			}
+			r = runes[i]
+		} else if unicode.IsDigit(r) {
+			return "", fmt.Errorf("count without a preceding character at position %d", i)
+		}
+		i++
+
+		start := i
+		for i < len(runes) && runes[i] >= '0' && runes[i] <= '9' {
+			i++
+		}
+		if start == i {
+			return "", fmt.Errorf("missing count for %q", r)
+		}
+		count, err := strconv.Atoi(string(runes[start:i]))
+		if err != nil || count == 0 {
+			return "", fmt.Errorf("invalid count %q for %q", string(runes[start:i]), r)
+		}
+		if count > MaxRunLengthDecodedLen-total {
+			return "", fmt.Errorf("decoded length exceeds %d characters", MaxRunLengthDecodedLen)
+		}
+		total += count
+		for ; count > 0; count-- {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String(), nil
+}
+
+// SafeString 将字节切片按UTF-8解码为字符串，非法的字节序列替换为替换字符U+FFFD
+// 适用于读取外部来源的字节数据，避免日志等输出中出现损坏的字符串；
+// 连续的非法字节只替换为一个U+FFFD，合法的输入原样转换
+// 参数:
+//
+//	b - 待转换的字节切片
+//
+// 返回值:
+//
+//	合法的UTF-8字符串，nil或空切片返回空字符串
+//
+// 示例:
+//
+//	SafeString([]byte("héllo")) → "héllo"
+//	SafeString([]byte{'a', 0xff, 0xfe, 'b'}) → "a�b"
+func SafeString(b []byte) string {
+	if utf8.Valid(b) {
+		return string(b)
+	}
+	return strings.ToValidUTF8(string(b), string(utf8.RuneError))
+}
+
+// IsValidUTF8 判断字节切片是否为合法的UTF-8编码
+// 参数:
+//
+//	b - 待检查的字节切片
+//
+// 返回值:
+//
+//	合法时返回true，nil或空切片也返回true
+//
+// 示例:
+//
+//	IsValidUTF8([]byte("你好")) → true
+//	IsValidUTF8([]byte{0xe4, 0xbd}) → false
+func IsValidUTF8(b []byte) bool {
+	return utf8.Valid(b)
+}
+
+// FNV-1a 64位哈希的参数
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// HashString 计算字符串的64位FNV-1a哈希值
+// 结果只取决于输入内容，在不同进程和不同次运行之间保持一致，
+// 适用于分桶、分片等需要稳定映射的场景；不具备抗碰撞性，不能用于安全相关的用途
+// 参数:
+//
+//	s - 待计算的字符串
+//
+// 返回值:
+//
+//	64位哈希值
+//
+// 示例:
+//
+//	HashString("") → 14695981039346656037
+//	HashString("a") → 12638187200555641996
+func HashString(s string) uint64 {
+	h := uint64(fnvOffset64)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnvPrime64
+	}
+	return h
+}
+
+// HashToColor 根据字符串的哈希值生成固定的十六进制颜色
+// 同一个字符串总是得到相同的颜色，适用于头像背景色、标签颜色等场景
+// 参数:
+//
+//	s - 待映射的字符串
+//
+// 返回值:
+//
+//	"#rrggbb"格式的小写十六进制颜色
+//
+// 示例:
+//
+//	HashToColor("alice") → "#2b13bc"
+func HashToColor(s string) string {
+	// 折叠高位，使颜色同时受到哈希所有位的影响
+	h := HashString(s)
+	h ^= h >> 32
+	return fmt.Sprintf("#%06x", h&0xffffff)
+}
+
+// HashToBucket 根据字符串的哈希值将其映射到[0, n)中的一个桶
+// 同一个字符串在n不变时总是落在同一个桶中
+// 参数:
+//
+//	s - 待映射的字符串
+//	n - 桶的数量
+//
+// 返回值:
+//
+//	桶的下标，范围为[0, n)；n不大于0时返回0
+//
+// 示例:
+//
+//	HashToBucket("user-42", 8) → 3
+func HashToBucket(s string, n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return int(HashString(s) % uint64(n))
+}
+
+// e164MaxDigits E.164号码（不含"+"）的最大位数
+const e164MaxDigits = 15
+
+// NormalizePhone 将各种书写格式的电话号码规范化为E.164格式（如"+8613812345678"）
+// 基于简单规则实现，不依赖号码规划数据：
+//   - 允许的分隔符为空白、"-"、"."、"("和")"，其他非数字字符视为错误
+//   - 以"+"或国际冠字"00"开头的号码视为已包含国家代码，其余号码视为本国号码，
+//     去掉一个开头的长途前缀"0"后加上defaultCountryCode
+//
+// 限制: 不校验号码在所属国家是否真实存在或长度是否正确，不处理分机号，
+// 也不处理"011"等其他国际冠字和意大利等保留开头"0"的号码规划
+// 参数:
+//
+//	s - 待规范化的电话号码
+//	defaultCountryCode - 本国号码使用的国家代码，如"86"或"+86"
+//
+// 返回值:
+//
+//	E.164格式的号码；号码为空、含有非法字符、缺少或给出非法的国家代码、
+//	位数超过15位或本国号码部分少于4位时返回错误
+//
+// 示例:
+//
+//	NormalizePhone("(138) 1234-5678", "86") → "+8613812345678", nil
+//	NormalizePhone("020 7946 0958", "44") → "+442079460958", nil
+//	NormalizePhone("+1 (415) 555-2671", "86") → "+14155552671", nil
+func NormalizePhone(s, defaultCountryCode string) (string, error) {
+	s = TrimAll(s)
+	if s == "" {
+		return "", errors.New("empty phone number")
+	}
+
+	international := false
+	switch {
+	case strings.HasPrefix(s, "+"):
+		international, s = true, s[1:]
+	case strings.HasPrefix(s, "00"):
+		international, s = true, s[2:]
+	}
+
+	var digits strings.Builder
+	digits.Grow(len(s) + 4)
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", fmt.Errorf("invalid character %q in phone number", r)
+		}
+	}
+	number := digits.String()
+
+	if !international {
+		code := strings.TrimPrefix(TrimAll(defaultCountryCode), "+")
+		if code == "" || len(code) > 3 || strings.Trim(code, "0123456789") != "" || code[0] == '0' {
+			return "", fmt.Errorf("invalid default country code %q", defaultCountryCode)
+		}
+		number = code + strings.TrimPrefix(number, "0")
+	} else if strings.HasPrefix(number, "0") {
+		return "", errors.New("country code must not start with 0")
+	}
+
+	if len(number)-phoneCountryCodeLen(number) < 4 {
+		return "", fmt.Errorf("phone number %q is too short", number)
+	}
+	if len(number) > e164MaxDigits {
+		return "", fmt.Errorf("phone number %q exceeds %d digits", number, e164MaxDigits)
+	}
+	return "+" + number, nil
+}
+
+// FormatPhone 将E.164格式的号码按分组格式化，便于显示
+// 国家代码与号码之间以空格分隔；北美号码（国家代码1且为10位）按3-3-4分组，
+// 其余号码从右向左每4位一组，如中国手机号为3-4-4
+// 参数:
+//
+//	e164 - E.164格式的号码，通常来自NormalizePhone
+//
+// 返回值:
+//
+//	分组后的号码；输入不是以"+"开头的纯数字号码时原样返回
+//
+// 示例:
+//
+//	FormatPhone("+8613812345678") → "+86 138 1234 5678"
+//	FormatPhone("+14155552671") → "+1 415 555 2671"
+//	FormatPhone("+442079460958") → "+44 20 7946 0958"
+func FormatPhone(e164 string) string {
+	number, ok := strings.CutPrefix(e164, "+")
+	if !ok || number == "" || len(number) > e164MaxDigits || strings.Trim(number, "0123456789") != "" {
+		return e164
+	}
+
+	ccLen := phoneCountryCodeLen(number)
+	if ccLen >= len(number) {
+		return e164
+	}
+	code, national := number[:ccLen], number[ccLen:]
+
+	var groups []string
+	if code == "1" && len(national) == 10 {
+		groups = []string{national[:3], national[3:6], national[6:]}
+	} else {
+		for len(national) > 4 {
+			groups = append(groups, national[len(national)-4:])
+			national = national[:len(national)-4]
+		}
+		groups = append(groups, national)
+		for i, j := 0, len(groups)-1; i < j; i, j = i+1, j-1 {
+			groups[i], groups[j] = groups[j], groups[i]
+		}
+	}
+	return "+" + code + " " + strings.Join(groups, " ")
+}
+
+// phoneCountryCodeLen 根据ITU-T E.164的国家代码分配规则返回号码开头国家代码的位数
+// 国家代码是前缀码: 以1和7开头的为1位，下列开头的为2位，其余为3位
+func phoneCountryCodeLen(digits string) int {
+	if digits == "" {
+		return 0
+	}
+	switch digits[0] {
+	case '1', '7':
+		return 1
+	}
+	if len(digits) < 2 {
+		return len(digits)
+	}
+	switch digits[:2] {
+	case "20", "27", "30", "31", "32", "33", "34", "36", "39",
+		"40", "41", "43", "44", "45", "46", "47", "48", "49",
+		"51", "52", "53", "54", "55", "56", "57", "58",
+		"60", "61", "62", "63", "64", "65", "66",
+		"81", "82", "84", "86", "90", "91", "92", "93", "94", "95", "98":
+		return 2
+	}
+	if len(digits) < 3 {
+		return len(digits)
+	}
+	return 3
+}
+
+// matchesMaskRune 判断字符是否符合掩码中的占位符，ok表示mask是否为占位符
+func matchesMaskRune(r, mask rune) (match, ok bool) {
+	switch mask {
+	case '#':
+		return r >= '0' && r <= '9', true
+	case 'A':
+		return unicode.IsLetter(r), true
+	case '*':
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+// MatchesMask 判断字符串是否符合输入掩码，用于校验带格式的表单输入
+// 掩码中'#'匹配一个ASCII数字，'A'匹配一个字母（包括非ASCII字母），'*'匹配任意一个字符，
+// 其他字符必须原样出现；字符串与掩码的字符数必须相同
+// 参数:
+//
+//	s - 待校验的字符串
+//	mask - 输入掩码，如"###-##-####"
+//
+// 返回值:
+//
+//	符合掩码时返回true
+//
+// 示例:
+//
+//	MatchesMask("123-45-6789", "###-##-####") → true
+//	MatchesMask("123-45-678", "###-##-####") → false
+//	MatchesMask("AB-12", "AA-##") → true
+func MatchesMask(s, mask string) bool {
+	maskRunes := []rune(mask)
+	i := 0
+	for _, r := range s {
+		if i >= len(maskRunes) {
+			return false
+		}
+		match, ok := matchesMaskRune(r, maskRunes[i])
+		if !ok {
+			match = r == maskRunes[i]
+		}
+		if !match {
+			return false
+		}
+		i++
+	}
+	return i == len(maskRunes)
+}
+
+// ApplyMask 按输入掩码格式化原始输入，在对应位置插入掩码中的字面字符
+// 依次用输入中的字符填充掩码的占位符（'#'、'A'、'*'，含义同MatchesMask），
+// 不符合当前占位符的输入字符会被跳过，因此已带分隔符的输入也能得到相同结果；
+// 输入用完时在下一个占位符前停止，适合输入过程中的实时格式化，多余的输入被丢弃
+// 参数:
+//
+//	input - 原始输入，如"123456789"
+//	mask - 输入掩码，如"###-##-####"
+//
+// 返回值:
+//
+//	格式化后的字符串；输入不足时只包含已填充的部分，可用MatchesMask判断是否完整
+//
+// 示例:
+//
+//	ApplyMask("123456789", "###-##-####") → "123-45-6789"
+//	ApplyMask("12345", "###-##-####") → "123-45"
+//	ApplyMask("123 45 6789", "###-##-####") → "123-45-6789"
+//	ApplyMask("415", "(###)") → "(415)"
+func ApplyMask(input, mask string) string {
+	inputRunes := []rune(input)
+	var builder strings.Builder
+	builder.Grow(len(mask))
+	next := 0           // 下一个待使用的输入字符的下标
+	var literals []rune // 等待写入的字面字符，只有其后的占位符被填充时才写入
+	for _, m := range mask {
+		if _, ok := matchesMaskRune(0, m); !ok {
+			literals = append(literals, m)
+			continue
+		}
+		for next < len(inputRunes) {
+			if match, _ := matchesMaskRune(inputRunes[next], m); match {
+				break
+			}
+			next++
+		}
+		if next >= len(inputRunes) {
+			return builder.String()
+		}
+		for _, l := range literals {
+			builder.WriteRune(l)
+		}
+		literals = literals[:0]
+		builder.WriteRune(inputRunes[next])
+		next++
+	}
+	// 所有占位符都已填充，写入最后一个占位符之后的字面字符
+	for _, l := range literals {
+		builder.WriteRune(l)
+	}
+	return builder.String()
+}
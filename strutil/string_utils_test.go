@@ -1,7 +1,10 @@
 package strutil
 
 import (
+	"strconv"
+	"strings"
 	"testing"
+	"testing/iotest"
 )
 
 func TestUrlEncode(t *testing.T) {
@@ -551,6 +554,46 @@ func TestEquals(t *testing.T) {
 	}
 }
 
+func TestSecureEquals(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+	}{{
+		name: "equal strings",
+		a:    "s3cr3t-token",
+		b:    "s3cr3t-token",
+	}, {
+		name: "equal length mismatch",
+		a:    "s3cr3t-token",
+		b:    "s3cr3t-tokem",
+	}, {
+		name: "different length",
+		a:    "short",
+		b:    "much longer",
+	}, {
+		name: "both empty",
+		a:    "",
+		b:    "",
+	}, {
+		name: "one empty",
+		a:    "",
+		b:    "x",
+	}, {
+		name: "chinese",
+		a:    "密钥",
+		b:    "密钥",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := SecureEquals(tt.a, tt.b), tt.a == tt.b; got != want {
+				t.Errorf("SecureEquals(%q, %q) = %v, want %v", tt.a, tt.b, got, want)
+			}
+		})
+	}
+}
+
 func TestDefaultIfEmpty(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1083,6 +1126,117 @@ func TestFormat(t *testing.T) {
 	}
 }
 
+func TestCompileFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		params   []string
+	}{
+		{"multiple placeholders", "Hello, {}! Today is {}.", []string{"Alice", "Monday"}},
+		{"more parameters", "{} and {}", []string{"A", "B", "C"}},
+		{"fewer parameters", "Name: {}, Age: {}", []string{"Bob"}},
+		{"named placeholder", "Hi {name}, {}", []string{"Carol"}},
+		{"unclosed placeholder", "total: {} {", []string{"3"}},
+		{"nested brace", "a{b{c}d", []string{"X"}},
+		{"no placeholders", "plain", []string{"extra"}},
+		{"empty template", "", nil},
+		{"unicode", "你好，{}！", []string{"世界"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpl := CompileFormat(tt.template)
+			want := Format(tt.template, tt.params...)
+			if got := tpl.Render(tt.params...); got != want {
+				t.Errorf("CompileFormat(%q).Render(%v) = %q, want %q", tt.template, tt.params, got, want)
+			}
+			// 同一模板可重复渲染
+			if got := tpl.Render(tt.params...); got != want {
+				t.Errorf("second Render(%v) = %q, want %q", tt.params, got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkFormat(b *testing.B) {
+	const template = "user {} logged in from {} at {}"
+	b.Run("Format", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Format(template, "alice", "10.0.0.1", "12:00")
+		}
+	})
+	b.Run("CompiledTemplate", func(b *testing.B) {
+		tpl := CompileFormat(template)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			tpl.Render("alice", "10.0.0.1", "12:00")
+		}
+	})
+}
+
+func TestReverseFastPath(t *testing.T) {
+	// 参照实现：始终按rune处理
+	runeReverse := func(s string) string {
+		runes := []rune(s)
+		for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+			runes[i], runes[j] = runes[j], runes[i]
+		}
+		return string(runes)
+	}
+	runePalindrome := func(s string) bool {
+		runes := []rune(s)
+		for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+			if runes[i] != runes[j] {
+				return false
+			}
+		}
+		return true
+	}
+
+	inputs := []string{
+		"", "a", "ab", "abba", "abcba", "hello world", "A man, a plan",
+		"世界", "上海自来水来自海上", "héllo", "a世a", "ab世ba", "\xff\xfe", "a\xffa",
+	}
+	for _, s := range inputs {
+		if got, want := Reverse(s), runeReverse(s); got != want {
+			t.Errorf("Reverse(%q) = %q, want %q", s, got, want)
+		}
+		if got, want := IsPalindrome(s), runePalindrome(s); got != want {
+			t.Errorf("IsPalindrome(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func BenchmarkReverse(b *testing.B) {
+	ascii := strings.Repeat("request_id=42 status=ok ", 8)
+	multibyte := strings.Repeat("请求编号=42 状态=成功 ", 8)
+	b.Run("ASCII", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Reverse(ascii)
+		}
+	})
+	b.Run("Multibyte", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Reverse(multibyte)
+		}
+	})
+}
+
+func BenchmarkIsPalindrome(b *testing.B) {
+	ascii := strings.Repeat("a", 100) + strings.Repeat("a", 100)
+	multibyte := strings.Repeat("上海", 50) + strings.Repeat("海上", 50)
+	b.Run("ASCII", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			IsPalindrome(ascii)
+		}
+	})
+	b.Run("Multibyte", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			IsPalindrome(multibyte)
+		}
+	})
+}
+
 func TestToUpper(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1305,3 +1459,1673 @@ func equalStringSlices(a, b []string) bool {
 	}
 	return true
 }
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want int
+	}{{
+		name: "ascii",
+		s:    "hello",
+		want: 5,
+	}, {
+		name: "chinese",
+		s:    "你好",
+		want: 4,
+	}, {
+		name: "mixed",
+		s:    "Go语言",
+		want: 6,
+	}, {
+		name: "fullwidth punctuation",
+		s:    "，。",
+		want: 4,
+	}, {
+		name: "combining mark",
+		s:    "e\u0301",
+		want: 1,
+	}, {
+		name: "empty",
+		s:    "",
+		want: 0,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DisplayWidth(tt.s); got != tt.want {
+				t.Errorf("DisplayWidth(%q) = %d, want %d", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    [][]string
+		headers []string
+		want    string
+	}{{
+		name:    "two columns",
+		rows:    [][]string{{"Alice", "30"}, {"Bartholomew", "7"}},
+		headers: []string{"Name", "Age"},
+		want: "Name        | Age\n" +
+			"------------+----\n" +
+			"Alice       | 30\n" +
+			"Bartholomew | 7\n",
+	}, {
+		name:    "ragged rows",
+		rows:    [][]string{{"a"}, {"b", "c", "d"}},
+		headers: []string{"x", "y"},
+		want: "x | y | \n" +
+			"--+---+--\n" +
+			"a |   | \n" +
+			"b | c | d\n",
+	}, {
+		name:    "cjk alignment",
+		rows:    [][]string{{"张三", "北京"}, {"Bob", "NY"}},
+		headers: []string{"姓名", "城市"},
+		want: "姓名 | 城市\n" +
+			"-----+-----\n" +
+			"张三 | 北京\n" +
+			"Bob  | NY\n",
+	}, {
+		name:    "no headers",
+		rows:    [][]string{{"k", "v"}, {"key", "value"}},
+		headers: nil,
+		want: "k   | v\n" +
+			"key | value\n",
+	}, {
+		name:    "empty",
+		rows:    nil,
+		headers: nil,
+		want:    "",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatTable(tt.rows, tt.headers); got != tt.want {
+				t.Errorf("FormatTable() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeTypography(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{{
+		name: "curly quotes and nbsp",
+		s:    "“Hello”,\u00a0it’s\u202f‘fine’",
+		want: "\"Hello\", it's 'fine'",
+	}, {
+		name: "dashes",
+		s:    "2020–2023 — done − 1",
+		want: "2020-2023 - done - 1",
+	}, {
+		name: "zero width and ellipsis",
+		s:    "wa\u200bit\ufeff…",
+		want: "wait...",
+	}, {
+		name: "plain ascii unchanged",
+		s:    "plain \"ascii\" text",
+		want: "plain \"ascii\" text",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeTypography(tt.s); got != tt.want {
+				t.Errorf("NormalizeTypography(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSmartify(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{{
+		name: "quotes and apostrophe",
+		s:    "\"Hello\" -- it's 'fine'...",
+		want: "“Hello” — it’s ‘fine’…",
+	}, {
+		name: "quote after bracket",
+		s:    "(\"a\")",
+		want: "(“a”)",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Smartify(tt.s); got != tt.want {
+				t.Errorf("Smartify(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		s       string
+		want    bool
+	}{{
+		name:    "subsequence",
+		pattern: "gco",
+		s:       "git checkout",
+		want:    true,
+	}, {
+		name:    "missing rune",
+		pattern: "gcx",
+		s:       "git checkout",
+		want:    false,
+	}, {
+		name:    "case insensitive",
+		pattern: "GCO",
+		s:       "git checkout",
+		want:    true,
+	}, {
+		name:    "wrong order",
+		pattern: "ocg",
+		s:       "git checkout",
+		want:    false,
+	}, {
+		name:    "chinese",
+		pattern: "中文",
+		s:       "中国文字",
+		want:    true,
+	}, {
+		name:    "empty pattern",
+		pattern: "",
+		s:       "anything",
+		want:    true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FuzzyMatch(tt.pattern, tt.s); got != tt.want {
+				t.Errorf("FuzzyMatch(%q, %q) = %v, want %v", tt.pattern, tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		better  string
+		worse   string
+	}{{
+		name:    "contiguous beats scattered",
+		pattern: "check",
+		better:  "git checkout",
+		worse:   "cache hack",
+	}, {
+		name:    "early beats late",
+		pattern: "log",
+		better:  "logs",
+		worse:   "git log",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			better := FuzzyScore(tt.pattern, tt.better)
+			worse := FuzzyScore(tt.pattern, tt.worse)
+			if better <= worse {
+				t.Errorf("FuzzyScore(%q, %q) = %d, want > FuzzyScore(%q, %q) = %d", tt.pattern, tt.better, better, tt.pattern, tt.worse, worse)
+			}
+		})
+	}
+
+	if got := FuzzyScore("gcx", "git checkout"); got != 0 {
+		t.Errorf("FuzzyScore(no match) = %d, want 0", got)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		size    int
+		want    []string
+		wantErr bool
+	}{{
+		name: "card number",
+		s:    "1234567890123456",
+		size: 4,
+		want: []string{"1234", "5678", "9012", "3456"},
+	}, {
+		name: "shorter last group",
+		s:    "abcdefg",
+		size: 3,
+		want: []string{"abc", "def", "g"},
+	}, {
+		name: "cjk runes",
+		s:    "你好世界再见",
+		size: 4,
+		want: []string{"你好世界", "再见"},
+	}, {
+		name: "empty string",
+		s:    "",
+		size: 2,
+		want: []string{},
+	}, {
+		name:    "zero size",
+		s:       "abc",
+		size:    0,
+		wantErr: true,
+	}, {
+		name:    "negative size",
+		s:       "abc",
+		size:    -1,
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Chunk(tt.s, tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Chunk(%q, %d) error = %v, wantErr %v", tt.s, tt.size, err, tt.wantErr)
+			}
+			if !tt.wantErr && !equalStringSlices(got, tt.want) {
+				t.Errorf("Chunk(%q, %d) = %q, want %q", tt.s, tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChunkJoin(t *testing.T) {
+	got, err := ChunkJoin("1234567890123456", 4, " ")
+	if err != nil || got != "1234 5678 9012 3456" {
+		t.Errorf("ChunkJoin() = %q, %v, want %q", got, err, "1234 5678 9012 3456")
+	}
+
+	got, err = ChunkJoin("你好世界", 1, "-")
+	if err != nil || got != "你-好-世-界" {
+		t.Errorf("ChunkJoin() = %q, %v, want %q", got, err, "你-好-世-界")
+	}
+
+	if _, err := ChunkJoin("abc", 0, " "); err == nil {
+		t.Error("ChunkJoin() with zero size should fail")
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{{
+		name: "digits",
+		s:    "user 42 logged in at 10:03",
+		want: "user # logged in at #:#",
+	}, {
+		name: "uuid",
+		s:    "req 550e8400-e29b-41d4-a716-446655440000 done",
+		want: "req <uuid> done",
+	}, {
+		name: "whitespace collapsed",
+		s:    "  timeout \t after   30s \n",
+		want: "timeout after #s",
+	}, {
+		name: "empty",
+		s:    "",
+		want: "",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Fingerprint(tt.s); got != tt.want {
+				t.Errorf("Fingerprint(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+
+	a := Fingerprint("2023-10-03 12:00:01 order 1001 failed: request 550e8400-e29b-41d4-a716-446655440000")
+	b := Fingerprint("2023-10-04 08:15:59 order 87 failed: request 6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	if a != b {
+		t.Errorf("Fingerprint() of lines differing only in ids: %q != %q", a, b)
+	}
+	if c := Fingerprint("2023-10-03 12:00:01 order 1001 shipped"); c == a {
+		t.Errorf("Fingerprint() of different messages should differ, both %q", c)
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{1, "1st"}, {2, "2nd"}, {3, "3rd"}, {4, "4th"},
+		{11, "11th"}, {12, "12th"}, {13, "13th"},
+		{21, "21st"}, {22, "22nd"}, {23, "23rd"},
+		{0, "0th"}, {101, "101st"}, {111, "111th"}, {-1, "-1st"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := Ordinal(tt.n); got != tt.want {
+				t.Errorf("Ordinal(%d) = %q, want %q", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrdinalWord(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{1, "first"}, {2, "second"}, {3, "third"}, {4, "fourth"},
+		{11, "eleventh"}, {12, "twelfth"}, {13, "thirteenth"},
+		{21, "twenty-first"}, {22, "twenty-second"}, {23, "twenty-third"},
+		{40, "fortieth"}, {100, "one hundredth"}, {1005, "one thousand fifth"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := OrdinalWord(tt.n); got != tt.want {
+				t.Errorf("OrdinalWord(%d) = %q, want %q", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumberToWords(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "zero"},
+		{7, "seven"},
+		{15, "fifteen"},
+		{40, "forty"},
+		{123, "one hundred twenty-three"},
+		{1005, "one thousand five"},
+		{1000000, "one million"},
+		{-42, "minus forty-two"},
+		{2001300, "two million one thousand three hundred"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := NumberToWords(tt.n); got != tt.want {
+				t.Errorf("NumberToWords(%d) = %q, want %q", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"empty", "", "''"},
+		{"safe", "file-1.txt", "file-1.txt"},
+		{"path", "/usr/local/bin", "/usr/local/bin"},
+		{"spaces", "hello world", "'hello world'"},
+		{"spaces and quote", "it's a file", `'it'"'"'s a file'`},
+		{"shell metacharacters", "a;rm -rf $HOME", "'a;rm -rf $HOME'"},
+		{"double quote", `say "hi"`, `'say "hi"'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ShellQuote(tt.s); got != tt.want {
+				t.Errorf("ShellQuote(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVField(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"plain", "plain", "plain"},
+		{"empty", "", ""},
+		{"comma", "a,b", `"a,b"`},
+		{"comma and quote", `Smith, "Bob"`, `"Smith, ""Bob"""`},
+		{"newline", "line1\nline2", "\"line1\nline2\""},
+		{"carriage return", "a\rb", "\"a\rb\""},
+		{"spaces kept", " x ", " x "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CSVField(tt.s); got != tt.want {
+				t.Errorf("CSVField(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWordFrequency(t *testing.T) {
+	got := WordFrequency("The cat and the hat and THE bat")
+	want := map[string]int{"the": 3, "cat": 1, "and": 2, "hat": 1, "bat": 1}
+	if len(got) != len(want) {
+		t.Fatalf("WordFrequency() = %v, want %v", got, want)
+	}
+	for word, count := range want {
+		if got[word] != count {
+			t.Errorf("WordFrequency()[%q] = %d, want %d", word, got[word], count)
+		}
+	}
+
+	got = WordFrequency("a,b;A,,c", ',', ';')
+	if got["a"] != 2 || got["b"] != 1 || got["c"] != 1 || len(got) != 3 {
+		t.Errorf("WordFrequency() with separators = %v", got)
+	}
+
+	if got := WordFrequency(""); len(got) != 0 {
+		t.Errorf("WordFrequency(\"\") = %v, want empty", got)
+	}
+}
+
+func TestTopWords(t *testing.T) {
+	s := "the cat and the hat and the bat sat"
+
+	tests := []struct {
+		name string
+		n    int
+		want []WordFreq
+	}{{
+		name: "top one",
+		n:    1,
+		want: []WordFreq{{"the", 3}},
+	}, {
+		name: "ties sorted alphabetically",
+		n:    4,
+		want: []WordFreq{{"the", 3}, {"and", 2}, {"bat", 1}, {"cat", 1}},
+	}, {
+		name: "n larger than distinct words",
+		n:    10,
+		want: []WordFreq{{"the", 3}, {"and", 2}, {"bat", 1}, {"cat", 1}, {"hat", 1}, {"sat", 1}},
+	}, {
+		name: "zero n",
+		n:    0,
+		want: []WordFreq{},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TopWords(s, tt.n)
+			if len(got) != len(tt.want) {
+				t.Fatalf("TopWords(%d) = %v, want %v", tt.n, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("TopWords(%d) = %v, want %v", tt.n, got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestCensor(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		words []string
+		want  string
+	}{{
+		name:  "listed word masked",
+		s:     "You are a Fool, really.",
+		words: []string{"fool"},
+		want:  "You are a ****, really.",
+	}, {
+		name:  "substring not masked",
+		s:     "foolish classes",
+		words: []string{"fool", "ass"},
+		want:  "foolish classes",
+	}, {
+		name:  "multiple occurrences and words",
+		s:     "darn it, DARN heck",
+		words: []string{"darn", "heck"},
+		want:  "**** it, **** ****",
+	}, {
+		name:  "multi-byte word",
+		s:     "你是笨蛋 吗",
+		words: []string{"笨蛋"},
+		want:  "你是笨蛋 吗",
+	}, {
+		name:  "multi-byte whole word",
+		s:     "说 笨蛋 了",
+		words: []string{"笨蛋"},
+		want:  "说 ** 了",
+	}, {
+		name:  "empty word ignored",
+		s:     "hello",
+		words: []string{""},
+		want:  "hello",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Censor(tt.s, tt.words, '*'); got != tt.want {
+				t.Errorf("Censor(%q, %v) = %q, want %q", tt.s, tt.words, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCensorContains(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		words    []string
+		maskChar rune
+		want     string
+	}{{
+		name:     "substring masked",
+		s:        "foolish Classes",
+		words:    []string{"fool", "ass"},
+		maskChar: '*',
+		want:     "****ish Cl***es",
+	}, {
+		name:     "multi-byte substring",
+		s:        "你是笨蛋吗",
+		words:    []string{"笨蛋"},
+		maskChar: '#',
+		want:     "你是##吗",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CensorContains(tt.s, tt.words, tt.maskChar); got != tt.want {
+				t.Errorf("CensorContains(%q, %v) = %q, want %q", tt.s, tt.words, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		maxWords int
+		ellipsis string
+		want     string
+	}{{
+		name:     "ten words to five",
+		s:        "one two three four five six seven eight nine ten",
+		maxWords: 5,
+		ellipsis: "...",
+		want:     "one two three four five...",
+	}, {
+		name:     "not truncated",
+		s:        "one  two three",
+		maxWords: 3,
+		ellipsis: "...",
+		want:     "one  two three",
+	}, {
+		name:     "collapses whitespace",
+		s:        "one\ttwo\n three four",
+		maxWords: 3,
+		ellipsis: "…",
+		want:     "one two three…",
+	}, {
+		name:     "zero words",
+		s:        "one two",
+		maxWords: 0,
+		ellipsis: "...",
+		want:     "...",
+	}, {
+		name:     "empty",
+		s:        "",
+		maxWords: 2,
+		ellipsis: "...",
+		want:     "",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TruncateWords(tt.s, tt.maxWords, tt.ellipsis); got != tt.want {
+				t.Errorf("TruncateWords(%q, %d) = %q, want %q", tt.s, tt.maxWords, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateAtWord(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		maxChars int
+		ellipsis string
+		want     string
+	}{{
+		name:     "mid-word backs up",
+		s:        "hello wonderful world",
+		maxChars: 12,
+		ellipsis: "...",
+		want:     "hello...",
+	}, {
+		name:     "limit on word boundary",
+		s:        "hello wonderful world",
+		maxChars: 15,
+		ellipsis: "...",
+		want:     "hello wonderful...",
+	}, {
+		name:     "limit on trailing space",
+		s:        "hello wonderful world",
+		maxChars: 16,
+		ellipsis: "...",
+		want:     "hello wonderful...",
+	}, {
+		name:     "not truncated",
+		s:        "hello",
+		maxChars: 5,
+		ellipsis: "...",
+		want:     "hello",
+	}, {
+		name:     "single long word hard cut",
+		s:        "supercalifragilistic",
+		maxChars: 5,
+		ellipsis: "...",
+		want:     "super...",
+	}, {
+		name:     "multi-byte",
+		s:        "你好 世界和平",
+		maxChars: 5,
+		ellipsis: "…",
+		want:     "你好…",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TruncateAtWord(tt.s, tt.maxChars, tt.ellipsis); got != tt.want {
+				t.Errorf("TruncateAtWord(%q, %d) = %q, want %q", tt.s, tt.maxChars, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want bool
+	}{
+		{"object", `{"a":1,"b":[true,null]}`, true},
+		{"array", `[1, 2, 3]`, true},
+		{"scalar", `"text"`, true},
+		{"unquoted_key", "{bad}", false},
+		{"trailing_comma", `{"a":1,}`, false},
+		{"single_quotes", `{'a':1}`, false},
+		{"empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsJSON(tt.args); got != tt.want {
+				t.Errorf("IsJSON(%q) = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsBase64(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want bool
+	}{
+		{"padded", "SGVsbG8=", true},
+		{"no_padding_needed", "SGVsbG8h", true},
+		{"missing_padding", "SGVsbG8", false},
+		{"invalid_char", "SGVs*G8=", false},
+		{"url_alphabet", "SGVsbG8_", false},
+		{"misplaced_padding", "SG=sbG8h", false},
+		{"newline", "SGVs\nbG8h", false},
+		{"empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsBase64(tt.args); got != tt.want {
+				t.Errorf("IsBase64(%q) = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsHex(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want bool
+	}{
+		{"lower", "deadbeef", true},
+		{"mixed_case", "DeadBEEF09", true},
+		{"prefix", "0x1f", false},
+		{"out_of_range", "abcg", false},
+		{"space", "ab cd", false},
+		{"empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsHex(tt.args); got != tt.want {
+				t.Errorf("IsHex(%q) = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveAccents(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"french", "Crème Brûlée", "Creme Brulee"},
+		{"german", "Straße Über", "Strasse Uber"},
+		{"polish", "Łódź", "Lodz"},
+		{"ligature", "Æsir œuvre", "AEsir oeuvre"},
+		{"untouched", "hello 你好", "hello 你好"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RemoveAccents(tt.args); got != tt.want {
+				t.Errorf("RemoveAccents(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSafeFilename(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"illegal_chars", "My: Report/2023?.pdf", "My_Report_2023.pdf"},
+		{"all_illegal", `:*?"<>|`, "untitled"},
+		{"only_dots", "...", "untitled"},
+		{"leading_dots", "..hidden file", "hidden_file"},
+		{"accents", "Café Menü.txt", "Cafe_Menu.txt"},
+		{"whitespace", "  a \t b\nc  ", "a_b_c"},
+		{"backslash", `C:\Users\me`, "C_Users_me"},
+		{"unicode_kept", "报告 2023.docx", "报告_2023.docx"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SafeFilename(tt.args); got != tt.want {
+				t.Errorf("SafeFilename(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSafeFilenameWithMaxLen(t *testing.T) {
+	long := strings.Repeat("word ", 100) + ".txt"
+	got := SafeFilename(long)
+	if len(got) > 255 {
+		t.Errorf("SafeFilename() length = %d, want <= 255", len(got))
+	}
+	if !strings.HasSuffix(got, ".txt") || !strings.HasPrefix(got, "word_word") {
+		t.Errorf("SafeFilename() = %q, want word_word... with .txt extension", got)
+	}
+
+	tests := []struct {
+		name   string
+		args   string
+		maxLen int
+		want   string
+	}{
+		{"keeps_extension", "annual report final.pdf", 14, "annual_rep.pdf"},
+		{"no_extension", "abcdefghij", 4, "abcd"},
+		{"multi_byte_not_split", "你好世界", 7, "你好"},
+		{"trailing_separator_trimmed", "ab cd", 3, "ab"},
+		{"default_when_non_positive", "abc", 0, "abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SafeFilenameWithMaxLen(tt.args, tt.maxLen); got != tt.want {
+				t.Errorf("SafeFilenameWithMaxLen(%q, %d) = %q, want %q", tt.args, tt.maxLen, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"email", "user@example.com", "xxxx@xxxxxxx.xxx"},
+		{"phone", "+86 138-1234-5678", "+xx xxx-xxxx-xxxx"},
+		{"phone_parens", "(555) 010-9999", "(xxx) xxx-xxxx"},
+		{"multi_byte", "张三_01", "xx_xx"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Redact(tt.args); got != tt.want {
+				t.Errorf("Redact(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactFully(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"value", "user@example.com", "[REDACTED]"},
+		{"empty", "", "[REDACTED]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RedactFully(tt.args); got != tt.want {
+				t.Errorf("RedactFully(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTitleCase(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"small_words", "the lord of the rings", "The Lord of the Rings"},
+		{"trailing_small_word", "what to look in", "What to Look In"},
+		{"uppercase_small_word", "war AND peace", "War and Peace"},
+		{"keeps_other_case", "a history of NASA", "A History of NASA"},
+		{"punctuation", "\"the end\" of the road.", "\"The End\" of the Road."},
+		{"whitespace_preserved", "  gone  with the wind ", "  Gone  With the Wind "},
+		{"single_small_word", "the", "The"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TitleCase(tt.args); got != tt.want {
+				t.Errorf("TitleCase(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTitleCaseAll(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"every_word", "the lord of the rings", "The Lord Of The Rings"},
+		{"multi_byte", "élan vital", "Élan Vital"},
+		{"whitespace_only", "  ", "  "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TitleCaseAll(tt.args); got != tt.want {
+				t.Errorf("TitleCaseAll(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want []string
+	}{
+		{"lf", "a\nb", []string{"a", "b"}},
+		{"crlf_and_cr", "a\r\nb\rc", []string{"a", "b", "c"}},
+		{"trailing_newline", "a\nb\n", []string{"a", "b"}},
+		{"empty_lines", "a\n\n\nb", []string{"a", "", "", "b"}},
+		{"only_newline", "\n", []string{""}},
+		{"empty", "", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Lines(tt.args)
+			if !equalStringSlices(got, tt.want) {
+				t.Errorf("Lines(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+			if LineCount(tt.args) != len(tt.want) {
+				t.Errorf("LineCount(%q) = %d, want %d", tt.args, LineCount(tt.args), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"multi_line", "hello\n\nworld", "> hello\n> \n> world"},
+		{"crlf", "line1\r\nline2\r\n", "> line1\n> line2"},
+		{"single_line", "hi", "> hi"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Quote(tt.args); got != tt.want {
+				t.Errorf("Quote(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCodeBlock(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		lang string
+		want string
+	}{
+		{"with_lang", "func main() {\r\n}\r\n", "go", "```go\nfunc main() {\n}\n```"},
+		{"without_lang", "x := 1", "", "```\nx := 1\n```"},
+		{"nested_fence", "```sh\nls\n```", "md", "````md\n```sh\nls\n```\n````"},
+		{"empty", "", "go", "```go\n```"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CodeBlock(tt.s, tt.lang); got != tt.want {
+				t.Errorf("CodeBlock(%q, %q) = %q, want %q", tt.s, tt.lang, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeWhitespace(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"tabs and newline", "a\t\tb\n c", "a b c"},
+		{"trim ends", "  hello  ", "hello"},
+		{"CRLF line endings", "key =\r\n  value\r\n", "key = value"},
+		{"unicode space", "a　 b", "a b"},
+		{"only whitespace", " \t\n ", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeWhitespace(tt.s); got != tt.want {
+				t.Errorf("NormalizeWhitespace(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEqualsIgnoreWhitespace(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{"different spacing", "host = localhost\nport = 8080\n", "  host =\tlocalhost\r\nport  =  8080", true},
+		{"identical", "a b", "a b", true},
+		{"separator removed", "ab", "a b", false},
+		{"different content", "a b", "a c", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EqualsIgnoreWhitespace(tt.a, tt.b); got != tt.want {
+				t.Errorf("EqualsIgnoreWhitespace(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountLinesReader(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want int
+	}{
+		{"CRLF", "a\r\nb\r\nc\r\n", 3},
+		{"no trailing newline", "a\nb\nc", 3},
+		{"blank lines", "a\n\n\nb\n", 4},
+		{"lone CR", "a\rb\r", 2},
+		{"single line", "hello", 1},
+		{"empty", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CountLinesReader(strings.NewReader(tt.s))
+			if err != nil {
+				t.Fatalf("CountLinesReader(%q) error = %v", tt.s, err)
+			}
+			if got != tt.want {
+				t.Errorf("CountLinesReader(%q) = %d, want %d", tt.s, got, tt.want)
+			}
+			if want := LineCount(tt.s); got != want {
+				t.Errorf("CountLinesReader(%q) = %d, LineCount = %d", tt.s, got, want)
+			}
+		})
+	}
+}
+
+func TestForEachLine(t *testing.T) {
+	// 逐字节读取，覆盖\r\n被拆分到两次读取中的情况
+	var lines []string
+	err := ForEachLine(iotest.OneByteReader(strings.NewReader("a\r\nb\r\nSTOP\r\nc")), func(line string) bool {
+		lines = append(lines, line)
+		return line != "STOP"
+	})
+	if err != nil {
+		t.Fatalf("ForEachLine() error = %v", err)
+	}
+	if want := []string{"a", "b", "STOP"}; !equalStringSlices(lines, want) {
+		t.Errorf("ForEachLine() lines = %q, want %q", lines, want)
+	}
+
+	_, err = CountLinesReader(strings.NewReader(strings.Repeat("x", 70*1024)))
+	if err == nil {
+		t.Error("CountLinesReader() with an overlong line should return an error")
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    int64
+		wantErr bool
+	}{
+		{"thousands separator", "1,234", 1234, false},
+		{"negative", "-42", -42, false},
+		{"currency and whitespace", "  $1,000,000 ", 1000000, false},
+		{"sign before currency", "-¥500", -500, false},
+		{"trailing currency", "250 €", 250, false},
+		{"explicit plus", "+7", 7, false},
+		{"decimal", "12.5", 0, true},
+		{"misplaced comma", "1,,234", 0, true},
+		{"trailing comma", "1234,", 0, true},
+		{"short group", "1,5", 0, true},
+		{"two-digit groups", "12,34", 0, true},
+		{"long leading group", "1234,567", 0, true},
+		{"letters", "12a", 0, true},
+		{"empty", "", 0, true},
+		{"only currency", "$", 0, true},
+		{"overflow", "9,223,372,036,854,775,808", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseInt(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseInt(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseInt(%q) = %d, want %d", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFloat(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    float64
+		wantErr bool
+	}{
+		{"currency and separators", "$1,234.56", 1234.56, false},
+		{"integer", "1,234", 1234, false},
+		{"negative", "-42", -42, false},
+		{"leading dot", ".5", 0.5, false},
+		{"trailing dot", "3.", 3, false},
+		{"multiple dots", "12.3.4", 0, true},
+		{"comma in fraction", "1.234,5", 0, true},
+		{"short group", "1,5", 0, true},
+		{"two-digit groups", "12,34.5", 0, true},
+		{"only dot", ".", 0, true},
+		{"exponent", "1e5", 0, true},
+		{"NaN", "NaN", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFloat(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFloat(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFloat(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitRespectingQuotes(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    []string
+		wantErr bool
+	}{
+		{"double quoted phrase", `say "hello world" now`, []string{"say", "hello world", "now"}, false},
+		{"single quoted phrase", `say 'hello world' now`, []string{"say", "hello world", "now"}, false},
+		{"escaped quotes inside double quotes", `echo "a \"quoted\" word"`, []string{"echo", `a "quoted" word`}, false},
+		{"backslash literal in single quotes", `'a\b' c`, []string{`a\b`, "c"}, false},
+		{"other quote kind inside quotes", `"it's" 'say "hi"'`, []string{"it's", `say "hi"`}, false},
+		{"escaped space", `a\ b c`, []string{"a b", "c"}, false},
+		{"adjacent parts concatenated", `a"b c"d`, []string{"ab cd"}, false},
+		{"empty quotes", `a "" b`, []string{"a", "", "b"}, false},
+		{"extra whitespace", "  a \t b\n", []string{"a", "b"}, false},
+		{"empty", "", []string{}, false},
+		{"unterminated double quote", `say "hello`, nil, true},
+		{"unterminated single quote", `say 'hello`, nil, true},
+		{"trailing backslash", `say hello\`, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SplitRespectingQuotes(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SplitRespectingQuotes(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			}
+			if !tt.wantErr && !equalStringSlices(got, tt.want) {
+				t.Errorf("SplitRespectingQuotes(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunLengthEncode(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"basic", "aaabbc", "a3b2c1"},
+		{"CJK run", "好好好的", "好3的1"},
+		{"long run", strings.Repeat("x", 12), "x12"},
+		{"digits escaped", "a11", "a1\\12"},
+		{"backslash escaped", `\\`, `\\2`},
+		{"non-adjacent repeats", "abab", "a1b1a1b1"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RunLengthEncode(tt.s)
+			if got != tt.want {
+				t.Errorf("RunLengthEncode(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+			decoded, err := RunLengthDecode(got)
+			if err != nil || decoded != tt.s {
+				t.Errorf("RunLengthDecode(%q) = %q, %v; want %q, nil", got, decoded, err, tt.s)
+			}
+		})
+	}
+}
+
+func TestRunLengthDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		wantErr bool
+	}{
+		{"basic", "a3b2c1", "aaabbc", false},
+		{"CJK", "中2文1", "中中文", false},
+		{"escaped digit", "\\73", "777", false},
+		{"count without character", "3a", "", true},
+		{"character without count", "a3b", "", true},
+		{"zero count", "a0", "", true},
+		{"trailing backslash", "a1\\", "", true},
+		{"count overflow", "a99999999999999999999", "", true},
+		{"decompression bomb", "a9999999999999999", "", true},
+		{"total exceeds limit", "a" + strconv.Itoa(MaxRunLengthDecodedLen) + "b1", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RunLengthDecode(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RunLengthDecode(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RunLengthDecode(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+
+	got, err := RunLengthDecode("a" + strconv.Itoa(MaxRunLengthDecodedLen))
+	if err != nil || len(got) != MaxRunLengthDecodedLen {
+		t.Errorf("RunLengthDecode(a%d) = %d characters, %v; want %d characters, nil", MaxRunLengthDecodedLen, len(got), err, MaxRunLengthDecodedLen)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	const composed, decomposed = "caf\u00e9", "cafe\u0301"
+	tests := []struct {
+		name string
+		s    string
+		form string
+		want string
+	}{
+		{"NFC composes", decomposed, "NFC", composed},
+		{"NFD decomposes", composed, "NFD", decomposed},
+		{"lowercase form", decomposed, "nfc", composed},
+		{"NFC keeps ligature", "\ufb01", "NFC", "\ufb01"},
+		{"NFKC folds ligature", "\ufb01", "NFKC", "fi"},
+		{"NFKD folds and decomposes", "\u00bd\u00e9", "NFKD", "1\u20442e\u0301"},
+		{"unknown form", decomposed, "XYZ", decomposed},
+		{"empty", "", "NFC", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.s, tt.form); got != tt.want {
+				t.Errorf("Normalize(%q, %q) = %q, want %q", tt.s, tt.form, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEqualsNormalized(t *testing.T) {
+	const composed, decomposed = "\u00e9", "e\u0301"
+	if Equals(composed, decomposed) {
+		t.Errorf("Equals(%q, %q) = true, want false before normalization", composed, decomposed)
+	}
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{composed, decomposed, true},
+		{decomposed, composed, true},
+		{"caf" + composed, "caf" + decomposed, true},
+		{"e", composed, false},
+		{"\ufb01", "fi", false},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		if got := EqualsNormalized(tt.a, tt.b); got != tt.want {
+			t.Errorf("EqualsNormalized(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSafeString(t *testing.T) {
+	tests := []struct {
+		name  string
+		b     []byte
+		want  string
+		valid bool
+	}{
+		{"nil", nil, "", true},
+		{"empty", []byte{}, "", true},
+		{"ascii", []byte("hello"), "hello", true},
+		{"multibyte", []byte("héllo 你好"), "héllo 你好", true},
+		{"invalid byte", []byte{'a', 0xff, 'b'}, "a\uFFFDb", false},
+		{"invalid run", []byte{'a', 0xff, 0xfe, 'b'}, "a\uFFFDb", false},
+		{"truncated multibyte", []byte{0xe4, 0xbd}, "\uFFFD", false},
+		{"surrogate", []byte{0xed, 0xa0, 0x80}, "\uFFFD", false},
+		{"overlong", []byte{0xc0, 0xaf, 'x'}, "\uFFFDx", false},
+		{"replacement char kept", []byte("a\uFFFDb"), "a\uFFFDb", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidUTF8(tt.b); got != tt.valid {
+				t.Errorf("IsValidUTF8(%q) = %v, want %v", tt.b, got, tt.valid)
+			}
+			got := SafeString(tt.b)
+			if got != tt.want {
+				t.Errorf("SafeString(%q) = %q, want %q", tt.b, got, tt.want)
+			}
+			if !IsValidUTF8([]byte(got)) {
+				t.Errorf("SafeString(%q) = %q is not valid UTF-8", tt.b, got)
+			}
+		})
+	}
+}
+
+func TestSplitIdentifier(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []string
+	}{
+		{"getHTTPResponseCode", []string{"get", "HTTP", "Response", "Code"}},
+		{"GetHTTPResponseCode", []string{"Get", "HTTP", "Response", "Code"}},
+		{"user_id", []string{"user", "id"}},
+		{"__user__id__", []string{"user", "id"}},
+		{"kebab-case-name", []string{"kebab", "case", "name"}},
+		{"XMLHttpRequest", []string{"XML", "Http", "Request"}},
+		{"userID", []string{"user", "ID"}},
+		{"HTTP", []string{"HTTP"}},
+		{"HTTP2Server", []string{"HTTP2", "Server"}},
+		{"v2Api", []string{"v2", "Api"}},
+		{"snake_CaseMix-up", []string{"snake", "Case", "Mix", "up"}},
+		{"a", []string{"a"}},
+		{"用户Name", []string{"用户", "Name"}},
+		{"", []string{}},
+		{"--", []string{}},
+	}
+	for _, tt := range tests {
+		got := SplitIdentifier(tt.s)
+		if len(got) != len(tt.want) {
+			t.Errorf("SplitIdentifier(%q) = %q, want %q", tt.s, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("SplitIdentifier(%q) = %q, want %q", tt.s, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestHumanizeIdentifier(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"getHTTPResponseCode", "Get HTTP Response Code"},
+		{"created_at", "Created At"},
+		{"user-ID", "User ID"},
+		{"PascalCase", "Pascal Case"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := HumanizeIdentifier(tt.s); got != tt.want {
+			t.Errorf("HumanizeIdentifier(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestSortRunes(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"listen", "eilnst"},
+		{"silent", "eilnst"},
+		{"cba", "abc"},
+		{"你好你", "你你好"},
+		{"b你a", "ab你"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := SortRunes(tt.s); got != tt.want {
+			t.Errorf("SortRunes(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestAreAnagrams(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"listen", "silent", true},
+		{"Listen", "Silent", true},
+		{"蜜蜂", "蜂蜜", true},
+		{"上海自来水", "水来自海上", true},
+		{"abc", "abd", false},
+		{"aab", "abb", false},
+		{"abc", "abcd", false},
+		{"dormitory", "dirty room", false},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		if got := AreAnagrams(tt.a, tt.b); got != tt.want {
+			t.Errorf("AreAnagrams(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	const tmpl = "Hi {name}{if premium}, thanks for subscribing{end}!"
+	tests := []struct {
+		name    string
+		tmpl    string
+		data    map[string]interface{}
+		want    string
+		wantErr bool
+	}{
+		{"conditional present", tmpl, map[string]interface{}{"name": "Ann", "premium": true}, "Hi Ann, thanks for subscribing!", false},
+		{"conditional false", tmpl, map[string]interface{}{"name": "Ann", "premium": false}, "Hi Ann!", false},
+		{"conditional absent", tmpl, map[string]interface{}{"name": "Ann"}, "Hi Ann!", false},
+		{"text around placeholders", "[{a}-{b}]: {c}.", map[string]interface{}{"a": 1, "b": "x", "c": 2.5}, "[1-x]: 2.5.", false},
+		{"spaces in tags", "{ name }{ if vip }*{ end }", map[string]interface{}{"name": "Bo", "vip": 1}, "Bo*", false},
+		{"nested", "{if a}A{if b}B{end}a{end}.", map[string]interface{}{"a": "yes", "b": 0}, "Aa.", false},
+		{"nested inner true outer false", "{if a}A{if b}B{end}{end}.", map[string]interface{}{"a": "", "b": true}, ".", false},
+		{"missing key in skipped section", "{if a}{missing}{end}ok", map[string]interface{}{}, "ok", false},
+		{"truthy empty slice", "{if items}has items{end}", map[string]interface{}{"items": []string{}}, "", false},
+		{"truthy slice", "{if items}has items{end}", map[string]interface{}{"items": []string{"x"}}, "has items", false},
+		{"nil value", "[{v}]{if v}set{end}", map[string]interface{}{"v": nil}, "[]", false},
+		{"escaped braces", "{{name}} is {name}", map[string]interface{}{"name": "Ann"}, "{name} is Ann", false},
+		{"unicode", "你好，{name}{if vip}（会员）{end}", map[string]interface{}{"name": "张三", "vip": true}, "你好，张三（会员）", false},
+		{"no tags", "plain } text", nil, "plain } text", false},
+		{"missing key", "Hi {name}", map[string]interface{}{}, "", true},
+		{"unclosed tag", "Hi {name", map[string]interface{}{"name": "Ann"}, "", true},
+		{"unclosed if", "{if a}text", map[string]interface{}{"a": true}, "", true},
+		{"unexpected end", "text{end}", nil, "", true},
+		{"empty tag", "a{}b", nil, "", true},
+		{"if without key", "{if }x{end}", nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderTemplate(tt.tmpl, tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RenderTemplate(%q) error = %v, wantErr %v", tt.tmpl, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RenderTemplate(%q) = %q, want %q", tt.tmpl, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHashString(t *testing.T) {
+	tests := []struct {
+		input string
+		want  uint64
+	}{
+		{"", 0xcbf29ce484222325},
+		{"a", 0xaf63dc4c8601ec8c},
+		{"foobar", 0x85944171f73967e8},
+	}
+	for _, tt := range tests {
+		if got := HashString(tt.input); got != tt.want {
+			t.Errorf("HashString(%q) = %#x, want %#x", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestHashToColor(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, input := range []string{"", "alice", "bob", "carol", "张三"} {
+		color := HashToColor(input)
+		if len(color) != 7 || color[0] != '#' || strings.Trim(color[1:], "0123456789abcdef") != "" {
+			t.Errorf("HashToColor(%q) = %q, want #rrggbb", input, color)
+		}
+		for i := 0; i < 3; i++ {
+			if again := HashToColor(input); again != color {
+				t.Errorf("HashToColor(%q) = %q, then %q; want stable result", input, color, again)
+			}
+		}
+		seen[color] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("HashToColor produced %d distinct colors for 5 inputs", len(seen))
+	}
+}
+
+func TestHashToBucket(t *testing.T) {
+	for _, n := range []int{1, 2, 7, 16, 1000} {
+		counts := make([]int, n)
+		for i := 0; i < 1000; i++ {
+			input := "user-" + strconv.Itoa(i)
+			bucket := HashToBucket(input, n)
+			if bucket < 0 || bucket >= n {
+				t.Fatalf("HashToBucket(%q, %d) = %d, want in [0, %d)", input, n, bucket, n)
+			}
+			if again := HashToBucket(input, n); again != bucket {
+				t.Errorf("HashToBucket(%q, %d) = %d, then %d; want stable result", input, n, bucket, again)
+			}
+			counts[bucket]++
+		}
+		if n == 7 {
+			for bucket, count := range counts {
+				if count == 0 {
+					t.Errorf("HashToBucket(_, 7) never chose bucket %d", bucket)
+				}
+			}
+		}
+	}
+	for _, n := range []int{0, -3} {
+		if got := HashToBucket("x", n); got != 0 {
+			t.Errorf("HashToBucket(x, %d) = %d, want 0", n, got)
+		}
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		code    string
+		want    string
+		wantErr bool
+	}{
+		{"chinese mobile with separators", "(138) 1234-5678", "86", "+8613812345678", false},
+		{"already e164", "+8613812345678", "1", "+8613812345678", false},
+		{"e164 with spaces", "+1 (415) 555-2671", "86", "+14155552671", false},
+		{"international prefix 00", "0044 20 7946 0958", "86", "+442079460958", false},
+		{"trunk prefix stripped", "020 7946 0958", "44", "+442079460958", false},
+		{"plus in country code", "138.1234.5678", "+86", "+8613812345678", false},
+		{"full-width spaces", "138\u30001234\u30005678", "86", "+8613812345678", false},
+		{"empty", "  ", "86", "", true},
+		{"letters", "138-CALL-NOW", "86", "", true},
+		{"missing country code", "13812345678", "", "", true},
+		{"invalid country code", "13812345678", "8a", "", true},
+		{"country code starting with 0", "+0123456789", "86", "", true},
+		{"too long", "+86 1381234567890123", "86", "", true},
+		{"too short", "+86 12", "86", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizePhone(tt.s, tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizePhone(%q, %q) error = %v, wantErr %v", tt.s, tt.code, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizePhone(%q, %q) = %q, want %q", tt.s, tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatPhone(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"+8613812345678", "+86 138 1234 5678"},
+		{"+14155552671", "+1 415 555 2671"},
+		{"+442079460958", "+44 20 7946 0958"},
+		{"+35312345678", "+353 1234 5678"},
+		{"+79161234567", "+7 91 6123 4567"},
+		{"+6681234", "+66 8 1234"},
+		{"8613812345678", "8613812345678"},
+		{"+86 138", "+86 138"},
+		{"+", "+"},
+		{"+86", "+86"},
+	}
+	for _, tt := range tests {
+		if got := FormatPhone(tt.input); got != tt.want {
+			t.Errorf("FormatPhone(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	normalized, err := NormalizePhone("(138) 1234-5678", "86")
+	if err != nil {
+		t.Fatalf("NormalizePhone() error = %v", err)
+	}
+	if got, want := FormatPhone(normalized), "+86 138 1234 5678"; got != want {
+		t.Errorf("FormatPhone(NormalizePhone()) = %q, want %q", got, want)
+	}
+}
+
+func TestMatchesMask(t *testing.T) {
+	tests := []struct {
+		s    string
+		mask string
+		want bool
+	}{
+		{"123-45-6789", "###-##-####", true},
+		{"123-45-678", "###-##-####", false},
+		{"123-45-67890", "###-##-####", false},
+		{"123456789", "###-##-####", false},
+		{"123-4a-6789", "###-##-####", false},
+		{"123_45_6789", "###-##-####", false},
+		{"AB-12", "AA-##", true},
+		{"\u00e9\u4e2d-12", "AA-##", true},
+		{"A1-12", "AA-##", false},
+		{"x?z", "***", true},
+		{"\uff11\uff12", "##", false},
+		{"", "", true},
+		{"", "#", false},
+	}
+	for _, tt := range tests {
+		if got := MatchesMask(tt.s, tt.mask); got != tt.want {
+			t.Errorf("MatchesMask(%q, %q) = %v, want %v", tt.s, tt.mask, got, tt.want)
+		}
+	}
+}
+
+func TestApplyMask(t *testing.T) {
+	tests := []struct {
+		input string
+		mask  string
+		want  string
+	}{
+		{"123456789", "###-##-####", "123-45-6789"},
+		{"12345", "###-##-####", "123-45"},
+		{"123", "###-##-####", "123"},
+		{"123 45 6789", "###-##-####", "123-45-6789"},
+		{"123-45-6789", "###-##-####", "123-45-6789"},
+		{"1234567890123", "###-##-####", "123-45-6789"},
+		{"4155552671", "(###) ###-####", "(415) 555-2671"},
+		{"ab12", "AA-##", "ab-12"},
+		{"123", "(###)", "(123)"},
+		{"12", "(###)", "(12"},
+		{"12345", "###.##%", "123.45%"},
+		{"1234", "###.##%", "123.4"},
+		{"", "###-##-####", ""},
+	}
+	for _, tt := range tests {
+		got := ApplyMask(tt.input, tt.mask)
+		if got != tt.want {
+			t.Errorf("ApplyMask(%q, %q) = %q, want %q", tt.input, tt.mask, got, tt.want)
+		}
+	}
+
+	if got := ApplyMask("123456789", "###-##-####"); !MatchesMask(got, "###-##-####") {
+		t.Errorf("MatchesMask(ApplyMask(9 digits)) = false for %q", got)
+	}
+	if got := ApplyMask("12345678", "###-##-####"); MatchesMask(got, "###-##-####") {
+		t.Errorf("MatchesMask(ApplyMask(8 digits)) = true for %q", got)
+	}
+	if got := ApplyMask("123", "(###)"); !MatchesMask(got, "(###)") {
+		t.Errorf("MatchesMask(ApplyMask(trailing literal)) = false for %q", got)
+	}
+}